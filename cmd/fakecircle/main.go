@@ -0,0 +1,123 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+// Command fakecircle serves the in-memory fake CircleCI API used by the
+// provider's tests, so the provider can be run against it by hand:
+//
+//	go run ./cmd/fakecircle -seed seed.yml -dump state.json
+//
+// On startup it prints the CIRCLE_HOST, CIRCLE_RUNNER_HOST and CIRCLE_TOKEN
+// settings that point the provider at it. The seed file is YAML or JSON in the
+// format of fakecircle.Seed. On SIGINT or SIGTERM the fake's state is written
+// to the dump file as JSON, in the same format, so it can seed a later run.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "fakecircle:", err)
+		os.Exit(1)
+	}
+}
+
+// run serves the fake until ctx is done.
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("fakecircle", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "address to listen on")
+	token := fs.String("token", "fakecircle-token", "API token the fake accepts")
+	seedFile := fs.String("seed", "", "YAML or JSON file of state to start with")
+	dumpFile := fs.String("dump", "", "file to write the state to as JSON on exit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fc := fakecircle.New(*token)
+	// Nothing reads the journal of a served fake, so it would only grow.
+	fc.SetRecording(false)
+	if *seedFile != "" {
+		if err := loadSeed(fc, *seedFile); err != nil {
+			return err
+		}
+	}
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+
+	host := "http://" + l.Addr().String()
+	_, _ = fmt.Fprintf(stdout, "CIRCLE_HOST=%s/api/v2\nCIRCLE_RUNNER_HOST=%s\nCIRCLE_TOKEN=%s\n", host, host, *token)
+
+	srv := &http.Server{
+		Handler:           fc,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(l) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	if *dumpFile != "" {
+		return dump(fc, *dumpFile)
+	}
+	return nil
+}
+
+func loadSeed(fc *fakecircle.Service, name string) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML, so one decoder handles both.
+	var seed fakecircle.Seed
+	if err := yaml.Unmarshal(b, &seed); err != nil {
+		return fmt.Errorf("parse seed %s: %w", name, err)
+	}
+	if err := fc.LoadSeed(seed); err != nil {
+		return fmt.Errorf("load seed %s: %w", name, err)
+	}
+	return nil
+}
+
+func dump(fc *fakecircle.Service, name string) error {
+	b, err := json.MarshalIndent(fc.Dump(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(b, '\n'), 0o600)
+}
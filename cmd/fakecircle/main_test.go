@@ -0,0 +1,170 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	circlecontext "terraform-provider-circleci/internal/circleci/context"
+	"terraform-provider-circleci/internal/circleci/envcontext"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+const seedYAML = `
+orgs:
+  - id: 2b8c7f7e-5d1a-4c3e-9f0b-6a4d2e1c0b9a
+    type: circleci
+    name: seeded org
+    contexts:
+      - id: 7e6d5c4b-3a29-4f18-8e07-d6c5b4a39281
+        name: seeded context
+        env_vars:
+          FOO: foo
+    oidc_claims:
+      audience: [seeded]
+    policies:
+      seeded: "package org"
+    policy_decisions_enabled: true
+    policy_decisions:
+      - status: PASS
+        branch: main
+    projects:
+      - name: seeded project
+        settings:
+          autocancel_builds: true
+        pipelines:
+          - name: seeded pipeline
+            config_source:
+              provider: github_app
+              repo: {full_name: seeded/repo, external_id: "1"}
+              file_path: .circleci/config.yml
+            checkout_source:
+              provider: github_app
+              repo: {full_name: seeded/repo, external_id: "1"}
+            triggers:
+              - event_source:
+                  provider: schedule
+                  cron_expression: "0 0 * * *"
+                  attribution_actor: system
+                event_name: nightly
+                checkout_ref: main
+                config_ref: main
+                parameters: {deploy: "yes"}
+        webhooks:
+          - name: seeded webhook
+            url: https://example.com/hook
+            signing_secret: secret
+            events: [workflow-completed]
+        checkout_keys:
+          - type: deploy-key
+        ssh_keys:
+          - hostname: example.com
+            private_key: |
+              %s
+resource_classes:
+  - resource_class: seeded-org/runner
+    description: seeded
+    tokens:
+      - nickname: seeded token
+runners:
+  - name: runner-1
+    resource_class: seeded-org/runner
+`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	seedFile := filepath.Join(dir, "seed.yml")
+	dumpFile := filepath.Join(dir, "dump.json")
+	key, err := fakecircle.NewSSHPrivateKey()
+	assert.NilError(t, err)
+	seed := fmt.Sprintf(seedYAML, strings.ReplaceAll(strings.TrimSpace(key), "\n", "\n              "))
+	assert.NilError(t, os.WriteFile(seedFile, []byte(seed), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- run(ctx, []string{"-addr", "127.0.0.1:0", "-token", "test-token", "-seed", seedFile, "-dump", dumpFile}, pw)
+	}()
+
+	env := map[string]string{}
+	sc := bufio.NewScanner(pr)
+	for len(env) < 3 && sc.Scan() {
+		k, v, _ := strings.Cut(sc.Text(), "=")
+		env[k] = v
+	}
+	go func() { _, _ = io.Copy(io.Discard, pr) }()
+	assert.Check(t, cmp.Equal(env["CIRCLE_TOKEN"], "test-token"))
+	assert.Check(t, strings.HasSuffix(env["CIRCLE_HOST"], "/api/v2"))
+
+	c := client.NewClient(env["CIRCLE_HOST"], env["CIRCLE_TOKEN"], "terraform-provider-circleci/test")
+
+	got, err := circlecontext.NewContextService(c).Get(ctx, "7e6d5c4b-3a29-4f18-8e07-d6c5b4a39281")
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Equal(got.Name, "seeded context"))
+
+	_, err = envcontext.NewEnvService(c).Create(ctx, got.ID, "bar", "BAR")
+	assert.Assert(t, err)
+
+	cancel()
+	assert.NilError(t, <-errc)
+
+	b, err := os.ReadFile(dumpFile)
+	assert.NilError(t, err)
+	var dumped fakecircle.Seed
+	assert.NilError(t, json.Unmarshal(b, &dumped))
+
+	assert.Assert(t, cmp.Len(dumped.Orgs, 1))
+	org := dumped.Orgs[0]
+	assert.Check(t, cmp.Equal(org.ID.String(), "2b8c7f7e-5d1a-4c3e-9f0b-6a4d2e1c0b9a"))
+	assert.Assert(t, cmp.Len(org.Contexts, 1))
+	assert.Check(t, cmp.DeepEqual(org.Contexts[0].EnvVars, map[string]string{"FOO": "foo", "BAR": "bar"}))
+	assert.Assert(t, cmp.Len(org.Projects, 1))
+	assert.Check(t, org.Projects[0].Settings.AutocancelBuilds)
+	assert.Check(t, cmp.DeepEqual(org.OIDCClaims.Audience, []string{"seeded"}))
+	assert.Check(t, cmp.DeepEqual(org.Policies, map[string]string{"seeded": "package org"}))
+	assert.Check(t, org.PolicyDecisionsEnabled)
+	assert.Check(t, cmp.Len(org.PolicyDecisions, 1))
+	prj := org.Projects[0]
+	assert.Assert(t, cmp.Len(prj.Pipelines, 1))
+	assert.Assert(t, cmp.Len(prj.Pipelines[0].Triggers, 1))
+	assert.Check(t, cmp.Equal(prj.Pipelines[0].Triggers[0].EventSource.AttributionActor, "system"))
+	assert.Check(t, cmp.Len(prj.Webhooks, 1))
+	assert.Assert(t, cmp.Len(prj.CheckoutKeys, 1))
+	assert.Check(t, prj.CheckoutKeys[0].Fingerprint != "")
+	assert.Assert(t, cmp.Len(prj.SSHKeys, 1))
+	assert.Check(t, cmp.Equal(prj.SSHKeys[0].PrivateKey, ""))
+	assert.Check(t, prj.SSHKeys[0].Fingerprint != "")
+	assert.Assert(t, cmp.Len(dumped.ResourceClasses, 1))
+	assert.Check(t, cmp.Len(dumped.ResourceClasses[0].Tokens, 1))
+	assert.Check(t, cmp.Len(dumped.Runners, 1))
+
+	t.Run("reload_dump", func(t *testing.T) {
+		fc := fakecircle.New("test-token")
+		assert.NilError(t, loadSeed(fc, dumpFile))
+		assert.Check(t, cmp.DeepEqual(fc.Dump(), dumped))
+	})
+}
+
+func TestRun_BadSeed(t *testing.T) {
+	seedFile := filepath.Join(t.TempDir(), "seed.yml")
+	assert.NilError(t, os.WriteFile(seedFile, []byte("orgs:\n  - name: a\n    type: circleci\n  - name: a\n    type: circleci\n"), 0o600))
+
+	err := run(context.Background(), []string{"-addr", "127.0.0.1:0", "-seed", seedFile}, io.Discard)
+	assert.Check(t, cmp.ErrorContains(err, `org "a"`))
+}
@@ -0,0 +1,81 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package checkout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+// The types of checkout key.
+const (
+	// TypeDeployKey is a key added to the repository, with read-only
+	// access to it alone.
+	TypeDeployKey = "deploy-key"
+	// TypeUserKey is a key added to the user whose token created it, with
+	// their access to every repository.
+	TypeUserKey = "user-key"
+)
+
+// Key represents a project checkout key.
+type Key struct {
+	PublicKey   string    `json:"public-key,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	Preferred   bool      `json:"preferred"`
+	CreatedAt   time.Time `json:"created-at"`
+}
+
+// Service manages the checkout keys of projects.
+type Service struct {
+	client *client.Client
+}
+
+// NewService returns a Service that uses c.
+func NewService(c *client.Client) *Service {
+	return &Service{client: c}
+}
+
+// List returns the project's checkout keys.
+func (s *Service) List(ctx context.Context, projectSlug string) (_ []Key, err error) {
+	ctx = client.WithProject(ctx, projectSlug)
+	return client.Collect(client.Paginate[Key](ctx, s.client, fmt.Sprintf("/project/%s/checkout-key", projectSlug)))
+}
+
+// Create creates a checkout key of the given type, TypeDeployKey or
+// TypeUserKey, for the project. CircleCI generates the keypair.
+func (s *Service) Create(ctx context.Context, projectSlug, keyType string) (_ *Key, err error) {
+	ctx = client.WithProject(ctx, projectSlug)
+	payload := map[string]string{
+		"type": keyType,
+	}
+	var key Key
+	_, err = s.client.RequestHelper(ctx, http.MethodPost, fmt.Sprintf("/project/%s/checkout-key", projectSlug), payload, &key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Get returns the project's checkout key with the given fingerprint.
+func (s *Service) Get(ctx context.Context, projectSlug, fingerprint string) (_ *Key, err error) {
+	ctx = client.WithProject(ctx, projectSlug)
+	var key Key
+	_, err = s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/project/%s/checkout-key/%s", projectSlug, fingerprint), nil, &key)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Delete deletes the project's checkout key with the given fingerprint.
+func (s *Service) Delete(ctx context.Context, projectSlug, fingerprint string) (err error) {
+	ctx = client.WithProject(ctx, projectSlug)
+	_, err = s.client.RequestHelper(ctx, http.MethodDelete, fmt.Sprintf("/project/%s/checkout-key/%s", projectSlug, fingerprint), nil, nil)
+	return err
+}
@@ -0,0 +1,110 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package checkout_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/checkout"
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+const testTok = "9d1b6f3e-27a4-4c8e-b5d0-3e6f8a1c2b4d"
+
+func setup(t *testing.T) (*fakecircle.Service, *checkout.Service, fakecircle.Project) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+
+	return fc, checkout.NewService(c), prj
+}
+
+func TestService(t *testing.T) {
+	ctx := context.TODO()
+	_, cs, prj := setup(t)
+
+	var created *checkout.Key
+	t.Run("create", func(t *testing.T) {
+		var err error
+		created, err = cs.Create(ctx, prj.Slug, checkout.TypeDeployKey)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(created.Type, checkout.TypeDeployKey))
+		assert.Check(t, strings.HasPrefix(created.PublicKey, "ssh-ed25519 "))
+		assert.Check(t, cmp.Len(strings.Split(created.Fingerprint, ":"), 16))
+		assert.Check(t, created.Preferred)
+		assert.Check(t, !created.CreatedAt.IsZero())
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := cs.Get(ctx, prj.Slug, created.Fingerprint)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(got, created))
+	})
+
+	t.Run("user_key_preferred", func(t *testing.T) {
+		user, err := cs.Create(ctx, prj.Slug, checkout.TypeUserKey)
+		assert.Assert(t, err)
+		assert.Check(t, user.Preferred)
+
+		keys, err := cs.List(ctx, prj.Slug)
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(keys, 2))
+		assert.Check(t, cmp.Equal(keys[0].Fingerprint, created.Fingerprint))
+		assert.Check(t, !keys[0].Preferred)
+		assert.Check(t, keys[1].Preferred)
+	})
+
+	t.Run("invalid_type", func(t *testing.T) {
+		_, err := cs.Create(ctx, prj.Slug, "github-app-key")
+		assert.Check(t, cmp.ErrorContains(err, "type must be one of"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := cs.Delete(ctx, prj.Slug, created.Fingerprint)
+		assert.Assert(t, err)
+
+		_, err = cs.Get(ctx, prj.Slug, created.Fingerprint)
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
+
+func TestService_List(t *testing.T) {
+	ctx := context.TODO()
+	fc, cs, prj := setup(t)
+	fc.SetPageSize(2)
+
+	for range 5 {
+		_, err := fc.AddCheckoutKey(prj.ID, fakecircle.CheckoutKeyTypeDeploy)
+		assert.Assert(t, err)
+	}
+
+	got, err := cs.List(ctx, prj.Slug)
+	assert.Assert(t, err)
+	assert.Assert(t, cmp.Len(got, 5))
+	for i, k := range got {
+		assert.Check(t, cmp.Equal(k.Preferred, i == 4), "key %d", i)
+	}
+}
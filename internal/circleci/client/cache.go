@@ -0,0 +1,152 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// readCache holds the bodies of successful GET responses, keyed by URL and
+// the token they were fetched with, for a short time. Concurrent GETs of the
+// same URL with the same token share one request.
+//
+// A write to a collection drops every cached response from that collection,
+// so a client never reads back anything older than its own last write.
+type readCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// generations counts the writes to each collection, so a GET that was
+	// in flight during a write does not cache what it read before it.
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	collection string
+	status     int
+	body       []byte
+	expires    time.Time
+}
+
+// cachedResponse is a response shared between callers. body must not be
+// modified.
+type cachedResponse struct {
+	status int
+	body   []byte
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:         ttl,
+		entries:     make(map[string]cacheEntry),
+		generations: make(map[string]uint64),
+	}
+}
+
+// get returns the response cached for rawURL and token, or calls fetch for
+// it. Only one fetch of a URL with a token runs at a time; callers that ask
+// for it meanwhile get the same result. shared reports whether the response
+// came from the cache or another caller's fetch.
+//
+// fetch is given ctx without its cancellation, as its result is shared: a
+// caller whose ctx is done returns its error straight away, and leaves the
+// fetch to finish for the others.
+func (c *readCache) get(ctx context.Context, token, rawURL string, fetch func(context.Context) (cachedResponse, error)) (_ cachedResponse, shared bool, err error) {
+	collection := cacheCollection(rawURL)
+	key := cacheKey(token, rawURL)
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && time.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return cachedResponse{status: e.status, body: e.body}, true, nil
+	}
+	c.mu.Unlock()
+
+	fetchCtx := context.WithoutCancel(ctx)
+	ch := c.group.DoChan(key, func() (any, error) {
+		c.mu.Lock()
+		gen := c.generations[collection]
+		c.mu.Unlock()
+
+		res, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generations[collection] == gen {
+			c.entries[key] = cacheEntry{
+				collection: collection,
+				status:     res.status,
+				body:       res.body,
+				expires:    time.Now().Add(c.ttl),
+			}
+		}
+		return res, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return cachedResponse{}, false, ctx.Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return cachedResponse{}, false, r.Err
+		}
+		return r.Val.(cachedResponse), r.Shared, nil
+	}
+}
+
+// invalidate drops the cached responses from the collection rawURL is in.
+func (c *readCache) invalidate(rawURL string) {
+	collection := cacheCollection(rawURL)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[collection]++
+	for k, e := range c.entries {
+		if e.collection == collection {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// cacheKey keys a response by a hash of the token it was fetched with as
+// well as its URL, so a response is never served to a caller that would
+// have made the request with another token.
+func cacheKey(token, rawURL string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:]) + " " + rawURL
+}
+
+var apiVersionPrefix = regexp.MustCompile(`^/api/v\d+(\.\d+)?`)
+
+// cacheCollection returns the collection rawURL belongs to: its host and the
+// first path segment after the API version, such as "/context" for
+// "/api/v2/context/{id}/environment-variable". Lists and the objects in them
+// share a collection, so writing an object drops both.
+func cacheCollection(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	p := u.Path
+	version := apiVersionPrefix.FindString(p)
+	p = strings.TrimPrefix(p, version)
+	first, _, _ := strings.Cut(strings.TrimPrefix(p, "/"), "/")
+	return u.Host + version + "/" + first
+}
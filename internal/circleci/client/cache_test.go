@@ -0,0 +1,245 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestClient_Cache(t *testing.T) {
+	const testTok = "CCIPAT_0f1e2d3c-4b5a-4968-8776-5a4b3c2d1e0f"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	org, err := fs.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "cache",
+	})
+	assert.Assert(t, err)
+	orgCtx, err := fs.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "cache",
+	})
+	assert.Assert(t, err)
+	envPath := "/context/" + orgCtx.ID.String() + "/environment-variable"
+
+	newClient := func(ttl time.Duration) *client.Client {
+		return client.NewClientWithOptions(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test", client.Options{
+			RetryMaxWait: 10 * time.Millisecond,
+			CacheTTL:     ttl,
+		})
+	}
+	listEnv := func(t *testing.T, c *client.Client) []string {
+		t.Helper()
+		var res struct {
+			Items []struct {
+				Variable string `json:"variable"`
+			} `json:"items"`
+		}
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, envPath, nil, &res)
+		assert.Assert(t, err)
+		names := []string{}
+		for _, item := range res.Items {
+			names = append(names, item.Variable)
+		}
+		return names
+	}
+
+	t.Run("hit", func(t *testing.T) {
+		fs.ResetCalls()
+		c := newClient(time.Minute)
+
+		assert.Check(t, cmp.DeepEqual(listEnv(t, c), []string{}))
+		assert.Check(t, cmp.DeepEqual(listEnv(t, c), []string{}))
+		fs.AssertCallCount(t, http.MethodGet, "/context/*/environment-variable", 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		fs.ResetCalls()
+		c := newClient(0)
+
+		listEnv(t, c)
+		listEnv(t, c)
+		fs.AssertCallCount(t, http.MethodGet, "/context/*/environment-variable", 2)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		fs.ResetCalls()
+		c := newClient(10 * time.Millisecond)
+
+		listEnv(t, c)
+		time.Sleep(20 * time.Millisecond)
+		listEnv(t, c)
+		fs.AssertCallCount(t, http.MethodGet, "/context/*/environment-variable", 2)
+	})
+
+	t.Run("invalidated_by_write", func(t *testing.T) {
+		fs.ResetCalls()
+		c := newClient(time.Minute)
+
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/me", nil, nil)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(listEnv(t, c), []string{}))
+
+		_, err = c.RequestHelper(context.TODO(), http.MethodPut, envPath+"/FOO", map[string]string{"value": "bar"}, nil)
+		assert.Assert(t, err)
+
+		assert.Check(t, cmp.DeepEqual(listEnv(t, c), []string{"FOO"}))
+		fs.AssertCallCount(t, http.MethodGet, "/context/*/environment-variable", 2)
+
+		// Other collections are left alone.
+		_, err = c.RequestHelper(context.TODO(), http.MethodGet, "/me", nil, nil)
+		assert.Assert(t, err)
+		fs.AssertCallCount(t, http.MethodGet, "/me", 1)
+	})
+
+	t.Run("errors_not_cached", func(t *testing.T) {
+		fs.ResetCalls()
+		c := newClient(time.Minute)
+
+		for range 2 {
+			_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/context/00000000-0000-0000-0000-000000000000", nil, nil)
+			assert.Check(t, client.IsNotFound(err))
+		}
+		fs.AssertCallCount(t, http.MethodGet, "/context/*", 2)
+	})
+}
+
+func TestClient_CacheCoalesces(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			requests.Add(1)
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"shared"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := client.NewClientWithOptions(srv.URL, "", "terraform-provider-circleci/test", client.Options{CacheTTL: time.Minute})
+
+	const callers = 5
+	var wg sync.WaitGroup
+	names := make([]string, callers)
+	for i := range callers {
+		wg.Go(func() {
+			var res struct {
+				Name string `json:"name"`
+			}
+			_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/context/1", nil, &res)
+			assert.Check(t, err)
+			names[i] = res.Name
+		})
+	}
+
+	// Let the callers pile up behind the first request before it returns.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Check(t, cmp.Equal(requests.Load(), int32(1)))
+	for _, name := range names {
+		assert.Check(t, cmp.Equal(name, "shared"))
+	}
+}
+
+// TestClient_CacheWriteDuringRead checks that a GET answered before a write
+// to its collection, but returned after it, is not cached.
+func TestClient_CacheWriteDuringRead(t *testing.T) {
+	var requests atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && requests.Add(1) == 1 {
+			started <- struct{}{}
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := client.NewClientWithOptions(srv.URL, "", "terraform-provider-circleci/test", client.Options{CacheTTL: time.Minute})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/context/1", nil, nil)
+		assert.Check(t, err)
+	}()
+
+	<-started
+	_, err := c.RequestHelper(context.TODO(), http.MethodDelete, "/context/1", nil, nil)
+	assert.Assert(t, err)
+	close(release)
+	<-done
+
+	_, err = c.RequestHelper(context.TODO(), http.MethodGet, "/context/1", nil, nil)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Equal(requests.Load(), int32(2)))
+}
+
+// TestClient_CacheCancelledCaller checks that cancelling the caller whose
+// GET is shared does not fail the others waiting on it.
+func TestClient_CacheCancelledCaller(t *testing.T) {
+	var requests atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		started <- struct{}{}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"shared"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := client.NewClientWithOptions(srv.URL, "", "terraform-provider-circleci/test", client.Options{CacheTTL: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.RequestHelper(ctx, http.MethodGet, "/context/1", nil, nil)
+		firstErr <- err
+	}()
+	<-started
+
+	var name string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var res struct {
+			Name string `json:"name"`
+		}
+		_, err := c.RequestHelper(context.Background(), http.MethodGet, "/context/1", nil, &res)
+		assert.Check(t, err)
+		name = res.Name
+	}()
+
+	// Let the second caller join the first's request before cancelling it.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.Check(t, cmp.ErrorIs(<-firstErr, context.Canceled))
+
+	close(release)
+	<-done
+	assert.Check(t, cmp.Equal(name, "shared"))
+	assert.Check(t, cmp.Equal(requests.Load(), int32(1)))
+}
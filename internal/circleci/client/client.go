@@ -6,55 +6,261 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	"terraform-provider-circleci/internal/circleci/closer"
 )
 
+const (
+	// DefaultMaxRetries is how many times NewClient retries a failed request.
+	DefaultMaxRetries = 10
+	// DefaultRetryMaxWait is the longest NewClient waits between retries.
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// Options configure a Client made with NewClientWithOptions.
+type Options struct {
+	// MaxRetries is how many times a request that failed with a 429, a 5xx
+	// or a connection error is retried. Zero disables retries.
+	MaxRetries int
+	// RetryMaxWait caps the wait between retries. It also caps a wait asked
+	// for by the API's Retry-After or X-RateLimit-Reset headers. Zero means
+	// DefaultRetryMaxWait.
+	RetryMaxWait time.Duration
+	// Limiter, if set, paces every request the client makes, retries
+	// included. Share one between clients to pace them together.
+	Limiter *Limiter
+	// MaxPages caps how many pages of a list Paginate fetches. Zero means
+	// DefaultMaxPages.
+	MaxPages int
+	// TLSConfig, if set, replaces the TLS settings of every connection, e.g.
+	// to trust a private CA or to present a client certificate.
+	TLSConfig *tls.Config
+	// ProxyURL, if set, is the proxy every request goes through. Otherwise
+	// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply.
+	ProxyURL *url.URL
+	// TracerProvider and MeterProvider, if set, receive a span for each
+	// request and counts of requests, retries and 429s.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	// CacheTTL, if set, is how long the bodies of successful GET responses
+	// are kept and reused. Concurrent GETs of the same URL are then made
+	// once, and any write drops the cached responses from the collection it
+	// is in.
+	CacheTTL time.Duration
+	// TokenSource, if set, supplies the token for each request in place of
+	// the one the client was made with, so a token rotated while the client
+	// is in use is picked up.
+	TokenSource TokenSource
+	// Credentials maps org IDs and slugs to the token used for requests made
+	// for that org with WithOrganization or WithProject.
+	Credentials map[string]string
+}
+
+// TokenSource supplies the API token for a request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenFunc adapts a function to a TokenSource.
+type TokenFunc func(ctx context.Context) (string, error)
+
+func (f TokenFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
 type Client struct {
-	baseURL   string
-	client    *retryablehttp.Client
-	authToken string
-	userAgent string
+	baseURL     string
+	client      *retryablehttp.Client
+	authToken   string
+	tokenSource TokenSource
+	credentials map[string]string
+	userAgent   string
+	maxPages    int
+	cache       *readCache
+	telemetry   *telemetry
 }
 
 func NewClient(baseURL, authToken, userAgent string) *Client {
+	return NewClientWithOptions(baseURL, authToken, userAgent, Options{
+		MaxRetries:   DefaultMaxRetries,
+		RetryMaxWait: DefaultRetryMaxWait,
+	})
+}
+
+func NewClientWithOptions(baseURL, authToken, userAgent string, opts Options) *Client {
+	// Without a cap the retries would not back off at all.
+	retryMaxWait := opts.RetryMaxWait
+	if retryMaxWait <= 0 {
+		retryMaxWait = DefaultRetryMaxWait
+	}
+
 	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 10
+	retryClient.RetryMax = opts.MaxRetries
+	retryClient.RetryWaitMin = min(retryClient.RetryWaitMin, retryMaxWait)
+	retryClient.RetryWaitMax = retryMaxWait
+	retryClient.Backoff = backoff
+	// Requests are logged through tflog instead, with secrets redacted.
+	retryClient.Logger = nil
+	tel := newTelemetry(opts.TracerProvider, opts.MeterProvider)
+	retryClient.RequestLogHook = func(l retryablehttp.Logger, req *http.Request, attempt int) {
+		logRetry(l, req, attempt)
+		tel.retryHook(l, req, attempt)
+	}
+	retryClient.ResponseLogHook = tel.responseHook
+	// Hand the final response back once retries are exhausted, rather than a
+	// generic "giving up" error, so its status still reaches the caller.
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	if transport, ok := retryClient.HTTPClient.Transport.(*http.Transport); ok {
+		if opts.TLSConfig != nil {
+			transport.TLSClientConfig = opts.TLSConfig
+		}
+		if opts.ProxyURL != nil {
+			transport.Proxy = http.ProxyURL(opts.ProxyURL)
+		}
+	}
+
+	if opts.Limiter != nil {
+		retryClient.HTTPClient.Transport = &rateLimitTransport{
+			base:    retryClient.HTTPClient.Transport,
+			limiter: opts.Limiter,
+			maxWait: retryMaxWait,
+		}
+	}
+
+	maxPages := opts.MaxPages
+	if maxPages == 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	credentials := make(map[string]string, len(opts.Credentials))
+	for org, tok := range opts.Credentials {
+		credentials[credentialKey(org)] = tok
+	}
+
+	var cache *readCache
+	if opts.CacheTTL > 0 {
+		cache = newReadCache(opts.CacheTTL)
+	}
 
 	return &Client{
-		baseURL:   baseURL,
-		client:    retryClient,
-		authToken: authToken,
-		userAgent: userAgent,
+		baseURL:     baseURL,
+		client:      retryClient,
+		authToken:   authToken,
+		tokenSource: opts.TokenSource,
+		credentials: credentials,
+		userAgent:   userAgent,
+		maxPages:    maxPages,
+		cache:       cache,
+		telemetry:   tel,
+	}
+}
+
+// backoff waits as long as the API asked for, when it said, and otherwise
+// backs off exponentially. Either way the wait is capped at maxWait.
+func backoff(minWait, maxWait time.Duration, attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := rateLimitWait(res, time.Now()); ok {
+			return min(wait, maxWait)
+		}
 	}
+	return retryablehttp.DefaultBackoff(minWait, maxWait, attempt, nil)
 }
 
 func (c *Client) request(ctx context.Context, url, method string, body, respBody any) (_ *Response, err error) {
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting API token: %w", err)
+	}
+
+	var status int
+	var b []byte
+	switch {
+	case c.cache == nil:
+		status, b, err = c.send(ctx, token, url, method, body)
+	case method == http.MethodGet && body == nil:
+		var res cachedResponse
+		var shared bool
+		res, shared, err = c.cache.get(ctx, token, url, func(ctx context.Context) (cachedResponse, error) {
+			status, b, err := c.send(ctx, token, url, method, nil)
+			return cachedResponse{status: status, body: b}, err
+		})
+		if shared {
+			logCacheHit(ctx, url)
+		}
+		status, b = res.status, res.body
+	default:
+		status, b, err = c.send(ctx, token, url, method, body)
+		// Drop cached reads even when the write failed, as it may have been
+		// applied before the error.
+		c.cache.invalidate(url)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if respBody != nil {
+		// Pass the byte slice to Unmarshal instead of the Reader to Decoder
+		if err := json.Unmarshal(b, respBody); err != nil {
+			return nil, fmt.Errorf("error decoding response body: %s: %w", string(b), err)
+		}
+	}
+
+	return &Response{
+		StatusCode: status,
+	}, nil
+}
+
+// send makes a request with token and returns the status and body of a
+// successful response, or an *APIError for an error status.
+func (c *Client) send(ctx context.Context, token, url, method string, body any) (status int, b []byte, err error) {
+	ctx, state, span := c.telemetry.startRequest(ctx, method, url)
+	defer func() {
+		c.telemetry.endRequest(ctx, state, span, status, err)
+	}()
+
+	return c.do(ctx, token, url, method, body)
+}
+
+func (c *Client) do(ctx context.Context, token, url, method string, body any) (_ int, _ []byte, err error) {
 	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		jsonData, err = json.Marshal(body)
 		if err != nil {
-			return nil, err
+			return 0, nil, err
 		}
 		reqBody = bytes.NewBuffer(jsonData)
 	}
 	req, err := retryablehttp.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Circle-Token", c.authToken)
+	req.Header.Set("Circle-Token", token)
 
+	logRequest(ctx, req, jsonData)
+	start := time.Now()
 	res, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		if res != nil {
+			_ = res.Body.Close()
+		}
+		logRequestError(ctx, req, err, time.Since(start))
+		return 0, nil, err
 	}
 
 	defer closer.ErrorHandler(res.Body, &err)
@@ -63,29 +269,18 @@ func (c *Client) request(ctx context.Context, url, method string, body, respBody
 		_, _ = io.Copy(io.Discard, res.Body)
 	}()
 
-	if res.StatusCode >= 400 {
-		body, err := io.ReadAll(res.Body)
-		if err != nil {
-			return nil, fmt.Errorf("error Reading response: %w", err)
-		}
-		return nil, fmt.Errorf("%s: %s", res.Status, string(body))
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		logRequestError(ctx, req, err, time.Since(start))
+		return 0, nil, fmt.Errorf("error reading response: %w", err)
 	}
+	logResponse(ctx, req, res, b, time.Since(start))
 
-	if respBody != nil {
-		b, err := io.ReadAll(res.Body) // Body is now empty
-		if err != nil {
-			return nil, fmt.Errorf("error reading body: %s: %w", string(b), err)
-		}
-
-		// Pass the byte slice to Unmarshal instead of the Reader to Decoder
-		if err := json.Unmarshal(b, respBody); err != nil {
-			return nil, fmt.Errorf("error decoding response body: %s: %w", string(b), err)
-		}
+	if res.StatusCode >= 400 {
+		return 0, nil, newAPIError(res, b)
 	}
 
-	return &Response{
-		StatusCode: res.StatusCode,
-	}, nil
+	return res.StatusCode, b, nil
 }
 
 type Response struct {
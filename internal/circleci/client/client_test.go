@@ -4,11 +4,19 @@
 package client_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/assert/cmp"
 
@@ -136,3 +144,531 @@ func TestClient_SetsHeaders(t *testing.T) {
 	assert.Check(t, cmp.Equal(gotToken, testTok))
 	assert.Check(t, cmp.Equal(gotAccept, "application/json"))
 }
+
+func TestClient_TokenSource(t *testing.T) {
+	var gotTokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTokens = append(gotTokens, r.Header.Get("Circle-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	tokens := []string{"first", "second"}
+	c := client.NewClientWithOptions(srv.URL, "unused", "terraform-provider-circleci/test", client.Options{
+		TokenSource: client.TokenFunc(func(context.Context) (string, error) {
+			if len(tokens) == 0 {
+				return "", errors.New("no more tokens")
+			}
+			tok := tokens[0]
+			tokens = tokens[1:]
+			return tok, nil
+		}),
+	})
+
+	for range 2 {
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/whatever", nil, nil)
+		assert.Assert(t, err)
+	}
+	assert.Check(t, cmp.DeepEqual(gotTokens, []string{"first", "second"}))
+
+	_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/whatever", nil, nil)
+	assert.Check(t, cmp.ErrorContains(err, "no more tokens"))
+	assert.Check(t, cmp.Len(gotTokens, 2))
+}
+
+func TestClient_APIError(t *testing.T) {
+	const testTok = "CCIPAT_2b7c9e4d-61a0-4f3e-9d85-0c6f1e2a7b34"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	t.Run("not_found", func(t *testing.T) {
+		ctx := context.TODO()
+		res, err := c.RequestHelper(ctx, http.MethodGet, "/context/3c1c3a0e-5d87-4f51-a0ab-34f4ba6b5d2a", nil, nil)
+		assert.Check(t, cmp.Nil(res))
+		assert.Check(t, client.IsNotFound(err))
+		assert.Check(t, !client.IsConflict(err))
+		assert.Check(t, !client.IsRateLimited(err))
+
+		var apiErr *client.APIError
+		assert.Assert(t, errors.As(err, &apiErr))
+		assert.Check(t, cmp.Equal(apiErr.StatusCode, http.StatusNotFound))
+		assert.Check(t, cmp.Equal(apiErr.Message, "context not found"))
+		assert.Check(t, cmp.Equal(apiErr.Method, http.MethodGet))
+		assert.Check(t, cmp.Equal(apiErr.Path, "/api/v2/context/3c1c3a0e-5d87-4f51-a0ab-34f4ba6b5d2a"))
+		assert.Check(t, apiErr.RequestID != "")
+		assert.Check(t, cmp.ErrorContains(err, "GET /api/v2/context/3c1c3a0e-5d87-4f51-a0ab-34f4ba6b5d2a: 404 Not Found: context not found"))
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		ctx := context.TODO()
+		_, err := c.RequestHelper(ctx, http.MethodGet, "/context/3c1c3a0e-5d87-4f51-a0ab-34f4ba6b5d2a", nil, nil)
+		err = fmt.Errorf("reading context: %w", err)
+		assert.Check(t, client.IsNotFound(err))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		ctx := context.TODO()
+		c := client.NewClient(srv.URL+"/api/v2", "not-valid", "terraform-provider-circleci/test")
+		_, err := c.RequestHelper(ctx, http.MethodGet, "/context/3c1c3a0e-5d87-4f51-a0ab-34f4ba6b5d2a", nil, nil)
+		assert.Check(t, client.IsUnauthorized(err))
+		assert.Check(t, !client.IsNotFound(err))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Check(t, !client.IsNotFound(nil))
+		assert.Check(t, !client.IsNotFound(errors.New("404 Not Found")))
+	})
+}
+
+func TestClient_APIErrorRawBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Circleci-Request-Id", "req-1234")
+		http.Error(w, "already exists", http.StatusConflict)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL, "tok", "terraform-provider-circleci/test")
+	_, err := c.RequestHelper(context.TODO(), http.MethodPost, "/thing?page-token=abc", map[string]any{}, nil)
+	assert.Check(t, client.IsConflict(err))
+
+	var apiErr *client.APIError
+	assert.Assert(t, errors.As(err, &apiErr))
+	assert.Check(t, cmp.Equal(apiErr.Message, "already exists"))
+	assert.Check(t, cmp.Equal(apiErr.Path, "/thing"))
+	assert.Check(t, cmp.Equal(apiErr.RequestID, "req-1234"))
+	assert.Check(t, cmp.Equal(err.Error(), "POST /thing: 409 Conflict: already exists (request id: req-1234)"))
+}
+
+func TestClient_Faults(t *testing.T) {
+	const testTok = "CCIPAT_7f3e1d2c-4b5a-4968-8776-5a4b3c2d1e0f"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL, testTok, "terraform-provider-circleci/test")
+	// Retry-After: 0 keeps retryablehttp from backing off between attempts.
+	noWait := http.Header{"Retry-After": {"0"}}
+
+	t.Run("retries_503", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:   "/api/test/hello",
+			Times:  3,
+			Status: http.StatusServiceUnavailable,
+			Header: noWait,
+		})
+
+		body := make(map[string]any)
+		res, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, &body)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(res.StatusCode, http.StatusOK))
+		assert.Check(t, cmp.Equal(fs.PendingFaults(), 0))
+	})
+
+	t.Run("gives_up", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:   "/api/test/hello",
+			Status: http.StatusServiceUnavailable,
+			Header: noWait,
+		})
+
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Check(t, client.HasStatus(err, http.StatusServiceUnavailable))
+		assert.Check(t, cmp.ErrorContains(err, "injected fault"))
+	})
+
+	t.Run("does_not_retry_400", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Method: http.MethodGet,
+			Path:   "/api/test/hello",
+			Times:  2,
+			Status: http.StatusBadRequest,
+		})
+
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Check(t, client.HasStatus(err, http.StatusBadRequest))
+		// Only one of the two failures was used up.
+		assert.Check(t, cmp.Equal(fs.PendingFaults(), 1))
+	})
+
+	t.Run("method_mismatch", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Method: http.MethodPost,
+			Path:   "/api/test/hello",
+			Status: http.StatusBadRequest,
+		})
+
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Check(t, err)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:    "/api/test/hello",
+			Times:   1,
+			Latency: 50 * time.Millisecond,
+		})
+
+		start := time.Now()
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Assert(t, err)
+		assert.Check(t, time.Since(start) >= 50*time.Millisecond)
+	})
+
+	t.Run("context_cancelled", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:    "/api/test/hello",
+			Latency: time.Minute,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		res, err := c.RequestHelper(ctx, http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Check(t, cmp.Nil(res))
+		assert.Check(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Check(t, time.Since(start) < 10*time.Second)
+	})
+
+	t.Run("malformed_body", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:          "/api/test/hello",
+			Times:         1,
+			MalformedBody: true,
+		})
+
+		body := make(map[string]any)
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, &body)
+		assert.Check(t, cmp.ErrorContains(err, "error decoding response body"))
+
+		var apiErr *client.APIError
+		assert.Check(t, !errors.As(err, &apiErr))
+	})
+
+	t.Run("drop_connection", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:           "/api/test/hello",
+			Times:          1,
+			DropConnection: true,
+		})
+
+		body := make(map[string]any)
+		res, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, &body)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(res.StatusCode, http.StatusOK))
+		assert.Check(t, cmp.Equal(fs.PendingFaults(), 0))
+	})
+}
+
+func TestClient_Journal(t *testing.T) {
+	const testTok = "CCIPAT_0e9d8c7b-6a5f-4e3d-8c2b-1a0f9e8d7c6b"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	ctx := context.TODO()
+	c := client.NewClient(srv.URL, testTok, "terraform-provider-circleci/test")
+	_, err := c.RequestHelper(ctx, http.MethodPost, "/api/test/echo?foo=bar", map[string]any{"a": "b"}, nil)
+	assert.Assert(t, err)
+
+	unauthed := client.NewClient(srv.URL, "", "terraform-provider-circleci/test")
+	_, err = unauthed.RequestHelper(ctx, http.MethodGet, "/api/test/hello", nil, nil)
+	assert.Check(t, client.IsUnauthorized(err))
+
+	const orgTok = "CCIPAT_5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d"
+	orgID := uuid.New()
+	fs.AddOrgToken(orgTok, orgID)
+	orgClient := client.NewClient(srv.URL, orgTok, "terraform-provider-circleci/test")
+	_, err = orgClient.RequestHelper(ctx, http.MethodGet, "/api/test/hello", nil, nil)
+	assert.Assert(t, err)
+
+	assert.Check(t, cmp.DeepEqual(fs.Calls(), []fakecircle.Call{
+		{
+			Method:        http.MethodPost,
+			Path:          "/api/test/echo",
+			Query:         url.Values{"foo": {"bar"}},
+			Body:          map[string]any{"a": "b"},
+			Authenticated: true,
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/test/hello",
+			Query:  url.Values{},
+		},
+		{
+			Method:        http.MethodGet,
+			Path:          "/api/test/hello",
+			Query:         url.Values{},
+			Authenticated: true,
+			TokenOrg:      orgID,
+		},
+	}))
+
+	assert.Check(t, cmp.Equal(fs.CallCount(http.MethodPost, "/api/test/*"), 1))
+	assert.Check(t, cmp.Equal(fs.CallCount("", "/api/test/*"), 3))
+	assert.Check(t, cmp.Equal(fs.CallCount(http.MethodDelete, ""), 0))
+
+	fs.ResetCalls()
+	assert.Check(t, cmp.Len(fs.Calls(), 0))
+
+	fs.SetRecording(false)
+	_, err = c.RequestHelper(ctx, http.MethodGet, "/api/test/hello", nil, nil)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Len(fs.Calls(), 0))
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("nil", func(t *testing.T) {
+		var l *client.Limiter
+		assert.Check(t, l.Wait(ctx))
+		l.PauseUntil(time.Now().Add(time.Hour))
+		assert.Check(t, l.Wait(ctx))
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		l := client.NewLimiter(20, 1)
+		start := time.Now()
+		for range 5 {
+			assert.Assert(t, l.Wait(ctx))
+		}
+		// The first request uses the burst; the other four wait 50ms each.
+		assert.Check(t, time.Since(start) >= 180*time.Millisecond)
+	})
+
+	t.Run("burst", func(t *testing.T) {
+		l := client.NewLimiter(1, 5)
+		start := time.Now()
+		for range 5 {
+			assert.Assert(t, l.Wait(ctx))
+		}
+		assert.Check(t, time.Since(start) < 500*time.Millisecond)
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		l := client.NewLimiter(0, 1)
+		l.PauseUntil(time.Now().Add(100 * time.Millisecond))
+		start := time.Now()
+		assert.Assert(t, l.Wait(ctx))
+		assert.Check(t, time.Since(start) >= 100*time.Millisecond)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		l := client.NewLimiter(0, 1)
+		l.PauseUntil(time.Now().Add(time.Minute))
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		assert.Check(t, errors.Is(l.Wait(ctx), context.DeadlineExceeded))
+	})
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	const testTok = "CCIPAT_5b4a3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	newClient := func(opts client.Options) *client.Client {
+		return client.NewClientWithOptions(srv.URL, testTok, "terraform-provider-circleci/test", opts)
+	}
+
+	t.Run("retry_after_capped", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:   "/api/test/hello",
+			Times:  1,
+			Status: http.StatusTooManyRequests,
+			Header: http.Header{"Retry-After": {"60"}},
+		})
+
+		c := newClient(client.Options{MaxRetries: 1, RetryMaxWait: 50 * time.Millisecond})
+		start := time.Now()
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Assert(t, err)
+		assert.Check(t, time.Since(start) < 10*time.Second)
+		assert.Check(t, cmp.Equal(fs.PendingFaults(), 0))
+	})
+
+	t.Run("ratelimit_reset", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:   "/api/test/hello",
+			Times:  1,
+			Status: http.StatusTooManyRequests,
+			Header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"0.2"},
+			},
+		})
+
+		c := newClient(client.Options{MaxRetries: 1, RetryMaxWait: 10 * time.Second})
+		start := time.Now()
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Assert(t, err)
+		assert.Check(t, time.Since(start) >= 200*time.Millisecond)
+	})
+
+	t.Run("no_retries", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.ResetCalls()
+		fs.InjectFault(fakecircle.Fault{
+			Path:   "/api/test/hello",
+			Times:  2,
+			Status: http.StatusTooManyRequests,
+			Header: http.Header{"Retry-After": {"0"}},
+		})
+
+		c := newClient(client.Options{RetryMaxWait: time.Second})
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Check(t, client.IsRateLimited(err))
+		assert.Check(t, cmp.Equal(fs.CallCount(http.MethodGet, "/api/test/hello"), 1))
+		assert.Check(t, cmp.Equal(fs.PendingFaults(), 1))
+	})
+
+	t.Run("zero_max_wait_backs_off", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		fs.InjectFault(fakecircle.Fault{
+			Path:   "/api/test/hello",
+			Times:  1,
+			Status: http.StatusServiceUnavailable,
+		})
+
+		// A zero RetryMaxWait falls back to the default rather than
+		// retrying straight away.
+		c := newClient(client.Options{MaxRetries: 1})
+		start := time.Now()
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Assert(t, err)
+		assert.Check(t, time.Since(start) >= 500*time.Millisecond)
+	})
+
+	t.Run("shared_limiter_paused", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		// A successful response that uses up the rate limit pauses every
+		// client sharing the limiter until the reset.
+		fs.InjectFault(fakecircle.Fault{
+			Path:  "/api/test/hello",
+			Times: 1,
+			Header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"0.2"},
+			},
+		})
+
+		l := client.NewLimiter(0, 1)
+		opts := client.Options{MaxRetries: 1, RetryMaxWait: 10 * time.Second, Limiter: l}
+		a, b := newClient(opts), newClient(opts)
+
+		_, err := a.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Assert(t, err)
+
+		start := time.Now()
+		_, err = b.RequestHelper(context.TODO(), http.MethodGet, "/api/test/hello", nil, nil)
+		assert.Assert(t, err)
+		assert.Check(t, time.Since(start) >= 150*time.Millisecond)
+	})
+}
+
+func TestClient_Logging(t *testing.T) {
+	const testTok = "CCIPAT_3d2c1b0a-9f8e-4d7c-a6b5-4c3d2e1f0a9b"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &buf)
+
+	c := client.NewClient(srv.URL, testTok, "terraform-provider-circleci/test")
+	_, err := c.RequestHelper(ctx, http.MethodPost, "/api/test/echo", map[string]any{
+		"name":           "FOO",
+		"value":          "env-secret",
+		"signing-secret": "webhook-secret",
+		"private_key":    "ssh-secret",
+		"items":          []any{map[string]any{"token": "runner-secret"}},
+	}, nil)
+	assert.Assert(t, err)
+
+	assert.Check(t, !strings.Contains(buf.String(), testTok), "token was logged")
+	for _, secret := range []string{"env-secret", "webhook-secret", "ssh-secret", "runner-secret"} {
+		assert.Check(t, !strings.Contains(buf.String(), secret), "%s was logged", secret)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	assert.Assert(t, err)
+
+	var sawResponse bool
+	for _, e := range entries {
+		_, hasReqBody := e["tf_http_req_body"]
+		_, hasResBody := e["tf_http_res_body"]
+		if hasReqBody || hasResBody {
+			assert.Check(t, cmp.Equal(e["@level"], "trace"), "body logged at %v", e["@level"])
+		}
+
+		if e["@message"] == "Received CircleCI API response" {
+			sawResponse = true
+			assert.Check(t, cmp.Equal(e["@level"], "debug"))
+			assert.Check(t, cmp.Equal(e["tf_http_req_method"], http.MethodPost))
+			assert.Check(t, cmp.Equal(e["tf_http_req_uri"], srv.URL+"/api/test/echo"))
+			assert.Check(t, cmp.Equal(e["tf_http_res_status"], float64(http.StatusOK)))
+			assert.Check(t, e["tf_http_req_duration_ms"] != nil)
+		}
+
+		if e["@message"] == "CircleCI API request details" {
+			headers := e["tf_http_req_headers"].(map[string]any)
+			assert.Check(t, cmp.Equal(headers["Circle-Token"], "***"))
+			assert.Check(t, cmp.Contains(e["tf_http_req_body"], `"name":"FOO"`))
+		}
+	}
+	assert.Check(t, sawResponse)
+}
+
+func TestClient_LoggingRetries(t *testing.T) {
+	const testTok = "CCIPAT_9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+	fs.InjectFault(fakecircle.Fault{
+		Path:   "/api/test/hello",
+		Times:  1,
+		Status: http.StatusServiceUnavailable,
+		Header: http.Header{"Retry-After": {"0"}},
+	})
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &buf)
+
+	c := client.NewClient(srv.URL, testTok, "terraform-provider-circleci/test")
+	_, err := c.RequestHelper(ctx, http.MethodGet, "/api/test/hello", nil, nil)
+	assert.Assert(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	assert.Assert(t, err)
+
+	var retries int
+	for _, e := range entries {
+		if e["@message"] == "Retrying CircleCI API request" {
+			retries++
+			assert.Check(t, cmp.Equal(e["tf_http_attempt"], float64(1)))
+		}
+	}
+	assert.Check(t, cmp.Equal(retries, 1))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+)
+
+// organizationKey is the context key for the org a request is made for.
+type organizationKey struct{}
+
+// WithOrganization returns a copy of ctx for requests made on behalf of the
+// org with the given ID or slug, e.g. "gh/my-org". They use the org's token
+// when the client has one in its Credentials, and its usual token otherwise.
+func WithOrganization(ctx context.Context, org string) context.Context {
+	if org == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, organizationKey{}, org)
+}
+
+// WithProject is WithOrganization for the org of the project with the given
+// slug, e.g. "gh/my-org/my-repo".
+func WithProject(ctx context.Context, projectSlug string) context.Context {
+	parts := strings.Split(projectSlug, "/")
+	if len(parts) < 3 {
+		return ctx
+	}
+	return WithOrganization(ctx, parts[0]+"/"+parts[1])
+}
+
+// credentialKey normalizes an org ID or slug for looking up its token. Slugs
+// are matched without regard to case, as the API treats them.
+func credentialKey(org string) string {
+	return strings.ToLower(org)
+}
+
+// token returns the token to send with a request made with ctx.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if org, ok := ctx.Value(organizationKey{}).(string); ok {
+		if tok, ok := c.credentials[credentialKey(org)]; ok {
+			return tok, nil
+		}
+	}
+	if c.tokenSource != nil {
+		return c.tokenSource.Token(ctx)
+	}
+	return c.authToken, nil
+}
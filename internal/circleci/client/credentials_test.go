@@ -0,0 +1,91 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+func TestClient_Credentials(t *testing.T) {
+	var gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("Circle-Token")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := client.NewClientWithOptions(srv.URL, "default-token", "terraform-provider-circleci/test", client.Options{
+		Credentials: map[string]string{
+			"5e8c3f8a-1b2d-4c6e-9f0a-7b3d2e1c4a5f": "org-token",
+			"gh/My-Org":                            "org-token",
+		},
+	})
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "no_org", ctx: context.Background(), want: "default-token"},
+		{name: "org_id", ctx: client.WithOrganization(context.Background(), "5e8c3f8a-1b2d-4c6e-9f0a-7b3d2e1c4a5f"), want: "org-token"},
+		{name: "org_slug", ctx: client.WithOrganization(context.Background(), "gh/my-org"), want: "org-token"},
+		{name: "project_slug", ctx: client.WithProject(context.Background(), "gh/my-org/my-repo"), want: "org-token"},
+		{name: "other_org", ctx: client.WithOrganization(context.Background(), "gh/other-org"), want: "default-token"},
+		{name: "empty_org", ctx: client.WithOrganization(context.Background(), ""), want: "default-token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.RequestHelper(tc.ctx, http.MethodGet, "/whatever", nil, nil)
+			assert.Assert(t, err)
+			assert.Check(t, cmp.Equal(gotToken, tc.want))
+		})
+	}
+}
+
+// TestClient_CredentialsCache checks that a response cached for one org's
+// token is not served to a request made with another org's token.
+func TestClient_CredentialsCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token":"` + r.Header.Get("Circle-Token") + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := client.NewClientWithOptions(srv.URL, "default-token", "terraform-provider-circleci/test", client.Options{
+		CacheTTL: time.Minute,
+		Credentials: map[string]string{
+			"gh/org-a": "org-a-token",
+			"gh/org-b": "org-b-token",
+		},
+	})
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "org_a", ctx: client.WithOrganization(context.Background(), "gh/org-a"), want: "org-a-token"},
+		{name: "org_b", ctx: client.WithOrganization(context.Background(), "gh/org-b"), want: "org-b-token"},
+		{name: "no_org", ctx: context.Background(), want: "default-token"},
+		{name: "org_a_again", ctx: client.WithOrganization(context.Background(), "gh/org-a"), want: "org-a-token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var res struct {
+				Token string `json:"token"`
+			}
+			_, err := c.RequestHelper(tc.ctx, http.MethodGet, "/context/1", nil, &res)
+			assert.Assert(t, err)
+			assert.Check(t, cmp.Equal(res.Token, tc.want))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// requestIDHeaders are the response headers the API may use to identify a
+// request, in order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Circleci-Request-Id"}
+
+// APIError is returned for any response with a 4xx or 5xx status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response, e.g. 404.
+	StatusCode int
+	// Status is the HTTP status line of the response, e.g. "404 Not Found".
+	Status string
+	// Message is the `message` field of the response body, or the raw body
+	// when it is not of the form {"message": "..."}.
+	Message string
+	// Method and Path identify the request that failed. Path excludes the
+	// query string.
+	Method string
+	Path   string
+	// RequestID is the request identifier the API returned, if any.
+	RequestID string
+}
+
+func newAPIError(res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	var decoded struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &decoded); err == nil && decoded.Message != "" {
+		e.Message = decoded.Message
+	}
+
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		if res.Request.URL != nil {
+			e.Path = res.Request.URL.Path
+		}
+	}
+
+	for _, h := range requestIDHeaders {
+		if id := res.Header.Get(h); id != "" {
+			e.RequestID = id
+			break
+		}
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	if e.Method != "" {
+		fmt.Fprintf(&sb, "%s %s: ", e.Method, e.Path)
+	}
+	sb.WriteString(e.Status)
+	if e.Message != "" {
+		sb.WriteString(": ")
+		sb.WriteString(e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&sb, " (request id: %s)", e.RequestID)
+	}
+	return sb.String()
+}
+
+// HasStatus reports whether err is, or wraps, an *APIError with the given
+// status code.
+func HasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsNotFound reports whether err is, or wraps, a 404 from the API.
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is, or wraps, a 409 from the API.
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is, or wraps, a 429 from the API. This is
+// only seen once the client has exhausted its retries.
+func IsRateLimited(err error) bool {
+	return HasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsUnauthorized reports whether err is, or wraps, a 401 from the API.
+func IsUnauthorized(err error) bool {
+	return HasStatus(err, http.StatusUnauthorized)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces secrets in logged headers and bodies.
+const redacted = "***"
+
+// sensitiveHeaders are the request headers whose values are never logged.
+var sensitiveHeaders = []string{"Circle-Token"}
+
+// sensitiveFields are the JSON fields, at any depth in a body, whose values
+// are never logged: env var values, webhook signing secrets, runner tokens
+// and SSH private keys.
+var sensitiveFields = map[string]bool{
+	"value":          true,
+	"signing-secret": true,
+	"token":          true,
+	"private_key":    true,
+}
+
+// logRetry is a retryablehttp.RequestLogHook that logs each retry. It stands
+// in for retryablehttp's own logger, which writes unredacted to stderr.
+func logRetry(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if attempt == 0 {
+		return
+	}
+	tflog.Debug(req.Context(), "Retrying CircleCI API request", map[string]any{
+		"tf_http_req_method": req.Method,
+		"tf_http_req_uri":    req.URL.String(),
+		"tf_http_attempt":    attempt,
+	})
+}
+
+// logRequest logs a request before it is sent. The headers and body are
+// only logged at TRACE.
+func logRequest(ctx context.Context, req *retryablehttp.Request, body []byte) {
+	fields := map[string]any{
+		"tf_http_req_method": req.Method,
+		"tf_http_req_uri":    req.URL.String(),
+	}
+	tflog.Debug(ctx, "Sending CircleCI API request", fields)
+
+	fields["tf_http_req_headers"] = redactHeaders(req.Header)
+	fields["tf_http_req_body"] = redactBody(body)
+	tflog.Trace(ctx, "CircleCI API request details", fields)
+}
+
+// logResponse logs the final response to a request, after any retries. The
+// headers and body are only logged at TRACE.
+func logResponse(ctx context.Context, req *retryablehttp.Request, res *http.Response, body []byte, duration time.Duration) {
+	fields := map[string]any{
+		"tf_http_req_method":      req.Method,
+		"tf_http_req_uri":         req.URL.String(),
+		"tf_http_res_status":      res.StatusCode,
+		"tf_http_req_duration_ms": duration.Milliseconds(),
+	}
+	tflog.Debug(ctx, "Received CircleCI API response", fields)
+
+	fields["tf_http_res_headers"] = redactHeaders(res.Header)
+	fields["tf_http_res_body"] = redactBody(body)
+	tflog.Trace(ctx, "CircleCI API response details", fields)
+}
+
+// logRequestError logs a request that got no response at all.
+func logRequestError(ctx context.Context, req *retryablehttp.Request, err error, duration time.Duration) {
+	tflog.Debug(ctx, "CircleCI API request failed", map[string]any{
+		"tf_http_req_method":      req.Method,
+		"tf_http_req_uri":         req.URL.String(),
+		"tf_http_req_duration_ms": duration.Milliseconds(),
+		"error":                   err.Error(),
+	})
+}
+
+// logCacheHit logs a GET answered from the client's read cache, or by
+// another caller's request for the same URL.
+func logCacheHit(ctx context.Context, url string) {
+	tflog.Debug(ctx, "Using cached CircleCI API response", map[string]any{
+		"tf_http_req_method": http.MethodGet,
+		"tf_http_req_uri":    url,
+	})
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	res := make(map[string]string, len(h))
+	for k, vs := range h {
+		res[k] = strings.Join(vs, ", ")
+	}
+	for _, k := range sensitiveHeaders {
+		if _, ok := res[http.CanonicalHeaderKey(k)]; ok {
+			res[http.CanonicalHeaderKey(k)] = redacted
+		}
+	}
+	return res
+}
+
+// redactBody returns body with the values of any sensitiveFields replaced.
+// A body that is not JSON, such as a plain text error, is returned as is.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+	return string(b)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, fv := range v {
+			if sensitiveFields[k] {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(fv)
+			}
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+	}
+	return v
+}
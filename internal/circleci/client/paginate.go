@@ -0,0 +1,105 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"terraform-provider-circleci/internal/circleci/common"
+)
+
+// DefaultMaxPages is how many pages of a list a client fetches before giving
+// up, unless Options.MaxPages says otherwise.
+const DefaultMaxPages = 1000
+
+var (
+	// ErrTooManyPages is returned when a list has more pages than the
+	// client's MaxPages.
+	ErrTooManyPages = errors.New("too many pages")
+	// ErrRepeatedPageToken is returned when the API hands back a page token
+	// it has already returned for the same list, which would otherwise page
+	// forever.
+	ErrRepeatedPageToken = errors.New("repeated page token")
+)
+
+// Paginate returns an iterator over every item of the paginated list at path,
+// which is relative to the client's base URL and may already have a query.
+// Pages are fetched as the iterator reaches them, so breaking out of the loop
+// early saves the remaining requests.
+//
+// An error ends the iteration: it is yielded once, with the zero T.
+func Paginate[T any](ctx context.Context, c *Client, path string) iter.Seq2[T, error] {
+	return PaginateAbsolute[T](ctx, c, c.baseURL+path)
+}
+
+// PaginateAbsolute is the same as Paginate but takes a full URL, for lists on
+// other APIs.
+func PaginateAbsolute[T any](ctx context.Context, c *Client, listURL string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := make(map[string]bool)
+		var pageToken string
+		for page := 1; ; page++ {
+			if page > c.maxPages {
+				yield(zero, fmt.Errorf("%w: %s has more than %d pages", ErrTooManyPages, listURL, c.maxPages))
+				return
+			}
+
+			var res common.PaginatedResponse[T]
+			_, err := c.request(ctx, withPageToken(listURL, pageToken), http.MethodGet, nil, &res)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range res.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if res.NextPageToken == "" {
+				return
+			}
+			seen[pageToken] = true
+			if seen[res.NextPageToken] {
+				yield(zero, fmt.Errorf("%w: %s returned the page token %q twice", ErrRepeatedPageToken, listURL, res.NextPageToken))
+				return
+			}
+			pageToken = res.NextPageToken
+		}
+	}
+}
+
+// Collect returns the items of seq in a slice, or the first error. An empty
+// list gives an empty slice rather than nil.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// withPageToken adds pageToken to the query of listURL. The first page has no
+// token, and listURL is returned unchanged.
+func withPageToken(listURL, pageToken string) string {
+	if pageToken == "" {
+		return listURL
+	}
+	sep := "?"
+	if strings.Contains(listURL, "?") {
+		sep = "&"
+	}
+	return listURL + sep + "page-token=" + url.QueryEscape(pageToken)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+// pagedServer serves the numbers 0 to n-1 in pages of size, with page tokens
+// from next. It counts the requests it gets.
+func pagedServer(t *testing.T, n, size int, next func(offset int) string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("filter") != "yes" {
+			http.Error(w, `{"message":"lost the filter"}`, http.StatusBadRequest)
+			return
+		}
+
+		offset := 0
+		if tok := r.URL.Query().Get("page-token"); tok != "" {
+			var err error
+			offset, err = strconv.Atoi(tok)
+			if err != nil {
+				http.Error(w, `{"message":"bad page token"}`, http.StatusBadRequest)
+				return
+			}
+		}
+
+		res := struct {
+			NextPageToken string `json:"next_page_token"`
+			Items         []int  `json:"items"`
+		}{Items: []int{}}
+		end := min(offset+size, n)
+		for i := offset; i < end; i++ {
+			res.Items = append(res.Items, i)
+		}
+		if end < n {
+			res.NextPageToken = next(end)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.TODO()
+	offsetToken := strconv.Itoa
+
+	t.Run("all_pages", func(t *testing.T) {
+		srv, requests := pagedServer(t, 7, 3, offsetToken)
+		c := client.NewClient(srv.URL, "", "terraform-provider-circleci/test")
+
+		got, err := client.Collect(client.Paginate[int](ctx, c, "/list?filter=yes"))
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(got, []int{0, 1, 2, 3, 4, 5, 6}))
+		assert.Check(t, cmp.Equal(requests.Load(), int32(3)))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		srv, _ := pagedServer(t, 0, 3, offsetToken)
+		c := client.NewClient(srv.URL, "", "terraform-provider-circleci/test")
+
+		got, err := client.Collect(client.PaginateAbsolute[int](ctx, c, srv.URL+"/list?filter=yes"))
+		assert.Assert(t, err)
+		assert.Check(t, got != nil)
+		assert.Check(t, cmp.Len(got, 0))
+	})
+
+	t.Run("early_stop", func(t *testing.T) {
+		srv, requests := pagedServer(t, 7, 3, offsetToken)
+		c := client.NewClient(srv.URL, "", "terraform-provider-circleci/test")
+
+		var got []int
+		for i, err := range client.Paginate[int](ctx, c, "/list?filter=yes") {
+			assert.Assert(t, err)
+			got = append(got, i)
+			if i == 4 {
+				break
+			}
+		}
+		assert.Check(t, cmp.DeepEqual(got, []int{0, 1, 2, 3, 4}))
+		assert.Check(t, cmp.Equal(requests.Load(), int32(2)))
+	})
+
+	t.Run("max_pages", func(t *testing.T) {
+		srv, requests := pagedServer(t, 7, 3, offsetToken)
+		c := client.NewClientWithOptions(srv.URL, "", "terraform-provider-circleci/test", client.Options{MaxPages: 2})
+
+		got, err := client.Collect(client.Paginate[int](ctx, c, "/list?filter=yes"))
+		assert.Check(t, errors.Is(err, client.ErrTooManyPages), "got %v", err)
+		assert.Check(t, cmp.Nil(got))
+		assert.Check(t, cmp.Equal(requests.Load(), int32(2)))
+	})
+
+	t.Run("repeated_token", func(t *testing.T) {
+		srv, requests := pagedServer(t, 7, 3, func(int) string { return "3" })
+		c := client.NewClient(srv.URL, "", "terraform-provider-circleci/test")
+
+		got, err := client.Collect(client.Paginate[int](ctx, c, "/list?filter=yes"))
+		assert.Check(t, errors.Is(err, client.ErrRepeatedPageToken), "got %v", err)
+		assert.Check(t, cmp.Nil(got))
+		assert.Check(t, cmp.Equal(requests.Load(), int32(2)))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		srv, requests := pagedServer(t, 7, 3, func(int) string { return "not a number" })
+		c := client.NewClient(srv.URL, "", "terraform-provider-circleci/test")
+
+		var got []int
+		var gotErr error
+		for i, err := range client.Paginate[int](ctx, c, "/list?filter=yes") {
+			if err != nil {
+				gotErr = err
+				continue
+			}
+			got = append(got, i)
+		}
+		assert.Check(t, client.HasStatus(gotErr, http.StatusBadRequest), "got %v", gotErr)
+		assert.Check(t, cmp.DeepEqual(got, []int{0, 1, 2}))
+		assert.Check(t, cmp.Equal(requests.Load(), int32(2)))
+	})
+}
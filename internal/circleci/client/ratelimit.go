@@ -0,0 +1,175 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket that spaces out requests. It can be shared by
+// several clients, so they stay under one rate limit between them.
+//
+// A nil *Limiter lets every request through.
+type Limiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewLimiter returns a Limiter that allows requestsPerSecond on average, and
+// bursts of up to burst requests. A requestsPerSecond of zero or less does not
+// limit the rate, though the limiter is still paused when the API reports
+// that its rate limit has been used up.
+func NewLimiter(requestsPerSecond float64, burst int) *Limiter {
+	burst = max(burst, 1)
+	return &Limiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be made, or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		d := l.reserve(time.Now())
+		if d <= 0 {
+			return nil
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, and otherwise returns how long
+// to wait before trying again.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens < 1 {
+		return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+	l.tokens--
+	return 0
+}
+
+// PauseUntil holds back every request until t.
+func (l *Limiter) PauseUntil(t time.Time) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// rateLimitTransport waits on the limiter before each attempt, retries
+// included, and pauses it when a response says the rate limit is used up.
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *Limiter
+	// maxWait caps how long a response can pause the limiter for.
+	maxWait time.Duration
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+
+	now := time.Now()
+	if wait, ok := rateLimitWait(res, now); ok && wait > 0 {
+		t.limiter.PauseUntil(now.Add(min(wait, t.maxWait)))
+	}
+	return res, nil
+}
+
+// rateLimitWait returns how long the API has asked us to wait before the next
+// request. It uses the Retry-After header of a 429 or 503, or else the
+// X-RateLimit-Reset header once X-RateLimit-Remaining reaches zero.
+func rateLimitWait(res *http.Response, now time.Time) (time.Duration, bool) {
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable {
+		if d, ok := parseRetryAfter(res.Header.Get("Retry-After"), now); ok {
+			return d, true
+		}
+	}
+
+	if res.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	return parseRateLimitReset(res.Header.Get("X-RateLimit-Reset"), now)
+}
+
+// parseRetryAfter parses a Retry-After value, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// resetEpochThreshold separates the two forms X-RateLimit-Reset is seen in:
+// values below it are seconds until the reset, and values above it are the
+// Unix time of the reset.
+const resetEpochThreshold = 1_000_000_000
+
+func parseRateLimitReset(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, false
+	}
+	if f >= resetEpochThreshold {
+		sec, frac := math.Modf(f)
+		return max(time.Unix(int64(sec), int64(frac*1e9)).Sub(now), 0), true
+	}
+	return time.Duration(f * float64(time.Second)), true
+}
@@ -0,0 +1,196 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName names the tracer and meter the client reports with.
+const instrumentationName = "terraform-provider-circleci/internal/circleci/client"
+
+// telemetry holds the client's OpenTelemetry instruments. Without a tracer or
+// meter provider they are no-ops.
+type telemetry struct {
+	tracer      trace.Tracer
+	requests    metric.Int64Counter
+	retries     metric.Int64Counter
+	rateLimited metric.Int64Counter
+}
+
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) *telemetry {
+	if tp == nil {
+		tp = tracenoop.NewTracerProvider()
+	}
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName)
+
+	// An instrument that could not be created is returned as a no-op along
+	// with the error, so the errors are safe to ignore.
+	requests, _ := meter.Int64Counter("circleci.client.requests",
+		metric.WithDescription("CircleCI API requests made, not counting retries or reads served from the cache."),
+		metric.WithUnit("{request}"))
+	retries, _ := meter.Int64Counter("circleci.client.retries",
+		metric.WithDescription("CircleCI API requests retried after a rate limit, server error or connection error."),
+		metric.WithUnit("{retry}"))
+	rateLimited, _ := meter.Int64Counter("circleci.client.rate_limited",
+		metric.WithDescription("CircleCI API responses with status 429 Too Many Requests, retried or not."),
+		metric.WithUnit("{response}"))
+
+	return &telemetry{
+		tracer:      tp.Tracer(instrumentationName),
+		requests:    requests,
+		retries:     retries,
+		rateLimited: rateLimited,
+	}
+}
+
+type requestStateKey struct{}
+
+// requestState follows one request through its retries.
+type requestState struct {
+	method string
+	route  string
+	// attempt is the latest attempt, counting from 0, so it is also the
+	// number of retries so far.
+	attempt int
+}
+
+// startRequest starts the span for a request, which lasts until end is
+// called and covers every retry.
+func (t *telemetry) startRequest(ctx context.Context, method, rawURL string) (context.Context, *requestState, trace.Span) {
+	state := &requestState{method: method, route: routeTemplate(rawURL)}
+	ctx = context.WithValue(ctx, requestStateKey{}, state)
+
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(method),
+		semconv.URLTemplate(state.route),
+	}
+	if u, err := url.Parse(rawURL); err == nil {
+		attrs = append(attrs, semconv.ServerAddress(u.Hostname()))
+	}
+	ctx, span := t.tracer.Start(ctx, method+" "+state.route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx, state, span
+}
+
+// endRequest records the outcome of a request on its span and counts it.
+// status is 0 when the request got no response.
+func (t *telemetry) endRequest(ctx context.Context, state *requestState, span trace.Span, status int, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(state.method),
+		semconv.URLTemplate(state.route),
+	}
+	if status != 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(status))
+	}
+	t.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+
+	span.SetAttributes(semconv.HTTPRequestResendCount(state.attempt))
+	if status != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	}
+	if err != nil {
+		errType := "error"
+		if status != 0 {
+			errType = strconv.Itoa(status)
+		}
+		span.SetAttributes(semconv.ErrorTypeKey.String(errType))
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// retryHook is a retryablehttp.RequestLogHook that counts retries.
+func (t *telemetry) retryHook(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	state, ok := req.Context().Value(requestStateKey{}).(*requestState)
+	if !ok {
+		return
+	}
+	state.attempt = attempt
+	if attempt > 0 {
+		t.retries.Add(req.Context(), 1, metric.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(state.method),
+			semconv.URLTemplate(state.route),
+		))
+	}
+}
+
+// responseHook is a retryablehttp.ResponseLogHook that counts 429s, including
+// those that are retried.
+func (t *telemetry) responseHook(_ retryablehttp.Logger, res *http.Response) {
+	if res.StatusCode != http.StatusTooManyRequests || res.Request == nil {
+		return
+	}
+	state, ok := res.Request.Context().Value(requestStateKey{}).(*requestState)
+	if !ok {
+		return
+	}
+	t.rateLimited.Add(res.Request.Context(), 1, metric.WithAttributes(
+		semconv.HTTPRequestMethodKey.String(state.method),
+		semconv.URLTemplate(state.route),
+	))
+}
+
+var uuidSegment = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// namedSegments are path segments followed by a name rather than an ID.
+var namedSegments = map[string]string{
+	"environment-variable": "{name}",
+	"envvar":               "{name}",
+}
+
+// routeTemplate returns the path of rawURL with IDs, project slugs and env var
+// names replaced by placeholders, e.g. "/api/v2/context/{id}". Spans and
+// metrics for the same API call then share a route however many objects
+// there are.
+func routeTemplate(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	segs := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	var out []string
+	for i := 0; i < len(segs); i++ {
+		seg := segs[i]
+		switch {
+		case uuidSegment.MatchString(seg):
+			out = append(out, "{id}")
+		case i > 0 && segs[i-1] == "project" && i+2 < len(segs):
+			// A project slug is vcs-type/org-name/repo-name.
+			out = append(out, "{project-slug}")
+			i += 2
+		case i > 0 && namedSegments[segs[i-1]] != "":
+			out = append(out, namedSegments[segs[i-1]])
+		default:
+			out = append(out, seg)
+		}
+	}
+	return "/" + strings.Join(out, "/")
+}
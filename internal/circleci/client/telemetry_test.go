@@ -0,0 +1,140 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestClient_Telemetry(t *testing.T) {
+	const testTok = "CCIPAT_7a6b5c4d-3e2f-4a1b-9c8d-7e6f5a4b3c2d"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	org, err := fs.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "telemetry",
+	})
+	assert.Assert(t, err)
+	prj, err := fs.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "telemetry",
+	})
+	assert.Assert(t, err)
+
+	setup := func(t *testing.T) (*client.Client, *tracetest.InMemoryExporter, *sdktrace.TracerProvider, *sdkmetric.ManualReader) {
+		spans := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() {
+			_ = tp.Shutdown(context.Background())
+			_ = mp.Shutdown(context.Background())
+		})
+
+		c := client.NewClientWithOptions(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test", client.Options{
+			MaxRetries:     2,
+			RetryMaxWait:   10 * time.Millisecond,
+			TracerProvider: tp,
+			MeterProvider:  mp,
+		})
+		return c, spans, tp, reader
+	}
+
+	t.Run("spans", func(t *testing.T) {
+		t.Cleanup(fs.ClearFaults)
+		c, spans, tp, reader := setup(t)
+		fs.InjectFault(fakecircle.Fault{
+			Method: http.MethodGet,
+			Path:   "/project/*/*/*/envvar",
+			Times:  1,
+			Status: http.StatusTooManyRequests,
+			Header: http.Header{"Retry-After": {"0"}},
+		})
+
+		ctx, parent := tp.Tracer("test").Start(context.Background(), "circleci_project_environment_variable Read")
+		_, err := c.RequestHelper(ctx, http.MethodGet, "/project/"+prj.Slug+"/envvar", nil, nil)
+		assert.Assert(t, err)
+		_, err = c.RequestHelper(ctx, http.MethodGet, "/context/"+org.ID.String(), nil, nil)
+		assert.Check(t, client.IsNotFound(err))
+		parent.End()
+
+		got := spans.GetSpans()
+		assert.Assert(t, cmp.Len(got, 3))
+
+		envvar := got[0]
+		assert.Check(t, cmp.Equal(envvar.Name, "GET /api/v2/project/{project-slug}/envvar"))
+		assert.Check(t, cmp.Equal(envvar.Parent.SpanID(), parent.SpanContext().SpanID()))
+		assert.Check(t, cmp.Equal(envvar.Status.Code, codes.Unset))
+		assert.Check(t, hasAttribute(envvar.Attributes, attribute.String("http.request.method", "GET")))
+		assert.Check(t, hasAttribute(envvar.Attributes, attribute.String("url.template", "/api/v2/project/{project-slug}/envvar")))
+		assert.Check(t, hasAttribute(envvar.Attributes, attribute.Int("http.response.status_code", 200)))
+		assert.Check(t, hasAttribute(envvar.Attributes, attribute.Int("http.request.resend_count", 1)))
+
+		notFound := got[1]
+		assert.Check(t, cmp.Equal(notFound.Name, "GET /api/v2/context/{id}"))
+		assert.Check(t, cmp.Equal(notFound.Status.Code, codes.Error))
+		assert.Check(t, hasAttribute(notFound.Attributes, attribute.Int("http.response.status_code", 404)))
+		assert.Check(t, hasAttribute(notFound.Attributes, attribute.String("error.type", "404")))
+
+		var rm metricdata.ResourceMetrics
+		assert.Assert(t, reader.Collect(context.Background(), &rm))
+		assert.Check(t, cmp.Equal(counterTotal(rm, "circleci.client.requests"), int64(2)))
+		assert.Check(t, cmp.Equal(counterTotal(rm, "circleci.client.retries"), int64(1)))
+		assert.Check(t, cmp.Equal(counterTotal(rm, "circleci.client.rate_limited"), int64(1)))
+	})
+
+	t.Run("no_providers", func(t *testing.T) {
+		c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+		_, err := c.RequestHelper(context.Background(), http.MethodGet, "/me", nil, nil)
+		assert.Check(t, err)
+	})
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a.Key == want.Key {
+			return a.Value == want.Value
+		}
+	}
+	return false
+}
+
+// counterTotal adds up the data points of the named counter.
+func counterTotal(rm metricdata.ResourceMetrics, name string) int64 {
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				total += dp.Value
+			}
+		}
+	}
+	return total
+}
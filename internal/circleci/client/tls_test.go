@@ -0,0 +1,150 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestClient_TLS(t *testing.T) {
+	const testTok = "CCIPAT_2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f"
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewTLSServer(fs)
+	t.Cleanup(srv.Close)
+
+	get := func(opts client.Options) error {
+		opts.RetryMaxWait = 10 * time.Millisecond
+		c := client.NewClientWithOptions(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test", opts)
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/me", nil, nil)
+		return err
+	}
+
+	t.Run("untrusted", func(t *testing.T) {
+		err := get(client.Options{})
+		assert.Check(t, cmp.ErrorContains(err, "certificate"))
+	})
+
+	t.Run("ca", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+		assert.Check(t, get(client.Options{TLSConfig: &tls.Config{RootCAs: pool}}))
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		assert.Check(t, get(client.Options{TLSConfig: &tls.Config{InsecureSkipVerify: true}}))
+	})
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	const testTok = "CCIPAT_3d4e5f6a-7b8c-4d9e-8f0a-2b3c4d5e6f7a"
+
+	cert := newClientCert(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert.Leaf)
+
+	fs := fakecircle.New(testTok)
+	srv := httptest.NewUnstartedServer(fs)
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(srv.Certificate())
+
+	get := func(tlsConfig *tls.Config) error {
+		c := client.NewClientWithOptions(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test", client.Options{
+			RetryMaxWait: 10 * time.Millisecond,
+			TLSConfig:    tlsConfig,
+		})
+		_, err := c.RequestHelper(context.TODO(), http.MethodGet, "/me", nil, nil)
+		return err
+	}
+
+	t.Run("no_client_cert", func(t *testing.T) {
+		assert.Check(t, get(&tls.Config{RootCAs: rootCAs}) != nil)
+	})
+
+	t.Run("client_cert", func(t *testing.T) {
+		assert.Check(t, get(&tls.Config{
+			RootCAs:      rootCAs,
+			Certificates: []tls.Certificate{cert},
+		}))
+	})
+}
+
+func TestClient_Proxy(t *testing.T) {
+	const testTok = "CCIPAT_4e5f6a7b-8c9d-4e0f-9a1b-3c4d5e6f7a8b"
+
+	// A plain HTTP proxy is sent the absolute URL; this one answers for the
+	// fake itself.
+	fs := fakecircle.New(testTok)
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "circleci.invalid" {
+			proxied.Add(1)
+		}
+		fs.ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.Assert(t, err)
+
+	c := client.NewClientWithOptions("http://circleci.invalid/api/v2", testTok, "terraform-provider-circleci/test", client.Options{
+		ProxyURL: proxyURL,
+	})
+	_, err = c.RequestHelper(context.TODO(), http.MethodGet, "/me", nil, nil)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Equal(proxied.Load(), int32(1)))
+}
+
+// newClientCert returns a self-signed certificate for TLS client auth.
+func newClientCert(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Assert(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-circleci test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Assert(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	assert.Assert(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"net/http"
 
 	"terraform-provider-circleci/internal/circleci/client"
-	"terraform-provider-circleci/internal/circleci/common"
 )
 
 type Context struct {
@@ -45,25 +44,12 @@ func (s *ContextService) Get(ctx context.Context, contextID string) (_ *Context,
 }
 
 func (s *ContextService) List(ctx context.Context, organizationSlug string) (_ []Context, err error) {
-	var nextPageToken string
-	var contextList []Context
-	for {
-		var response common.PaginatedResponse[Context]
-		_, err := s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/context?owner-slug=%s&page-token=%s", organizationSlug, nextPageToken), nil, &response)
-		if err != nil {
-			return nil, err
-		}
-
-		contextList = append(contextList, response.Items...)
-		if response.NextPageToken == "" {
-			break
-		}
-		nextPageToken = response.NextPageToken
-	}
-	return contextList, nil
+	ctx = client.WithOrganization(ctx, organizationSlug)
+	return client.Collect(client.Paginate[Context](ctx, s.client, "/context?owner-slug="+organizationSlug))
 }
 
 func (s *ContextService) Create(ctx context.Context, organizationID, name string) (_ *Context, err error) {
+	ctx = client.WithOrganization(ctx, organizationID)
 	payload := map[string]any{
 		"name": name,
 		"owner": map[string]string{
@@ -85,21 +71,7 @@ func (s *ContextService) Delete(ctx context.Context, contextID string) (err erro
 }
 
 func (s *ContextService) GetRestrictions(ctx context.Context, contextID string) (_ []ContextRestriction, err error) {
-	var nextPageToken string
-	var contextRestrictionList []ContextRestriction
-	for {
-		var response common.PaginatedResponse[ContextRestriction]
-		_, err := s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/context/%s/restrictions?page-token=%s", contextID, nextPageToken), nil, &response)
-		if err != nil {
-			return nil, err
-		}
-		contextRestrictionList = append(contextRestrictionList, response.Items...)
-		if response.NextPageToken == "" {
-			break
-		}
-		nextPageToken = response.NextPageToken
-	}
-	return contextRestrictionList, nil
+	return client.Collect(client.Paginate[ContextRestriction](ctx, s.client, fmt.Sprintf("/context/%s/restrictions", contextID)))
 }
 
 func (s *ContextService) DeleteRestriction(ctx context.Context, contextID, restrictionID string) (err error) {
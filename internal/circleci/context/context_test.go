@@ -68,6 +68,27 @@ func TestContextService_List(t *testing.T) {
 			},
 		}, cmpopts.IgnoreFields(sdkcontext.Context{}, "CreatedAt")))
 	})
+
+	t.Run("pages", func(t *testing.T) {
+		fc.SetPageSize(2)
+		for _, name := range []string{"a", "b", "c", "d"} {
+			_, err := fc.AddContext(fakecircle.NewContext{
+				OrgID: o.ID,
+				Name:  name,
+			})
+			assert.Assert(t, err)
+		}
+		fc.ResetCalls()
+
+		ctxs, err := contextService.List(context.TODO(), o.Slug)
+		assert.Assert(t, err)
+		var names []string
+		for _, c := range ctxs {
+			names = append(names, c.Name)
+		}
+		assert.Check(t, cmp.DeepEqual(names, []string{"a", "b", "c", "d", "test context"}))
+		fc.AssertCallCount(t, "GET", "/context", 3)
+	})
 }
 
 func TestContextService_Get(t *testing.T) {
@@ -155,6 +176,7 @@ func TestContextService_Full(t *testing.T) {
 		ctx := context.TODO()
 		ctxFetched, err := contextService.Get(ctx, ctxCreated.ID)
 		assert.Assert(t, cmp.ErrorContains(err, "context not found"))
+		assert.Check(t, client.IsNotFound(err))
 		assert.Check(t, cmp.Nil(ctxFetched))
 	})
 }
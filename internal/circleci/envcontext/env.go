@@ -6,11 +6,11 @@ package envcontext
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 
 	"terraform-provider-circleci/internal/circleci/client"
-	"terraform-provider-circleci/internal/circleci/common"
 )
 
 type EnvVariable struct {
@@ -29,22 +29,13 @@ func NewEnvService(c *client.Client) *EnvService {
 }
 
 func (s *EnvService) List(ctx context.Context, contextID string) (_ []EnvVariable, err error) {
-	var nextPageToken string
-	var contextList []EnvVariable
-	for {
-		var response common.PaginatedResponse[EnvVariable]
-		_, err = s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/context/%s/environment-variable?page-token=%s", contextID, nextPageToken), nil, &response)
-		if err != nil {
-			return nil, err
-		}
+	return client.Collect(s.All(ctx, contextID))
+}
 
-		contextList = append(contextList, response.Items...)
-		if response.NextPageToken == "" {
-			break
-		}
-		nextPageToken = response.NextPageToken
-	}
-	return contextList, nil
+// All iterates over the environment variables of a context, fetching each
+// page as it is reached.
+func (s *EnvService) All(ctx context.Context, contextID string) iter.Seq2[EnvVariable, error] {
+	return client.Paginate[EnvVariable](ctx, s.client, fmt.Sprintf("/context/%s/environment-variable", contextID))
 }
 
 func (s *EnvService) Create(ctx context.Context, contextID, value, name string) (_ *EnvVariable, err error) {
@@ -56,6 +56,35 @@ func TestEnvService_List(t *testing.T) {
 			},
 		}, cmpopts.EquateApproxTime(time.Second)))
 	})
+
+	t.Run("pages", func(t *testing.T) {
+		fc.SetPageSize(2)
+		for _, name := range []string{"B", "C", "D", "E"} {
+			_, err := fc.AddContextEnv(orgCtx.ID, fakecircle.NewEnvVarContext{
+				Variable: name,
+			})
+			assert.Assert(t, err)
+		}
+
+		t.Run("list", func(t *testing.T) {
+			fc.ResetCalls()
+			envs, err := envService.List(context.TODO(), orgCtx.ID.String())
+			assert.Assert(t, err)
+			assert.Check(t, cmp.Len(envs, 5))
+			fc.AssertCallCount(t, "GET", "/context/*/environment-variable", 3)
+		})
+
+		t.Run("stop_early", func(t *testing.T) {
+			fc.ResetCalls()
+			for env, err := range envService.All(context.TODO(), orgCtx.ID.String()) {
+				assert.Assert(t, err)
+				if env.Variable == "B" {
+					break
+				}
+			}
+			fc.AssertCallCount(t, "GET", "/context/*/environment-variable", 1)
+		})
+	})
 }
 
 func TestEnvService_Create(t *testing.T) {
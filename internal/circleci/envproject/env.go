@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"terraform-provider-circleci/internal/circleci/client"
-	"terraform-provider-circleci/internal/circleci/common"
 )
 
 type EnvVariable struct {
@@ -28,25 +27,12 @@ func NewEnvService(c *client.Client) *EnvService {
 }
 
 func (s *EnvService) List(ctx context.Context, projectSlug string) (_ []EnvVariable, err error) {
-	var nextPageToken string
-	var contextList []EnvVariable
-	for {
-		var response common.PaginatedResponse[EnvVariable]
-		_, err = s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/project/%s/envvar?page-token=%s", projectSlug, nextPageToken), nil, &response)
-		if err != nil {
-			return nil, err
-		}
-
-		contextList = append(contextList, response.Items...)
-		if response.NextPageToken == "" {
-			break
-		}
-		nextPageToken = response.NextPageToken
-	}
-	return contextList, nil
+	ctx = client.WithProject(ctx, projectSlug)
+	return client.Collect(client.Paginate[EnvVariable](ctx, s.client, fmt.Sprintf("/project/%s/envvar", projectSlug)))
 }
 
 func (s *EnvService) Get(ctx context.Context, projectSlug, name string) (_ *EnvVariable, err error) {
+	ctx = client.WithProject(ctx, projectSlug)
 	var envVariable EnvVariable
 	_, err = s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/project/%s/envvar/%s", projectSlug, name), nil, &envVariable)
 	if err != nil {
@@ -56,6 +42,7 @@ func (s *EnvService) Get(ctx context.Context, projectSlug, name string) (_ *EnvV
 }
 
 func (s *EnvService) Create(ctx context.Context, projectSlug, value, name string) (_ *EnvVariable, err error) {
+	ctx = client.WithProject(ctx, projectSlug)
 	payload := map[string]string{
 		"value": value,
 		"name":  name,
@@ -69,6 +56,7 @@ func (s *EnvService) Create(ctx context.Context, projectSlug, value, name string
 }
 
 func (s *EnvService) Delete(ctx context.Context, projectSlug, name string) (err error) {
+	ctx = client.WithProject(ctx, projectSlug)
 	_, err = s.client.RequestHelper(ctx, http.MethodDelete, fmt.Sprintf("/project/%s/envvar/%s", projectSlug, name), nil, nil)
 	return err
 }
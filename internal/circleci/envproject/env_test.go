@@ -54,6 +54,22 @@ func TestEnvService_List(t *testing.T) {
 			},
 		}, cmpopts.EquateApproxTime(time.Second)))
 	})
+
+	t.Run("pages", func(t *testing.T) {
+		fc.SetPageSize(2)
+		for _, name := range []string{"B", "C", "D", "E"} {
+			_, err := fc.AddProjectEnv(orgPrj.ID, fakecircle.NewEnvVarProject{
+				Name: name,
+			})
+			assert.Assert(t, err)
+		}
+		fc.ResetCalls()
+
+		envs, err := envService.List(context.TODO(), orgPrj.Slug)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(envs, 5))
+		fc.AssertCallCount(t, "GET", "/project/*/*/*/envvar", 3)
+	})
 }
 
 func TestEnvService_Create(t *testing.T) {
@@ -0,0 +1,128 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+// The custom claims of OIDC tokens.
+const (
+	// ClaimAudience is the aud claim.
+	ClaimAudience = "audience"
+	// ClaimTTL is how long a token is valid for.
+	ClaimTTL = "ttl"
+)
+
+// Claims are the custom claims CircleCI puts in the OIDC tokens of an
+// organization's or project's jobs. Claims that are not set are empty, and
+// the token uses CircleCI's default for them.
+type Claims struct {
+	Audience          []string  `json:"audience,omitempty"`
+	AudienceUpdatedAt time.Time `json:"audience_updated_at"`
+	OrgID             string    `json:"org_id,omitempty"`
+	ProjectID         string    `json:"project_id,omitempty"`
+	TTL               string    `json:"ttl,omitempty"`
+	TTLUpdatedAt      time.Time `json:"ttl_updated_at"`
+}
+
+// Update sets custom claims. Claims left empty are not changed.
+type Update struct {
+	Audience []string `json:"audience,omitempty"`
+	TTL      string   `json:"ttl,omitempty"`
+}
+
+// Service manages the custom claims of OIDC tokens.
+type Service struct {
+	client *client.Client
+}
+
+// NewService returns a Service that uses c.
+func NewService(c *client.Client) *Service {
+	return &Service{client: c}
+}
+
+// GetOrgClaims returns the organization's custom claims.
+func (s *Service) GetOrgClaims(ctx context.Context, orgID string) (*Claims, error) {
+	ctx = client.WithOrganization(ctx, orgID)
+	return s.get(ctx, orgClaimsPath(orgID))
+}
+
+// UpdateOrgClaims sets the organization's custom claims.
+func (s *Service) UpdateOrgClaims(ctx context.Context, orgID string, u Update) (*Claims, error) {
+	ctx = client.WithOrganization(ctx, orgID)
+	return s.update(ctx, orgClaimsPath(orgID), u)
+}
+
+// DeleteOrgClaims unsets the organization's custom claims with the given
+// names, ClaimAudience or ClaimTTL, so its tokens use CircleCI's defaults.
+func (s *Service) DeleteOrgClaims(ctx context.Context, orgID string, claims ...string) (*Claims, error) {
+	ctx = client.WithOrganization(ctx, orgID)
+	return s.delete(ctx, orgClaimsPath(orgID), claims)
+}
+
+// GetProjectClaims returns the project's custom claims. They override the
+// organization's.
+func (s *Service) GetProjectClaims(ctx context.Context, orgID, projectID string) (*Claims, error) {
+	ctx = client.WithOrganization(ctx, orgID)
+	return s.get(ctx, projectClaimsPath(orgID, projectID))
+}
+
+// UpdateProjectClaims sets the project's custom claims.
+func (s *Service) UpdateProjectClaims(ctx context.Context, orgID, projectID string, u Update) (*Claims, error) {
+	ctx = client.WithOrganization(ctx, orgID)
+	return s.update(ctx, projectClaimsPath(orgID, projectID), u)
+}
+
+// DeleteProjectClaims unsets the project's custom claims with the given
+// names, ClaimAudience or ClaimTTL, so its tokens use the organization's.
+func (s *Service) DeleteProjectClaims(ctx context.Context, orgID, projectID string, claims ...string) (*Claims, error) {
+	ctx = client.WithOrganization(ctx, orgID)
+	return s.delete(ctx, projectClaimsPath(orgID, projectID), claims)
+}
+
+func orgClaimsPath(orgID string) string {
+	return fmt.Sprintf("/org/%s/oidc-custom-claims", orgID)
+}
+
+func projectClaimsPath(orgID, projectID string) string {
+	return fmt.Sprintf("/org/%s/project/%s/oidc-custom-claims", orgID, projectID)
+}
+
+func (s *Service) get(ctx context.Context, path string) (*Claims, error) {
+	var claims Claims
+	_, err := s.client.RequestHelper(ctx, http.MethodGet, path, nil, &claims)
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+func (s *Service) update(ctx context.Context, path string, u Update) (*Claims, error) {
+	var claims Claims
+	_, err := s.client.RequestHelper(ctx, http.MethodPatch, path, u, &claims)
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+func (s *Service) delete(ctx context.Context, path string, names []string) (*Claims, error) {
+	values := url.Values{}
+	values.Set("claims", strings.Join(names, ","))
+
+	var claims Claims
+	_, err := s.client.RequestHelper(ctx, http.MethodDelete, path+"?"+values.Encode(), nil, &claims)
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
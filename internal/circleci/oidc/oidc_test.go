@@ -0,0 +1,136 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/oidc"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+const testTok = "4f7a2c9e-81d3-4b6a-a5e0-2c8d9f1b3e7a"
+
+func setup(t *testing.T) (*fakecircle.Service, *oidc.Service, fakecircle.Project) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+
+	return fc, oidc.NewService(c), prj
+}
+
+func TestService_Org(t *testing.T) {
+	ctx := context.TODO()
+	fc, s, prj := setup(t)
+	orgID := prj.Org.ID.String()
+
+	t.Run("get_unset", func(t *testing.T) {
+		claims, err := s.GetOrgClaims(ctx, orgID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(claims.OrgID, orgID))
+		assert.Check(t, cmp.Len(claims.Audience, 0))
+		assert.Check(t, cmp.Equal(claims.TTL, ""))
+		assert.Check(t, claims.AudienceUpdatedAt.IsZero())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		claims, err := s.UpdateOrgClaims(ctx, orgID, oidc.Update{
+			Audience: []string{"sts.amazonaws.com", "gcp"},
+			TTL:      "30m",
+		})
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(claims.Audience, []string{"sts.amazonaws.com", "gcp"}))
+		assert.Check(t, cmp.Equal(claims.TTL, "30m"))
+		assert.Check(t, !claims.AudienceUpdatedAt.IsZero())
+		assert.Check(t, !claims.TTLUpdatedAt.IsZero())
+
+		got, err := fc.OrgOIDCClaims(prj.Org.ID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(got.TTL, "30m"))
+	})
+
+	t.Run("update_one", func(t *testing.T) {
+		claims, err := s.UpdateOrgClaims(ctx, orgID, oidc.Update{TTL: "1h"})
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(claims.Audience, []string{"sts.amazonaws.com", "gcp"}))
+		assert.Check(t, cmp.Equal(claims.TTL, "1h"))
+	})
+
+	t.Run("invalid_ttl", func(t *testing.T) {
+		_, err := s.UpdateOrgClaims(ctx, orgID, oidc.Update{TTL: "48h"})
+		assert.Check(t, client.HasStatus(err, http.StatusBadRequest))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		claims, err := s.DeleteOrgClaims(ctx, orgID, oidc.ClaimAudience)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(claims.Audience, 0))
+		assert.Check(t, cmp.Equal(claims.TTL, "1h"))
+
+		claims, err = s.DeleteOrgClaims(ctx, orgID, oidc.ClaimAudience, oidc.ClaimTTL)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(claims.TTL, ""))
+
+		got, err := s.GetOrgClaims(ctx, orgID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(got.TTL, ""))
+	})
+
+	t.Run("unknown_org", func(t *testing.T) {
+		_, err := s.GetOrgClaims(ctx, "5e2a3c1f-0000-4000-8000-000000000000")
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
+
+func TestService_Project(t *testing.T) {
+	ctx := context.TODO()
+	fc, s, prj := setup(t)
+	orgID, projectID := prj.Org.ID.String(), prj.ID.String()
+
+	claims, err := s.UpdateProjectClaims(ctx, orgID, projectID, oidc.Update{Audience: []string{"vault"}})
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Equal(claims.OrgID, orgID))
+	assert.Check(t, cmp.Equal(claims.ProjectID, projectID))
+	assert.Check(t, cmp.DeepEqual(claims.Audience, []string{"vault"}))
+
+	// Project claims are separate from the org's.
+	org, err := s.GetOrgClaims(ctx, orgID)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Len(org.Audience, 0))
+
+	got, err := s.GetProjectClaims(ctx, orgID, projectID)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.DeepEqual(got.Audience, []string{"vault"}))
+
+	_, err = s.DeleteProjectClaims(ctx, orgID, projectID, oidc.ClaimAudience, oidc.ClaimTTL)
+	assert.Assert(t, err)
+	fake, err := fc.ProjectOIDCClaims(prj.ID)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Len(fake.Audience, 0))
+
+	_, err = s.GetProjectClaims(ctx, orgID, "5e2a3c1f-0000-4000-8000-000000000000")
+	assert.Check(t, client.IsNotFound(err))
+}
@@ -38,6 +38,7 @@ func (s *OrganizationService) Create(ctx context.Context, name, vcsType string)
 }
 
 func (s *OrganizationService) Get(ctx context.Context, orgID string) (*Organization, error) {
+	ctx = client.WithOrganization(ctx, orgID)
 	org := &Organization{}
 	_, err := s.client.RequestHelper(ctx, http.MethodGet, "/organization/"+orgID, nil, org)
 	if err != nil {
@@ -47,6 +48,7 @@ func (s *OrganizationService) Get(ctx context.Context, orgID string) (*Organizat
 }
 
 func (s *OrganizationService) Delete(ctx context.Context, orgID string) (err error) {
+	ctx = client.WithOrganization(ctx, orgID)
 	_, err = s.client.RequestHelper(ctx, http.MethodDelete, "/organization/"+orgID, nil, nil)
 	return err
 }
@@ -54,4 +54,10 @@ func TestOrganizationService_Create(t *testing.T) {
 		err := os.Delete(ctx, org.Id)
 		assert.Assert(t, err)
 	})
+	t.Run("get_deleted", func(t *testing.T) {
+		ctx := context.TODO()
+		organization, err := os.Get(ctx, org.Id)
+		assert.Check(t, client.IsNotFound(err))
+		assert.Check(t, cmp.Nil(organization))
+	})
 }
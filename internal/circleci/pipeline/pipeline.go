@@ -21,10 +21,6 @@ type Pipeline struct {
 	CheckoutSource common.CheckoutSource `json:"checkout_source,omitzero"`
 }
 
-type PipelineItems struct {
-	Items []Pipeline `json:"items"`
-}
-
 type PipelineService struct {
 	client *client.Client
 }
@@ -44,13 +40,7 @@ func (s *PipelineService) Get(ctx context.Context, projectID, pipelineID string)
 }
 
 func (s *PipelineService) List(ctx context.Context, projectID string) (_ []Pipeline, err error) {
-	var pipelineItems PipelineItems
-	_, err = s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/pipeline-definitions", projectID), nil, &pipelineItems)
-	if err != nil {
-		return nil, err
-	}
-
-	return pipelineItems.Items, nil
+	return client.Collect(client.Paginate[Pipeline](ctx, s.client, fmt.Sprintf("/projects/%s/pipeline-definitions", projectID)))
 }
 
 func (s *PipelineService) Create(ctx context.Context, newPipeline Pipeline, projectID string) (_ *Pipeline, err error) {
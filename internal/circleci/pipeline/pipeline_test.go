@@ -0,0 +1,175 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package pipeline_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/common"
+	"terraform-provider-circleci/internal/circleci/pipeline"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+const testTok = "0b6f1c4e-6f0c-4d5e-a5b4-3f1d0e2a9c71"
+
+func setup(t *testing.T) (*fakecircle.Service, *pipeline.PipelineService, fakecircle.Project) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+
+	return fc, pipeline.NewPipelineService(c), prj
+}
+
+func newPipeline(name string) pipeline.Pipeline {
+	return pipeline.Pipeline{
+		Name:        name,
+		Description: "a test pipeline",
+		ConfigSource: common.ConfigSource{
+			Provider: "github_app",
+			Repo: common.Repo{
+				FullName:   "test-org/test-repo",
+				ExternalId: "123456",
+			},
+			FilePath: ".circleci/config.yml",
+		},
+		CheckoutSource: common.CheckoutSource{
+			Provider: "github_app",
+			Repo: common.Repo{
+				FullName:   "test-org/test-repo",
+				ExternalId: "123456",
+			},
+		},
+	}
+}
+
+func TestPipelineService(t *testing.T) {
+	ctx := context.TODO()
+	fc, ps, prj := setup(t)
+
+	var created *pipeline.Pipeline
+	t.Run("create", func(t *testing.T) {
+		var err error
+		created, err = ps.Create(ctx, newPipeline("test pipeline"), prj.ID.String())
+		assert.Assert(t, err)
+		want := newPipeline("test pipeline")
+		assert.Check(t, cmp.DeepEqual(created, &want, cmpopts.IgnoreFields(pipeline.Pipeline{}, "ID", "CreatedAt")))
+		assert.Check(t, created.ID != "")
+		assert.Check(t, created.CreatedAt != "")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ps.Get(ctx, prj.ID.String(), created.ID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(got, created))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		_, err := ps.Create(ctx, newPipeline("other pipeline"), prj.ID.String())
+		assert.Assert(t, err)
+
+		got, err := ps.List(ctx, prj.ID.String())
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(got, 2))
+		assert.Check(t, cmp.DeepEqual(got[0], *created))
+		assert.Check(t, cmp.Equal(got[1].Name, "other pipeline"))
+	})
+
+	t.Run("list_pages", func(t *testing.T) {
+		fc.SetPageSize(1)
+		t.Cleanup(func() { fc.SetPageSize(20) })
+
+		got, err := ps.List(ctx, prj.ID.String())
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(got, 2))
+	})
+
+	t.Run("list_zero_page_size", func(t *testing.T) {
+		fc.SetPageSize(0)
+		t.Cleanup(func() { fc.SetPageSize(20) })
+
+		got, err := ps.List(ctx, prj.ID.String())
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(got, 2))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		got, err := ps.Update(ctx, pipeline.Pipeline{
+			Description: "updated",
+			ConfigSource: common.ConfigSource{
+				FilePath: ".circleci/other.yml",
+			},
+		}, prj.ID.String(), created.ID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(got.Name, "test pipeline"))
+		assert.Check(t, cmp.Equal(got.Description, "updated"))
+		assert.Check(t, cmp.Equal(got.ConfigSource.FilePath, ".circleci/other.yml"))
+		assert.Check(t, cmp.Equal(got.ConfigSource.Repo.ExternalId, "123456"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := ps.Delete(ctx, prj.ID.String(), created.ID)
+		assert.Assert(t, err)
+
+		_, err = ps.Get(ctx, prj.ID.String(), created.ID)
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
+
+func TestPipelineService_Errors(t *testing.T) {
+	ctx := context.TODO()
+	_, ps, prj := setup(t)
+
+	t.Run("missing_name", func(t *testing.T) {
+		_, err := ps.Create(ctx, newPipeline(""), prj.ID.String())
+		assert.Check(t, client.HasStatus(err, 400))
+		assert.Check(t, cmp.ErrorContains(err, "name is required"))
+	})
+
+	t.Run("bad_provider", func(t *testing.T) {
+		p := newPipeline("bad provider")
+		p.ConfigSource.Provider = "bitbucket"
+		_, err := ps.Create(ctx, p, prj.ID.String())
+		assert.Check(t, client.HasStatus(err, 400))
+		assert.Check(t, cmp.ErrorContains(err, "config_source.provider"))
+	})
+
+	t.Run("duplicate_name", func(t *testing.T) {
+		_, err := ps.Create(ctx, newPipeline("dup"), prj.ID.String())
+		assert.Assert(t, err)
+		_, err = ps.Create(ctx, newPipeline("dup"), prj.ID.String())
+		assert.Check(t, client.IsConflict(err))
+	})
+
+	t.Run("unknown_project", func(t *testing.T) {
+		_, err := ps.List(ctx, "5e2a3c1f-0000-4000-8000-000000000000")
+		assert.Check(t, client.IsNotFound(err))
+	})
+
+	t.Run("delete_missing", func(t *testing.T) {
+		err := ps.Delete(ctx, prj.ID.String(), "5e2a3c1f-0000-4000-8000-000000000000")
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
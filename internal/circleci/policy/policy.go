@@ -0,0 +1,250 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+// configContext is the policy context of config policies, which decide
+// whether a pipeline's config may run. It is the only context CircleCI has.
+const configContext = "config"
+
+// The statuses of a decision.
+const (
+	StatusPass     = "PASS"
+	StatusSoftFail = "SOFT_FAIL"
+	StatusHardFail = "HARD_FAIL"
+	StatusError    = "ERROR"
+)
+
+// Policy is one rego file of a bundle.
+type Policy struct {
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// Bundle is an organization's policies, keyed by name.
+type Bundle map[string][]Policy
+
+// Contents returns the content of each policy in the bundle, keyed by name.
+func (b Bundle) Contents() map[string]string {
+	contents := make(map[string]string, len(b))
+	for _, policies := range b {
+		for _, p := range policies {
+			contents[p.Name] = p.Content
+		}
+	}
+	return contents
+}
+
+// BundleDiff names the policies that pushing a bundle created, deleted and
+// modified.
+type BundleDiff struct {
+	Created  []string `json:"created"`
+	Deleted  []string `json:"deleted"`
+	Modified []string `json:"modified"`
+}
+
+// Settings are an organization's decision settings.
+type Settings struct {
+	// Enabled is whether pipelines' config is checked against the
+	// organization's policies.
+	Enabled bool `json:"enabled"`
+}
+
+// Failure is a rule a config failed.
+type Failure struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+// Decision is the result of checking a config against the policies.
+type Decision struct {
+	Status       string    `json:"status"`
+	Reason       string    `json:"reason,omitempty"`
+	EnabledRules []string  `json:"enabled_rules,omitempty"`
+	HardFailures []Failure `json:"hard_failures,omitempty"`
+	SoftFailures []Failure `json:"soft_failures,omitempty"`
+}
+
+// DecisionMetadata describes the pipeline a decision was made for.
+type DecisionMetadata struct {
+	BuildNumber int64  `json:"build_number"`
+	ProjectID   string `json:"project_id"`
+	SSHRerun    bool   `json:"ssh_rerun"`
+	VCS         VCS    `json:"vcs"`
+}
+
+// VCS describes the commit a pipeline ran on.
+type VCS struct {
+	Branch              string `json:"branch,omitempty"`
+	OriginRepositoryURL string `json:"origin_repository_url,omitempty"`
+	ReleaseTag          string `json:"release_tag,omitempty"`
+	TargetRepositoryURL string `json:"target_repository_url,omitempty"`
+}
+
+// DecisionLog is an entry of an organization's decision audit log.
+type DecisionLog struct {
+	ID          string            `json:"id"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Decision    Decision          `json:"decision"`
+	Metadata    DecisionMetadata  `json:"metadata"`
+	Policies    map[string]string `json:"policies,omitempty"`
+	TimeTakenMS int64             `json:"time_taken_ms"`
+}
+
+// DecisionFilter selects entries of the decision audit log. Zero fields
+// match every entry.
+type DecisionFilter struct {
+	Status    string
+	After     time.Time
+	Before    time.Time
+	Branch    string
+	ProjectID string
+	// Offset skips that many of the newest matching entries.
+	Offset int
+}
+
+// DefaultBaseURL is where circleci.com serves the config policy API, which
+// is not on the same host as the v2 API.
+const DefaultBaseURL = "https://internal.circleci.com"
+
+// Service manages an organization's config policies.
+type Service struct {
+	client  *client.Client
+	baseURL string
+}
+
+// NewService returns a Service that uses c against circleci.com.
+func NewService(c *client.Client) *Service {
+	return &Service{
+		client:  c,
+		baseURL: DefaultBaseURL,
+	}
+}
+
+// NewServiceWithBaseURL returns a Service that uses c against the config
+// policy API at baseURL, which is the host without an /api path, e.g.
+// "https://circleci.example.com".
+func NewServiceWithBaseURL(c *client.Client, baseURL string) *Service {
+	return &Service{
+		client:  c,
+		baseURL: baseURL,
+	}
+}
+
+// GetBundle returns the organization's policy bundle.
+func (s *Service) GetBundle(ctx context.Context, ownerID string) (Bundle, error) {
+	ctx = client.WithOrganization(ctx, ownerID)
+	var bundle Bundle
+	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodGet, s.bundleURL(ownerID), nil, &bundle)
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// PushBundle replaces the organization's policy bundle with policies, the
+// content of each policy keyed by name. With dry set, nothing is changed,
+// and the returned diff is what pushing would do.
+func (s *Service) PushBundle(ctx context.Context, ownerID string, policies map[string]string, dry bool) (*BundleDiff, error) {
+	ctx = client.WithOrganization(ctx, ownerID)
+	if policies == nil {
+		policies = map[string]string{}
+	}
+	payload := map[string]any{
+		"policies": policies,
+	}
+
+	path := s.bundleURL(ownerID)
+	if dry {
+		path += "?dry=true"
+	}
+
+	var diff BundleDiff
+	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodPost, path, payload, &diff)
+	if err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// GetSettings returns the organization's decision settings.
+func (s *Service) GetSettings(ctx context.Context, ownerID string) (*Settings, error) {
+	ctx = client.WithOrganization(ctx, ownerID)
+	var settings Settings
+	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodGet, s.decisionURL(ownerID)+"/settings", nil, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateSettings changes the organization's decision settings.
+func (s *Service) UpdateSettings(ctx context.Context, ownerID string, settings Settings) (*Settings, error) {
+	ctx = client.WithOrganization(ctx, ownerID)
+	var updated Settings
+	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodPatch, s.decisionURL(ownerID)+"/settings", settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// ListDecisions returns a page of the organization's decision audit log
+// entries that match filter, newest first. An empty page means there are no
+// more.
+func (s *Service) ListDecisions(ctx context.Context, ownerID string, filter DecisionFilter) ([]DecisionLog, error) {
+	ctx = client.WithOrganization(ctx, ownerID)
+
+	values := url.Values{}
+	if filter.Status != "" {
+		values.Set("status", filter.Status)
+	}
+	if !filter.After.IsZero() {
+		values.Set("after", filter.After.Format(time.RFC3339))
+	}
+	if !filter.Before.IsZero() {
+		values.Set("before", filter.Before.Format(time.RFC3339))
+	}
+	if filter.Branch != "" {
+		values.Set("branch", filter.Branch)
+	}
+	if filter.ProjectID != "" {
+		values.Set("project_id", filter.ProjectID)
+	}
+	if filter.Offset > 0 {
+		values.Set("offset", strconv.Itoa(filter.Offset))
+	}
+
+	path := s.decisionURL(ownerID)
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	var decisions []DecisionLog
+	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodGet, path, nil, &decisions)
+	if err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+func (s *Service) bundleURL(ownerID string) string {
+	return fmt.Sprintf("%s/api/v1/owner/%s/context/%s/policy-bundle", s.baseURL, ownerID, configContext)
+}
+
+func (s *Service) decisionURL(ownerID string) string {
+	return fmt.Sprintf("%s/api/v1/owner/%s/context/%s/decision", s.baseURL, ownerID, configContext)
+}
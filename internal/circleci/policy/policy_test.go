@@ -0,0 +1,204 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package policy_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/policy"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+const testTok = "9c1e4b7a-2d5f-4e8a-b3c6-7f0a1d2e5b8c"
+
+func setup(t *testing.T) (*fakecircle.Service, *policy.Service, fakecircle.Project) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+
+	return fc, policy.NewServiceWithBaseURL(c, srv.URL), prj
+}
+
+const (
+	branchPolicy = "package org\n\npolicy_name[\"branches\"]\n"
+	imagePolicy  = "package org\n\npolicy_name[\"images\"]\n"
+)
+
+func TestService_Bundle(t *testing.T) {
+	ctx := context.TODO()
+	fc, s, prj := setup(t)
+	orgID := prj.Org.ID.String()
+
+	t.Run("get_empty", func(t *testing.T) {
+		bundle, err := s.GetBundle(ctx, orgID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(bundle, 0))
+	})
+
+	t.Run("push", func(t *testing.T) {
+		diff, err := s.PushBundle(ctx, orgID, map[string]string{
+			"branches.rego": branchPolicy,
+			"images.rego":   imagePolicy,
+		}, false)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(diff, &policy.BundleDiff{
+			Created:  []string{"branches.rego", "images.rego"},
+			Deleted:  []string{},
+			Modified: []string{},
+		}))
+
+		bundle, err := s.GetBundle(ctx, orgID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(bundle.Contents(), map[string]string{
+			"branches.rego": branchPolicy,
+			"images.rego":   imagePolicy,
+		}))
+		assert.Check(t, !bundle["branches.rego"][0].CreatedAt.IsZero())
+	})
+
+	t.Run("dry_run", func(t *testing.T) {
+		diff, err := s.PushBundle(ctx, orgID, map[string]string{
+			"branches.rego": branchPolicy + "# changed\n",
+		}, true)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(diff, &policy.BundleDiff{
+			Created:  []string{},
+			Deleted:  []string{"images.rego"},
+			Modified: []string{"branches.rego"},
+		}))
+
+		got, err := fc.PolicyBundle(prj.Org.ID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(got, 2))
+		assert.Check(t, cmp.Equal(got["branches.rego"], branchPolicy))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.PushBundle(ctx, orgID, map[string]string{"bad.rego": "allow = true"}, false)
+		assert.Check(t, client.HasStatus(err, http.StatusBadRequest))
+	})
+
+	t.Run("push_empty", func(t *testing.T) {
+		diff, err := s.PushBundle(ctx, orgID, nil, false)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(diff.Deleted, []string{"branches.rego", "images.rego"}))
+
+		bundle, err := s.GetBundle(ctx, orgID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(bundle, 0))
+	})
+
+	t.Run("unknown_owner", func(t *testing.T) {
+		_, err := s.GetBundle(ctx, "5e2a3c1f-0000-4000-8000-000000000000")
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
+
+func TestService_Settings(t *testing.T) {
+	ctx := context.TODO()
+	fc, s, prj := setup(t)
+	orgID := prj.Org.ID.String()
+
+	settings, err := s.GetSettings(ctx, orgID)
+	assert.Assert(t, err)
+	assert.Check(t, !settings.Enabled)
+
+	settings, err = s.UpdateSettings(ctx, orgID, policy.Settings{Enabled: true})
+	assert.Assert(t, err)
+	assert.Check(t, settings.Enabled)
+
+	enabled, err := fc.PolicyDecisionsEnabled(prj.Org.ID)
+	assert.Assert(t, err)
+	assert.Check(t, enabled)
+
+	_, err = s.GetSettings(ctx, "5e2a3c1f-0000-4000-8000-000000000000")
+	assert.Check(t, client.IsNotFound(err))
+}
+
+func TestService_ListDecisions(t *testing.T) {
+	ctx := context.TODO()
+	fc, s, prj := setup(t)
+	orgID := prj.Org.ID.String()
+	fc.SetPageSize(2)
+
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i, status := range []string{
+		fakecircle.PolicyStatusPass,
+		fakecircle.PolicyStatusHardFail,
+		fakecircle.PolicyStatusPass,
+		fakecircle.PolicyStatusSoftFail,
+	} {
+		_, err := fc.AddPolicyDecision(prj.Org.ID, fakecircle.PolicyDecision{
+			CreatedAt:    start.Add(time.Duration(i) * time.Hour),
+			Status:       status,
+			EnabledRules: []string{"use_official_docker_image"},
+			HardFailures: []fakecircle.PolicyFailure{{Rule: "use_official_docker_image", Reason: "not official"}},
+			ProjectID:    prj.ID,
+			Branch:       "main",
+			BuildNumber:  int64(i + 1),
+			TimeTaken:    12 * time.Millisecond,
+		})
+		assert.Assert(t, err)
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		page, err := s.ListDecisions(ctx, orgID, policy.DecisionFilter{})
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(page, 2))
+		assert.Check(t, cmp.Equal(page[0].Metadata.BuildNumber, int64(4)))
+		assert.Check(t, cmp.Equal(page[0].Decision.Status, policy.StatusSoftFail))
+		assert.Check(t, cmp.Equal(page[0].Metadata.ProjectID, prj.ID.String()))
+		assert.Check(t, cmp.Equal(page[0].Metadata.VCS.Branch, "main"))
+		assert.Check(t, cmp.Equal(page[0].TimeTakenMS, int64(12)))
+		assert.Check(t, cmp.DeepEqual(page[0].Decision.HardFailures, []policy.Failure{
+			{Rule: "use_official_docker_image", Reason: "not official"},
+		}))
+
+		page, err = s.ListDecisions(ctx, orgID, policy.DecisionFilter{Offset: 2})
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(page, 2))
+		assert.Check(t, cmp.Equal(page[1].Metadata.BuildNumber, int64(1)))
+
+		page, err = s.ListDecisions(ctx, orgID, policy.DecisionFilter{Offset: 4})
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(page, 0))
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		page, err := s.ListDecisions(ctx, orgID, policy.DecisionFilter{
+			Status: policy.StatusPass,
+			After:  start,
+		})
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(page, 1))
+		assert.Check(t, cmp.Equal(page[0].Metadata.BuildNumber, int64(3)))
+
+		page, err = s.ListDecisions(ctx, orgID, policy.DecisionFilter{Branch: "release"})
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(page, 0))
+	})
+}
@@ -48,6 +48,7 @@ func NewProjectService(c *client.Client) *ProjectService {
 }
 
 func (s *ProjectService) Get(ctx context.Context, slug string) (_ *Project, err error) {
+	ctx = client.WithProject(ctx, slug)
 	var project Project
 	_, err = s.client.RequestHelper(ctx, http.MethodGet, "/project/"+slug, nil, &project)
 	if err != nil {
@@ -58,6 +59,7 @@ func (s *ProjectService) Get(ctx context.Context, slug string) (_ *Project, err
 }
 
 func (s *ProjectService) Create(ctx context.Context, projectName, organizationID string) (_ *Project, err error) {
+	ctx = client.WithOrganization(ctx, organizationID)
 	payload := map[string]string{
 		"name": projectName,
 	}
@@ -82,12 +84,14 @@ func (s *ProjectService) Create(ctx context.Context, projectName, organizationID
 
 // Delete - Only standalone projects can be deleted.
 func (s *ProjectService) Delete(ctx context.Context, slug string) (err error) {
+	ctx = client.WithProject(ctx, slug)
 	_, err = s.client.RequestHelper(ctx, http.MethodDelete, fmt.Sprintf("/project/%s", slug), nil, nil)
 	return err
 }
 
 // GetSettings - Settings are only available for standalone projects.
 func (s *ProjectService) GetSettings(ctx context.Context, provider, organization, project string) (_ *ProjectSettings, err error) {
+	ctx = client.WithOrganization(ctx, provider+"/"+organization)
 	var settings ProjectSettings
 	_, err = s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/project/%s/%s/%s/settings", provider, organization, project), nil, &settings)
 	if err != nil {
@@ -98,6 +102,7 @@ func (s *ProjectService) GetSettings(ctx context.Context, provider, organization
 
 // UpdateSettings - Settings are only available for standalone projects.
 func (s *ProjectService) UpdateSettings(ctx context.Context, newSettings ProjectSettings, provider, organization, project string) (_ *ProjectSettings, err error) {
+	ctx = client.WithOrganization(ctx, provider+"/"+organization)
 	var settings ProjectSettings
 	_, err = s.client.RequestHelper(ctx, http.MethodPatch, fmt.Sprintf("/project/%s/%s/%s/settings", provider, organization, project), newSettings, &settings)
 	if err != nil {
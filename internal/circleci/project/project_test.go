@@ -6,6 +6,7 @@ package project_test
 import (
 	"context"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -143,3 +144,68 @@ func TestProjectService_Delete(t *testing.T) {
 		assert.Check(t, cmp.Equal(p.ID, uuid.Nil))
 	})
 }
+
+func TestProjectService_Settings(t *testing.T) {
+	ctx := context.TODO()
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+	ps := project.NewProjectService(c)
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+	slug := strings.Split(prj.Slug, "/")
+
+	t.Run("get_defaults", func(t *testing.T) {
+		got, err := ps.GetSettings(ctx, slug[0], slug[1], slug[2])
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(got.Advanced, project.AdvanceSettings{
+			AutocancelBuilds:           common.Bool(false),
+			BuildForkPrs:               common.Bool(false),
+			DisableSSH:                 common.Bool(false),
+			ForksReceiveSecretEnvVars:  common.Bool(false),
+			OSS:                        common.Bool(false),
+			SetGithubStatus:            common.Bool(true),
+			SetupWorkflows:             common.Bool(false),
+			WriteSettingsRequiresAdmin: common.Bool(false),
+			PROnlyBranchOverrides:      []string{"main"},
+		}))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		got, err := ps.UpdateSettings(ctx, project.ProjectSettings{
+			Advanced: project.AdvanceSettings{
+				AutocancelBuilds:      common.Bool(true),
+				SetGithubStatus:       common.Bool(false),
+				PROnlyBranchOverrides: []string{"main", "release"},
+			},
+		}, slug[0], slug[1], slug[2])
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(got.Advanced.AutocancelBuilds, common.Bool(true)))
+		assert.Check(t, cmp.DeepEqual(got.Advanced.SetGithubStatus, common.Bool(false)))
+		assert.Check(t, cmp.DeepEqual(got.Advanced.DisableSSH, common.Bool(false)))
+		assert.Check(t, cmp.DeepEqual(got.Advanced.PROnlyBranchOverrides, []string{"main", "release"}))
+	})
+
+	t.Run("get_updated", func(t *testing.T) {
+		got, err := fc.ProjectSettings(prj.ID)
+		assert.Assert(t, err)
+		assert.Check(t, got.AutocancelBuilds)
+		assert.Check(t, !got.SetGitHubStatus)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		_, err := ps.GetSettings(ctx, slug[0], slug[1], "5e2a3c1f-0000-4000-8000-000000000000")
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
@@ -106,8 +106,10 @@ func NewServiceWithBaseURL(c *client.Client, baseURL string) *Service {
 }
 
 // ListRunners returns a list of runners filtered by the provided parameters.
-// At least one filter parameter should be provided.
+// At least one filter parameter should be provided. The API returns the
+// runners in an object's items, as it does resource classes and tokens.
 func (s *Service) ListRunners(ctx context.Context, params ListRunnersParams) ([]Runner, error) {
+	ctx = client.WithOrganization(ctx, params.OrgID)
 	values := url.Values{}
 	if params.ResourceClass != "" {
 		values.Add("resource-class", params.ResourceClass)
@@ -124,18 +126,13 @@ func (s *Service) ListRunners(ctx context.Context, params ListRunnersParams) ([]
 		query = "?" + values.Encode()
 	}
 
-	var runners []Runner
-	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodGet, s.baseURL+"/api/v3/runner"+query, nil, &runners)
-	if err != nil {
-		return nil, err
-	}
-
-	return runners, nil
+	return client.Collect(client.PaginateAbsolute[Runner](ctx, s.client, s.baseURL+"/api/v3/runner"+query))
 }
 
 // ListResourceClasses returns a list of resource classes filtered by namespace and/or organization ID.
 // At least one filter parameter should be provided.
 func (s *Service) ListResourceClasses(ctx context.Context, namespace, orgID string) (*ResourceClassItems, error) {
+	ctx = client.WithOrganization(ctx, orgID)
 	values := url.Values{}
 	if namespace != "" {
 		values.Add("namespace", namespace)
@@ -149,17 +146,17 @@ func (s *Service) ListResourceClasses(ctx context.Context, namespace, orgID stri
 		query = "?" + values.Encode()
 	}
 
-	var resourceClassItems ResourceClassItems
-	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodGet, s.baseURL+"/api/v3/runner/resource"+query, nil, &resourceClassItems)
+	items, err := client.Collect(client.PaginateAbsolute[ResourceClass](ctx, s.client, s.baseURL+"/api/v3/runner/resource"+query))
 	if err != nil {
 		return nil, err
 	}
 
-	return &resourceClassItems, nil
+	return &ResourceClassItems{Items: items}, nil
 }
 
 // CreateResourceClass creates a new runner resource class.
 func (s *Service) CreateResourceClass(ctx context.Context, req CreateResourceClassRequest) (*ResourceClass, error) {
+	ctx = client.WithOrganization(ctx, req.OrganizationID)
 	var resourceClass ResourceClass
 	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodPost, s.baseURL+"/api/v3/runner/resource", req, &resourceClass)
 	if err != nil {
@@ -193,18 +190,18 @@ func (s *Service) ListTokens(ctx context.Context, resourceClass string) (*TokenI
 		query = "?" + values.Encode()
 	}
 
-	var tokens TokenItems
-	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodGet, s.baseURL+"/api/v3/runner/token"+query, nil, &tokens)
+	items, err := client.Collect(client.PaginateAbsolute[Token](ctx, s.client, s.baseURL+"/api/v3/runner/token"+query))
 	if err != nil {
 		return nil, err
 	}
 
-	return &tokens, nil
+	return &TokenItems{Items: items}, nil
 }
 
 // CreateToken creates a new runner token.
 // The token value is only returned in the response and cannot be retrieved later.
 func (s *Service) CreateToken(ctx context.Context, req CreateTokenRequest) (*Token, error) {
+	ctx = client.WithOrganization(ctx, req.OrganizationID)
 	var token Token
 	_, err := s.client.RequestHelperAbsolute(ctx, http.MethodPost, s.baseURL+"/api/v3/runner/token", req, &token)
 	if err != nil {
@@ -5,6 +5,7 @@ package runner_test
 
 import (
 	"context"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -155,6 +156,78 @@ func TestListRunners(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestListRunners_Connected(t *testing.T) {
+	ctx := context.TODO()
+	fs := fakecircle.New(testToken)
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+	service := runner.NewServiceWithBaseURL(client.NewClient(srv.URL, testToken, "terraform-provider-circleci/test"), srv.URL)
+
+	fs.AddRunner(fakecircle.Runner{
+		Name:          "runner-1",
+		Hostname:      "host-1",
+		ResourceClass: "test-org/connected",
+		Status:        "online",
+	})
+	fs.AddRunner(fakecircle.Runner{
+		Name:          "runner-2",
+		ResourceClass: "test-org/other",
+	})
+
+	runners, err := service.ListRunners(ctx, runner.ListRunnersParams{
+		ResourceClass: "test-org/connected",
+	})
+	assert.NilError(t, err)
+	assert.Check(t, cmp.DeepEqual(runners, []runner.Runner{{
+		Name:          "runner-1",
+		Hostname:      "host-1",
+		ResourceClass: "test-org/connected",
+		Status:        "online",
+	}}))
+}
+
+// TestListRunners_APIResponse decodes a response in the shape the runner API
+// documents for GET /api/v3/runner, without the fake, so the fake and the
+// client cannot agree on a shape the API does not use.
+func TestListRunners_APIResponse(t *testing.T) {
+	ctx := context.TODO()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Check(t, cmp.Equal(r.URL.Path, "/api/v3/runner"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+  "items": [
+    {
+      "resource_class": "test-namespace/test-resource",
+      "hostname": "bobby",
+      "name": "bobby-sue",
+      "first_connected": "2020-05-15T00:00:00Z",
+      "last_connected": "2020-05-16T00:00:00Z",
+      "last_used": "2020-05-17T00:00:00Z",
+      "version": "5.4.3",
+      "ip": "127.0.0.1"
+    }
+  ]
+}`))
+	}))
+	t.Cleanup(srv.Close)
+	service := runner.NewServiceWithBaseURL(client.NewClient(srv.URL, testToken, "terraform-provider-circleci/test"), srv.URL)
+
+	runners, err := service.ListRunners(ctx, runner.ListRunnersParams{
+		ResourceClass: "test-namespace/test-resource",
+	})
+	assert.NilError(t, err)
+	assert.Check(t, cmp.DeepEqual(runners, []runner.Runner{{
+		Name:           "bobby-sue",
+		Hostname:       "bobby",
+		IP:             "127.0.0.1",
+		Version:        "5.4.3",
+		ResourceClass:  "test-namespace/test-resource",
+		FirstConnected: "2020-05-15T00:00:00Z",
+		LastConnected:  "2020-05-16T00:00:00Z",
+		LastUsed:       "2020-05-17T00:00:00Z",
+	}}))
+}
+
 func TestTaskCounts(t *testing.T) {
 	ctx := context.TODO()
 	service := setupTest(t)
@@ -0,0 +1,105 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+// Package sshkey manages a project's additional SSH keys, which jobs use to
+// reach hosts other than the VCS. They are only in the v1.1 API.
+package sshkey
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+// Key is an additional SSH key. CircleCI never returns the private key.
+type Key struct {
+	Hostname    string `json:"hostname"`
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Service manages the additional SSH keys of projects.
+type Service struct {
+	client  *client.Client
+	baseURL string
+}
+
+// NewService returns a Service that uses c against circleci.com.
+func NewService(c *client.Client) *Service {
+	return &Service{
+		client:  c,
+		baseURL: "https://circleci.com",
+	}
+}
+
+// NewServiceWithBaseURL returns a Service that uses c against the v1.1 API
+// at baseURL, which is the host without an /api path, e.g.
+// "https://circleci.example.com".
+func NewServiceWithBaseURL(c *client.Client, baseURL string) *Service {
+	return &Service{
+		client:  c,
+		baseURL: baseURL,
+	}
+}
+
+func (s *Service) projectURL(projectSlug, suffix string) string {
+	return fmt.Sprintf("%s/api/v1.1/project/%s/%s", s.baseURL, projectSlug, suffix)
+}
+
+// List returns the project's additional SSH keys, which are part of its
+// v1.1 settings.
+func (s *Service) List(ctx context.Context, projectSlug string) (_ []Key, err error) {
+	ctx = client.WithProject(ctx, projectSlug)
+	var settings struct {
+		SSHKeys []Key `json:"ssh_keys"`
+	}
+	_, err = s.client.RequestHelperAbsolute(ctx, http.MethodGet, s.projectURL(projectSlug, "settings"), nil, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return settings.SSHKeys, nil
+}
+
+// Create adds privateKey to the project as the SSH key for hostname. The API
+// does not return the new key, so Create finds it in the project's keys
+// afterwards as the one for hostname that was not there before.
+func (s *Service) Create(ctx context.Context, projectSlug, hostname, privateKey string) (_ *Key, err error) {
+	before, err := s.List(ctx, projectSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]string{
+		"hostname":    hostname,
+		"private_key": privateKey,
+	}
+	_, err = s.client.RequestHelperAbsolute(client.WithProject(ctx, projectSlug), http.MethodPost, s.projectURL(projectSlug, "ssh-key"), payload, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.List(ctx, projectSlug)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range after {
+		if k.Hostname == hostname && !slices.Contains(before, k) {
+			return &k, nil
+		}
+	}
+	return nil, fmt.Errorf("the SSH key for %s was added, but is not among the project's keys; it may duplicate a key the project already has", hostname)
+}
+
+// Delete removes the project's key for hostname with the given fingerprint.
+func (s *Service) Delete(ctx context.Context, projectSlug, hostname, fingerprint string) (err error) {
+	ctx = client.WithProject(ctx, projectSlug)
+	payload := map[string]string{
+		"hostname":    hostname,
+		"fingerprint": fingerprint,
+	}
+	_, err = s.client.RequestHelperAbsolute(ctx, http.MethodDelete, s.projectURL(projectSlug, "ssh-key"), payload, nil)
+	return err
+}
@@ -0,0 +1,121 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package sshkey_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/sshkey"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+const testTok = "5e8a2c7d-41b9-4f3e-a6d0-9c1b7e2f4a8d"
+
+func setup(t *testing.T) (*fakecircle.Service, *sshkey.Service, fakecircle.Project) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+
+	return fc, sshkey.NewServiceWithBaseURL(c, srv.URL), prj
+}
+
+func TestService(t *testing.T) {
+	ctx := context.TODO()
+	fc, ss, prj := setup(t)
+
+	privateKey, err := fakecircle.NewSSHPrivateKey()
+	assert.Assert(t, err)
+	other, err := fc.AddSSHKey(prj.ID, "other.example.com", privateKey)
+	assert.Assert(t, err)
+
+	var created *sshkey.Key
+	t.Run("create", func(t *testing.T) {
+		fc.ResetCalls()
+
+		// The same key for another host is a different key.
+		created, err = ss.Create(ctx, prj.Slug, "deploy.example.com", privateKey)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(created.Hostname, "deploy.example.com"))
+		assert.Check(t, cmp.Equal(created.Fingerprint, other.Fingerprint))
+		assert.Check(t, strings.HasPrefix(created.PublicKey, "ssh-ed25519 "))
+
+		calls := fc.CallsMatching(http.MethodPost, "/project/*/*/*/ssh-key")
+		assert.Assert(t, cmp.Len(calls, 1))
+		assert.Check(t, cmp.DeepEqual(calls[0].Body, map[string]any{
+			"hostname":    "deploy.example.com",
+			"private_key": privateKey,
+		}))
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := ss.Create(ctx, prj.Slug, "deploy.example.com", privateKey)
+		assert.Check(t, cmp.ErrorContains(err, "already exists"))
+	})
+
+	t.Run("rsa", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.Assert(t, err)
+		pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+		got, err := ss.Create(ctx, prj.Slug, "rsa.example.com", string(pemKey))
+		assert.Assert(t, err)
+		assert.Check(t, strings.HasPrefix(got.PublicKey, "ssh-rsa "))
+
+		err = ss.Delete(ctx, prj.Slug, got.Hostname, got.Fingerprint)
+		assert.Assert(t, err)
+	})
+
+	t.Run("invalid_key", func(t *testing.T) {
+		_, err := ss.Create(ctx, prj.Slug, "deploy.example.com", "not a key")
+		assert.Check(t, client.HasStatus(err, http.StatusBadRequest))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		keys, err := ss.List(ctx, prj.Slug)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(keys, []sshkey.Key{
+			{Hostname: other.Hostname, PublicKey: other.PublicKey, Fingerprint: other.Fingerprint},
+			*created,
+		}))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := ss.Delete(ctx, prj.Slug, created.Hostname, created.Fingerprint)
+		assert.Assert(t, err)
+
+		keys, err := ss.List(ctx, prj.Slug)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(keys, 1))
+
+		err = ss.Delete(ctx, prj.Slug, created.Hostname, created.Fingerprint)
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
@@ -3,15 +3,47 @@
 
 package fakecircle
 
-import "net/http"
+import (
+	"net/http"
 
-// auth rejects any request that does not carry the fake's Circle-Token.
+	"github.com/google/uuid"
+)
+
+// AddOrgToken makes tok a valid Circle-Token too, for a user that is only a
+// member of the org with the given ID. It stands in for an org admin's
+// token.
+func (s *Service) AddOrgToken(tok string, orgID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.orgTokens[tok] = orgID
+}
+
+// tokenOrg returns the org an org token is for, and false for the fake's
+// main token.
+func (s *Service) tokenOrg(r *http.Request) (uuid.UUID, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.orgTokens[r.Header.Get("Circle-Token")]
+	return id, ok
+}
+
+// canSeeOrg reports whether the request's token may see the org with the
+// given ID. The main token sees every org, and an org token only its own.
+func (s *Service) canSeeOrg(r *http.Request, orgID uuid.UUID) bool {
+	onlyOrg, isOrgToken := s.tokenOrg(r)
+	return !isOrgToken || onlyOrg == orgID
+}
+
+// auth rejects any request that does not carry one of the fake's tokens.
 func (s *Service) auth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch r.Header.Get("Circle-Token") {
-		case "":
+		_, isOrgToken := s.tokenOrg(r)
+		switch tok := r.Header.Get("Circle-Token"); {
+		case tok == "":
 			msg(w, r, http.StatusUnauthorized, "You must log in first.")
-		case s.tok:
+		case tok == s.tok, isOrgToken:
 			next.ServeHTTP(w, r)
 		default:
 			msg(w, r, http.StatusUnauthorized, "Invalid token provided.")
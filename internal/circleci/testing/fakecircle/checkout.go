@@ -0,0 +1,248 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// Checkout key types.
+const (
+	CheckoutKeyTypeDeploy = "deploy-key"
+	CheckoutKeyTypeUser   = "user-key"
+)
+
+// CheckoutKey is a project checkout key. The fake generates an ed25519
+// keypair for each one and keeps only the public half, as the API does.
+type CheckoutKey struct {
+	Type        string
+	PublicKey   string
+	Fingerprint string
+	Preferred   bool
+	CreatedAt   time.Time
+}
+
+// newCheckoutKey generates a keypair of the given type.
+func newCheckoutKey(keyType string) (CheckoutKey, error) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return CheckoutKey{}, err
+	}
+
+	publicKey, fingerprint := sshPublicKey("ssh-ed25519", pub)
+	return CheckoutKey{
+		Type:        keyType,
+		PublicKey:   publicKey,
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// checkoutKeys returns a copy of the project's checkout keys, with the one
+// CircleCI would check out with marked preferred: the newest user key, or
+// failing that the newest deploy key.
+func (p *project) checkoutKeys() []CheckoutKey {
+	keys := slices.Clone(p.CheckoutKeys)
+	preferred := -1
+	for i, k := range keys {
+		if preferred == -1 || k.Type == CheckoutKeyTypeUser || keys[preferred].Type == CheckoutKeyTypeDeploy {
+			preferred = i
+		}
+	}
+	if preferred != -1 {
+		keys[preferred].Preferred = true
+	}
+	return keys
+}
+
+// AddCheckoutKey generates a checkout key of the given type for a project.
+func (s *Service) AddCheckoutKey(projectID uuid.UUID, keyType string) (CheckoutKey, error) {
+	switch keyType {
+	case CheckoutKeyTypeDeploy, CheckoutKeyTypeUser:
+	default:
+		return CheckoutKey{}, validationError("type must be one of deploy-key, user-key")
+	}
+
+	k, err := newCheckoutKey(keyType)
+	if err != nil {
+		return CheckoutKey{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return CheckoutKey{}, errNotFound
+	}
+
+	p.CheckoutKeys = append(p.CheckoutKeys, k)
+	keys := p.checkoutKeys()
+	return keys[len(keys)-1], nil
+}
+
+// addExistingCheckoutKey adds a checkout key generated elsewhere, such as by
+// an earlier run, to a project.
+func (s *Service) addExistingCheckoutKey(projectID uuid.UUID, k CheckoutKey) error {
+	switch k.Type {
+	case CheckoutKeyTypeDeploy, CheckoutKeyTypeUser:
+	default:
+		return validationError("type must be one of deploy-key, user-key")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return errNotFound
+	}
+
+	k.Preferred = false
+	k.CreatedAt = time.Now()
+	p.CheckoutKeys = append(p.CheckoutKeys, k)
+	return nil
+}
+
+// CheckoutKeys returns a project's checkout keys, oldest first.
+func (s *Service) CheckoutKeys(projectID uuid.UUID) ([]CheckoutKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return p.checkoutKeys(), nil
+}
+
+// DeleteCheckoutKey deletes the project's checkout key with the given
+// fingerprint, as if it were removed outside of Terraform.
+func (s *Service) DeleteCheckoutKey(projectID uuid.UUID, fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return errNotFound
+	}
+
+	i := slices.IndexFunc(p.CheckoutKeys, func(k CheckoutKey) bool {
+		return k.Fingerprint == fingerprint
+	})
+	if i == -1 {
+		return errNotFound
+	}
+
+	p.CheckoutKeys = slices.Delete(p.CheckoutKeys, i, i+1)
+	return nil
+}
+
+// handlers below here
+
+type checkoutKeyResponse struct {
+	PublicKey   string    `json:"public-key"`
+	Type        string    `json:"type"`
+	Fingerprint string    `json:"fingerprint"`
+	Preferred   bool      `json:"preferred"`
+	CreatedAt   time.Time `json:"created-at"`
+}
+
+func toCheckoutKeyResponse(k CheckoutKey) checkoutKeyResponse {
+	return checkoutKeyResponse{
+		PublicKey:   k.PublicKey,
+		Type:        k.Type,
+		Fingerprint: k.Fingerprint,
+		Preferred:   k.Preferred,
+		CreatedAt:   k.CreatedAt,
+	}
+}
+
+func (s *Service) getCheckoutKeys(w http.ResponseWriter, r *http.Request) {
+	prj, ok := s.projectParam(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := s.CheckoutKeys(prj.ID)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	res := make([]checkoutKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, toCheckoutKeyResponse(k))
+	}
+	respondPage(w, r, res, int(s.pageSize.Load()))
+}
+
+func (s *Service) postCheckoutKey(w http.ResponseWriter, r *http.Request) {
+	prj, ok := s.projectParam(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Type string `json:"type"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	k, err := s.AddCheckoutKey(prj.ID, body.Type)
+	if err != nil {
+		writeError(w, r, err, "project not found")
+		return
+	}
+
+	respond(w, r, http.StatusCreated, toCheckoutKeyResponse(k))
+}
+
+func (s *Service) getCheckoutKey(w http.ResponseWriter, r *http.Request) {
+	prj, ok := s.projectParam(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := s.CheckoutKeys(prj.ID)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	i := slices.IndexFunc(keys, func(k CheckoutKey) bool {
+		return k.Fingerprint == chi.URLParam(r, "fingerprint")
+	})
+	if i == -1 {
+		msg(w, r, http.StatusNotFound, "checkout key not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, toCheckoutKeyResponse(keys[i]))
+}
+
+func (s *Service) deleteCheckoutKey(w http.ResponseWriter, r *http.Request) {
+	prj, ok := s.projectParam(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.DeleteCheckoutKey(prj.ID, chi.URLParam(r, "fingerprint"))
+	if err != nil {
+		writeError(w, r, err, "checkout key not found")
+		return
+	}
+
+	msg(w, r, http.StatusOK, "ok")
+}
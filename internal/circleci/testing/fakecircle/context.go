@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -40,6 +41,22 @@ func (c *context) addEnv(ev NewEnvVarContext) (EnvVarContext, error) {
 	return e, nil
 }
 
+// putEnv sets an environment variable's value, adding it if it is new, as
+// the API's PUT does.
+func (c *context) putEnv(ev NewEnvVarContext) EnvVarContext {
+	i := slices.IndexFunc(c.EnvVars, func(e EnvVarContext) bool {
+		return e.Variable == ev.Variable
+	})
+	if i == -1 {
+		e, _ := c.addEnv(ev)
+		return e
+	}
+
+	c.EnvVars[i].Value = ev.Value
+	c.EnvVars[i].UpdatedAt = time.Now()
+	return c.EnvVars[i]
+}
+
 func (c *context) deleteEnv(ev string) {
 	c.EnvVars = slices.DeleteFunc(c.EnvVars, func(e EnvVarContext) bool {
 		return e.Variable == ev
@@ -47,6 +64,8 @@ func (c *context) deleteEnv(ev string) {
 }
 
 type NewContext struct {
+	// ID is optional; a random ID is used if it is unset.
+	ID    uuid.UUID
 	OrgID uuid.UUID
 	Name  string
 }
@@ -66,10 +85,17 @@ func (s *Service) AddContext(c NewContext) (Context, error) {
 		return Context{}, errNotFound
 	}
 
+	id := c.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	} else if _, ok := s.contexts[id]; ok {
+		return Context{}, errDuplicate
+	}
+
 	orgCtx := &context{
 		Name:      c.Name,
 		Org:       o,
-		ID:        uuid.New(),
+		ID:        id,
 		CreatedAt: time.Now(),
 	}
 
@@ -94,11 +120,22 @@ func (s *Service) getContext(id uuid.UUID) (context, bool) {
 	return context{
 		ID:        envCtx.ID,
 		Name:      envCtx.Name,
+		Org:       envCtx.Org,
 		EnvVars:   slices.Clone(envCtx.EnvVars),
 		CreatedAt: envCtx.CreatedAt,
 	}, true
 }
 
+// visibleContext is getContext for a context the request's token may see.
+// Like the API, the fake treats any other context as not found.
+func (s *Service) visibleContext(r *http.Request, id uuid.UUID) (context, bool) {
+	envCtx, ok := s.getContext(id)
+	if !ok || !s.canSeeOrg(r, envCtx.Org.id) {
+		return context{}, false
+	}
+	return envCtx, true
+}
+
 func (s *Service) deleteEnvContext(id uuid.UUID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -148,6 +185,20 @@ func (s *Service) AddContextEnv(contextID uuid.UUID, ev NewEnvVarContext) (EnvVa
 	return envCtx.addEnv(ev)
 }
 
+// PutContextEnv sets a context environment variable's value, adding it if
+// it is new.
+func (s *Service) PutContextEnv(contextID uuid.UUID, ev NewEnvVarContext) (EnvVarContext, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	envCtx, ok := s.contexts[contextID]
+	if !ok {
+		return EnvVarContext{}, errNotFound
+	}
+
+	return envCtx.putEnv(ev), nil
+}
+
 func (s *Service) deleteContextEnvVar(id uuid.UUID, name string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -204,10 +255,15 @@ func (s *Service) deleteContext(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := s.visibleContext(r, id); !ok {
+		msg(w, r, http.StatusNotFound, "context not found")
+		return
+	}
+
 	err = s.deleteEnvContext(id)
 	switch {
 	case errors.Is(err, errNotFound):
-		msg(w, r, http.StatusBadRequest, "context not found")
+		msg(w, r, http.StatusNotFound, "context not found")
 		return
 	case err != nil:
 		msg(w, r, http.StatusInternalServerError, err.Error())
@@ -229,7 +285,7 @@ func (s *Service) getContextByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	envCtx, ok := s.getContext(id)
+	envCtx, ok := s.visibleContext(r, id)
 	if !ok {
 		msg(w, r, http.StatusNotFound, "context not found")
 		return
@@ -275,7 +331,11 @@ func (s *Service) getContextBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respond(w, r, http.StatusOK, newListResponse(res))
+	// Pages need a stable order, which the map of contexts does not have.
+	slices.SortFunc(res, func(a, b response) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	respondPage(w, r, res, int(s.pageSize.Load()))
 }
 
 func (s *Service) getContextEnv(w http.ResponseWriter, r *http.Request) {
@@ -291,7 +351,7 @@ func (s *Service) getContextEnv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	envCtx, ok := s.getContext(id)
+	envCtx, ok := s.visibleContext(r, id)
 	if !ok {
 		msg(w, r, http.StatusNotFound, "context not found")
 		return
@@ -306,7 +366,7 @@ func (s *Service) getContextEnv(w http.ResponseWriter, r *http.Request) {
 			ContextID: id,
 		})
 	}
-	respond(w, r, http.StatusOK, newListResponse(res))
+	respondPage(w, r, res, int(s.pageSize.Load()))
 }
 
 func (s *Service) putContextEnv(w http.ResponseWriter, r *http.Request) {
@@ -335,7 +395,12 @@ func (s *Service) putContextEnv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ev, err := s.AddContextEnv(contextID, NewEnvVarContext{
+	if envCtx, ok := s.getContext(contextID); ok && !s.canSeeOrg(r, envCtx.Org.id) {
+		msg(w, r, http.StatusNotFound, "context not found")
+		return
+	}
+
+	ev, err := s.PutContextEnv(contextID, NewEnvVarContext{
 		Variable: envVarName,
 		Value:    body.Value,
 	})
@@ -343,9 +408,6 @@ func (s *Service) putContextEnv(w http.ResponseWriter, r *http.Request) {
 	case errors.Is(err, errNotFound):
 		msg(w, r, http.StatusBadRequest, "context not found")
 		return
-	case errors.Is(err, errDuplicate):
-		msg(w, r, http.StatusBadRequest, "env var already exists")
-		return
 	case err != nil:
 		msg(w, r, http.StatusInternalServerError, err.Error())
 		return
@@ -371,10 +433,15 @@ func (s *Service) deleteContextEnv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := s.visibleContext(r, contextID); !ok {
+		msg(w, r, http.StatusNotFound, "context not found")
+		return
+	}
+
 	err = s.deleteContextEnvVar(contextID, envVarName)
 	switch {
 	case errors.Is(err, errNotFound):
-		msg(w, r, http.StatusBadRequest, "context not found")
+		msg(w, r, http.StatusNotFound, "context not found")
 		return
 	case err != nil:
 		msg(w, r, http.StatusInternalServerError, err.Error())
@@ -26,14 +26,30 @@ var (
 type Service struct {
 	http.Handler
 	tok string
+	// orgTokens are further valid tokens, each for a user that is only a
+	// member of one org.
+	orgTokens map[string]uuid.UUID
 
 	hit429 atomic.Bool
 	hit500 atomic.Bool
 
-	mu       sync.RWMutex
-	orgs     map[uuid.UUID]*org
-	projects map[uuid.UUID]*project
-	contexts map[uuid.UUID]*context
+	pageSize atomic.Int64
+
+	faultMu   sync.Mutex
+	faultPlan []*fault
+
+	journalMu    sync.Mutex
+	journal      []Call
+	notRecording atomic.Bool
+
+	mu        sync.RWMutex
+	user      User
+	orgs      map[uuid.UUID]*org
+	projects  map[uuid.UUID]*project
+	contexts  map[uuid.UUID]*context
+	pipelines map[uuid.UUID]*pipelineDefinition
+	triggers  map[uuid.UUID]*trigger
+	webhooks  map[uuid.UUID]*webhook
 
 	// Runner (v3) state.
 	resourceClasses map[string]*resourceClass
@@ -45,17 +61,27 @@ type Service struct {
 func New(tok string) *Service {
 	r := chi.NewRouter()
 	s := &Service{
-		tok:      tok,
-		Handler:  r,
-		orgs:     make(map[uuid.UUID]*org),
-		projects: make(map[uuid.UUID]*project),
-		contexts: make(map[uuid.UUID]*context),
+		tok:       tok,
+		orgTokens: make(map[string]uuid.UUID),
+		Handler:   r,
+		user:      DefaultUser,
+		orgs:      make(map[uuid.UUID]*org),
+		projects:  make(map[uuid.UUID]*project),
+		contexts:  make(map[uuid.UUID]*context),
+		pipelines: make(map[uuid.UUID]*pipelineDefinition),
+		triggers:  make(map[uuid.UUID]*trigger),
+		webhooks:  make(map[uuid.UUID]*webhook),
 
 		resourceClasses: make(map[string]*resourceClass),
 		tokens:          make(map[string]*token),
 		runners:         make([]*runner, 0),
 	}
 
+	s.pageSize.Store(defaultPageSize)
+
+	r.Use(requestID)
+	r.Use(s.record)
+	r.Use(s.faults)
 	r.Use(s.auth)
 
 	r.Get("/api/test/hello", s.getHello)
@@ -63,17 +89,60 @@ func New(tok string) *Service {
 	r.Get("/api/test/429", s.get429)
 	r.Get("/api/test/500", s.get500)
 
+	r.Get("/api/v2/me", s.getMe)
+	r.Get("/api/v2/me/collaborations", s.getCollaborations)
+
 	r.Post("/api/v2/organization", s.postOrganization)
 	r.Get("/api/v2/organization/{org-id}", s.getOrganizationByID)
 	r.Delete("/api/v2/organization/{org-id}", s.deleteOrganization)
 	r.Post("/api/v2/organization/{org-id}/project", s.postProject)
 
+	r.Get("/api/v2/org/{org-id}/oidc-custom-claims", s.getOIDCClaimsHandler)
+	r.Patch("/api/v2/org/{org-id}/oidc-custom-claims", s.patchOIDCClaims)
+	r.Delete("/api/v2/org/{org-id}/oidc-custom-claims", s.deleteOIDCClaimsHandler)
+	r.Get("/api/v2/org/{org-id}/project/{project-id}/oidc-custom-claims", s.getOIDCClaimsHandler)
+	r.Patch("/api/v2/org/{org-id}/project/{project-id}/oidc-custom-claims", s.patchOIDCClaims)
+	r.Delete("/api/v2/org/{org-id}/project/{project-id}/oidc-custom-claims", s.deleteOIDCClaimsHandler)
+
+	r.Get("/api/v1/owner/{owner-id}/context/{context}/policy-bundle", s.getPolicyBundle)
+	r.Post("/api/v1/owner/{owner-id}/context/{context}/policy-bundle", s.postPolicyBundle)
+	r.Get("/api/v1/owner/{owner-id}/context/{context}/decision", s.getPolicyDecisions)
+	r.Get("/api/v1/owner/{owner-id}/context/{context}/decision/settings", s.getPolicySettings)
+	r.Patch("/api/v1/owner/{owner-id}/context/{context}/decision/settings", s.patchPolicySettings)
+
 	r.Get("/api/v2/project/{org-type}/{org-name}/{project-name}", s.getProject)
 	r.Delete("/api/v2/project/{org-type}/{org-name}/{project-name}", s.deleteProject)
-	// TODO: GET ONE ENV
 	r.Get("/api/v2/project/{org-type}/{org-name}/{project-name}/envvar", s.getProjectEnv)
 	r.Post("/api/v2/project/{org-type}/{org-name}/{project-name}/envvar", s.postProjectEnv)
+	r.Get("/api/v2/project/{org-type}/{org-name}/{project-name}/envvar/{env-var}", s.getProjectEnvVar)
 	r.Delete("/api/v2/project/{org-type}/{org-name}/{project-name}/envvar/{env-var}", s.deleteProjectEnv)
+	r.Get("/api/v2/project/{org-type}/{org-name}/{project-name}/checkout-key", s.getCheckoutKeys)
+	r.Post("/api/v2/project/{org-type}/{org-name}/{project-name}/checkout-key", s.postCheckoutKey)
+	r.Get("/api/v2/project/{org-type}/{org-name}/{project-name}/checkout-key/{fingerprint}", s.getCheckoutKey)
+	r.Delete("/api/v2/project/{org-type}/{org-name}/{project-name}/checkout-key/{fingerprint}", s.deleteCheckoutKey)
+	r.Get("/api/v2/project/{org-type}/{org-name}/{project-name}/settings", s.getProjectSettings)
+	r.Patch("/api/v2/project/{org-type}/{org-name}/{project-name}/settings", s.patchProjectSettings)
+
+	r.Get("/api/v1.1/project/{org-type}/{org-name}/{project-name}/settings", s.getProjectSettingsV1)
+	r.Post("/api/v1.1/project/{org-type}/{org-name}/{project-name}/ssh-key", s.postSSHKey)
+	r.Delete("/api/v1.1/project/{org-type}/{org-name}/{project-name}/ssh-key", s.deleteSSHKey)
+
+	r.Get("/api/v2/projects/{project-id}/pipeline-definitions", s.getPipelineDefinitions)
+	r.Post("/api/v2/projects/{project-id}/pipeline-definitions", s.postPipelineDefinition)
+	r.Get("/api/v2/projects/{project-id}/pipeline-definitions/{pipeline-definition-id}", s.getPipelineDefinition)
+	r.Patch("/api/v2/projects/{project-id}/pipeline-definitions/{pipeline-definition-id}", s.patchPipelineDefinition)
+	r.Delete("/api/v2/projects/{project-id}/pipeline-definitions/{pipeline-definition-id}", s.deletePipelineDefinitionHandler)
+	r.Get("/api/v2/projects/{project-id}/pipeline-definitions/{pipeline-definition-id}/triggers", s.getTriggers)
+	r.Post("/api/v2/projects/{project-id}/pipeline-definitions/{pipeline-definition-id}/triggers", s.postTrigger)
+	r.Get("/api/v2/projects/{project-id}/triggers/{trigger-id}", s.getTrigger)
+	r.Patch("/api/v2/projects/{project-id}/triggers/{trigger-id}", s.patchTrigger)
+	r.Delete("/api/v2/projects/{project-id}/triggers/{trigger-id}", s.deleteTriggerHandler)
+
+	r.Get("/api/v2/webhook", s.getWebhooks)
+	r.Post("/api/v2/webhook", s.postWebhook)
+	r.Get("/api/v2/webhook/{webhook-id}", s.getWebhook)
+	r.Put("/api/v2/webhook/{webhook-id}", s.putWebhook)
+	r.Delete("/api/v2/webhook/{webhook-id}", s.deleteWebhookHandler)
 
 	r.Get("/api/v2/context", s.getContextBySlug)
 	r.Post("/api/v2/context", s.postContext)
@@ -88,6 +157,14 @@ func New(tok string) *Service {
 	return s
 }
 
+// requestID tags every response with an X-Request-Id, as the real API does.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", uuid.NewString())
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (s *Service) getHello(w http.ResponseWriter, r *http.Request) {
 	msg(w, r, http.StatusOK, "Hello World!")
 }
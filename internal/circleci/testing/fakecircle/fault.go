@@ -0,0 +1,176 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault makes the fake misbehave for requests that match it. Faults are
+// checked in the order they were injected, and only the first match applies.
+//
+// A fault with none of Status, MalformedBody or DropConnection set only adds
+// Latency; the request is then served as normal.
+type Fault struct {
+	// Method is the HTTP method to match. Empty matches any method.
+	Method string
+	// Path is a pattern for the URL path, where * matches a single path
+	// segment, e.g. "/projects/*/triggers/*". It matches either the full path
+	// or the path after its /api/vN or /api/vN.N prefix. Empty matches any
+	// path.
+	Path string
+
+	// After lets the first After matching requests through untouched, so a
+	// fault can land part-way through, e.g. on the second page of a list.
+	After int
+	// Times is how many matching requests the fault applies to, after which
+	// it is removed. Zero means every matching request, until ClearFaults.
+	Times int
+
+	// Latency delays the response. It ends early if the request is cancelled.
+	Latency time.Duration
+	// Status is the status code to respond with instead of serving the
+	// request. Header is added to that response, e.g. a Retry-After.
+	Status int
+	Header http.Header
+	// MalformedBody responds with a body that is not valid JSON, with Status
+	// or 200 if Status is unset.
+	MalformedBody bool
+	// DropConnection closes the connection without writing a response.
+	DropConnection bool
+}
+
+type fault struct {
+	Fault
+	skipped int
+	applied int
+}
+
+// InjectFault adds f to the fault plan.
+func (s *Service) InjectFault(f Fault) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+
+	s.faultPlan = append(s.faultPlan, &fault{Fault: f})
+}
+
+// ClearFaults removes every fault from the plan.
+func (s *Service) ClearFaults() {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+
+	s.faultPlan = nil
+}
+
+// PendingFaults returns how many faults are still in the plan. A test can
+// check it is zero to make sure each fault it injected was hit.
+func (s *Service) PendingFaults() int {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+
+	return len(s.faultPlan)
+}
+
+// matchPath reports whether urlPath matches pattern, either as a whole or
+// once its /api/vN or /api/vN.N prefix is stripped.
+func matchPath(pattern, urlPath string) bool {
+	if pattern == "" {
+		return true
+	}
+	if ok, _ := path.Match(pattern, urlPath); ok {
+		return true
+	}
+
+	rest, ok := strings.CutPrefix(urlPath, "/api/v")
+	if !ok {
+		return false
+	}
+	version, rest, ok := strings.Cut(rest, "/")
+	if !ok {
+		return false
+	}
+	if _, err := strconv.ParseFloat(version, 64); err != nil {
+		return false
+	}
+	match, _ := path.Match(pattern, "/"+rest)
+	return match
+}
+
+// nextFault returns the fault to apply to r, if any, and updates the plan.
+func (s *Service) nextFault(r *http.Request) (Fault, bool) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+
+	for i, f := range s.faultPlan {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !matchPath(f.Path, r.URL.Path) {
+			continue
+		}
+
+		if f.skipped < f.After {
+			f.skipped++
+			return Fault{}, false
+		}
+
+		f.applied++
+		if f.Times > 0 && f.applied >= f.Times {
+			s.faultPlan = slices.Delete(s.faultPlan, i, i+1)
+		}
+		return f.Fault, true
+	}
+	return Fault{}, false
+}
+
+// faults applies the fault plan to each request.
+func (s *Service) faults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := s.nextFault(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if f.Latency > 0 {
+			t := time.NewTimer(f.Latency)
+			select {
+			case <-t.C:
+			case <-r.Context().Done():
+				t.Stop()
+				return
+			}
+		}
+
+		for k, vs := range f.Header {
+			for _, v := range vs {
+				w.Header().Add(k, v)
+			}
+		}
+
+		switch {
+		case f.DropConnection:
+			// net/http closes the connection without a response when a
+			// handler panics with this value.
+			panic(http.ErrAbortHandler)
+		case f.MalformedBody:
+			status := f.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"message": "truncat`))
+		case f.Status != 0:
+			msg(w, r, f.Status, "injected fault")
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
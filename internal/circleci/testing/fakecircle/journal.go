@@ -0,0 +1,167 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// Call is a request the fake received.
+type Call struct {
+	Method string
+	Path   string
+	Query  url.Values
+	// Body is the request body decoded from JSON, or nil if it was empty or
+	// not JSON.
+	Body any
+	// Authenticated reports whether the request had a Circle-Token header,
+	// whether or not the token was valid.
+	Authenticated bool
+	// TokenOrg is the org of the org token the request was made with, as
+	// added with AddOrgToken, or uuid.Nil for any other token. The token
+	// itself is not kept, so that the journal does not collect secrets.
+	TokenOrg uuid.UUID
+}
+
+// SetRecording turns the journal on or off. It is on in a new fake, for
+// tests. A long-running fake should turn it off, as the journal grows with
+// every request and keeps each request's body.
+func (s *Service) SetRecording(on bool) {
+	s.notRecording.Store(!on)
+}
+
+// Calls returns every request received since the fake was created or the
+// journal was last reset, oldest first.
+func (s *Service) Calls() []Call {
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	return slices.Clone(s.journal)
+}
+
+// ResetCalls empties the journal, e.g. so a test only sees the requests made
+// by the step under test.
+func (s *Service) ResetCalls() {
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	s.journal = nil
+}
+
+// CallsMatching returns the requests with the given method and a path
+// matching pattern, as for Fault.Path. An empty method matches any method.
+func (s *Service) CallsMatching(method, pattern string) []Call {
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	var res []Call
+	for _, c := range s.journal {
+		if (method == "" || c.Method == method) && matchPath(pattern, c.Path) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// CallCount returns how many requests matched method and pattern, as for
+// CallsMatching.
+func (s *Service) CallCount(method, pattern string) int {
+	return len(s.CallsMatching(method, pattern))
+}
+
+// AssertCalled fails t unless at least one request matched method and
+// pattern.
+func (s *Service) AssertCalled(t testing.TB, method, pattern string) {
+	t.Helper()
+
+	if s.CallCount(method, pattern) == 0 {
+		t.Errorf("expected a %s %s request, got none; requests were:\n%s", method, pattern, s.formatCalls())
+	}
+}
+
+// AssertNotCalled fails t if any request matched method and pattern.
+func (s *Service) AssertNotCalled(t testing.TB, method, pattern string) {
+	t.Helper()
+
+	if n := s.CallCount(method, pattern); n != 0 {
+		t.Errorf("expected no %s %s requests, got %d; requests were:\n%s", method, pattern, n, s.formatCalls())
+	}
+}
+
+// AssertCallCount fails t unless exactly want requests matched method and
+// pattern.
+func (s *Service) AssertCallCount(t testing.TB, method, pattern string, want int) {
+	t.Helper()
+
+	if n := s.CallCount(method, pattern); n != want {
+		t.Errorf("expected %d %s %s requests, got %d; requests were:\n%s", want, method, pattern, n, s.formatCalls())
+	}
+}
+
+func (s *Service) formatCalls() string {
+	var sb strings.Builder
+	for _, c := range s.Calls() {
+		sb.WriteString("\t")
+		sb.WriteString(c.Method)
+		sb.WriteString(" ")
+		sb.WriteString(c.Path)
+		if len(c.Query) > 0 {
+			sb.WriteString("?")
+			sb.WriteString(c.Query.Encode())
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// record adds each request to the journal. The body is restored for the
+// handlers that follow.
+func (s *Service) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.notRecording.Load() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tokenOrg, _ := s.tokenOrg(r)
+		c := Call{
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			Query:         r.URL.Query(),
+			Authenticated: r.Header.Get("Circle-Token") != "",
+			TokenOrg:      tokenOrg,
+		}
+
+		if r.Body != nil {
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				msg(w, r, http.StatusBadRequest, err.Error())
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(b))
+
+			if len(b) > 0 {
+				var body any
+				if json.Unmarshal(b, &body) == nil {
+					c.Body = body
+				}
+			}
+		}
+
+		s.journalMu.Lock()
+		s.journal = append(s.journal, c)
+		s.journalMu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
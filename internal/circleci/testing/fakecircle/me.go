@@ -0,0 +1,89 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// User is the user the fake's token belongs to.
+type User struct {
+	ID    uuid.UUID
+	Login string
+	Name  string
+}
+
+// DefaultUser is the user a new fake's token belongs to. It is the user the
+// "current" attribution actor resolves to.
+var DefaultUser = User{
+	ID:    CurrentActorID,
+	Login: "fakecircle",
+	Name:  "Fake CircleCI",
+}
+
+// SetUser changes the user the fake's token belongs to.
+func (s *Service) SetUser(u User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.user = u
+}
+
+// handlers below here
+
+func (s *Service) getMe(w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		ID    uuid.UUID `json:"id"`
+		Login string    `json:"login"`
+		Name  string    `json:"name"`
+	}
+
+	s.mu.RLock()
+	u := s.user
+	s.mu.RUnlock()
+
+	respond(w, r, http.StatusOK, response{
+		ID:    u.ID,
+		Login: u.Login,
+		Name:  u.Name,
+	})
+}
+
+// getCollaborations lists every org in the fake; the user is taken to be a
+// member of them all. An org token's user is only a member of its org.
+func (s *Service) getCollaborations(w http.ResponseWriter, r *http.Request) {
+	onlyOrg, isOrgToken := s.tokenOrg(r)
+
+	type responseItem struct {
+		ID        uuid.UUID `json:"id"`
+		VcsType   string    `json:"vcs-type"`
+		Name      string    `json:"name"`
+		AvatarURL string    `json:"avatar_url"`
+		Slug      string    `json:"slug"`
+	}
+
+	s.mu.RLock()
+	res := make([]responseItem, 0, len(s.orgs))
+	for _, o := range s.orgs {
+		if isOrgToken && o.id != onlyOrg {
+			continue
+		}
+		res = append(res, responseItem{
+			ID:      o.id,
+			VcsType: o.typ,
+			Name:    o.name,
+			Slug:    fmtOrgSlug(o.typ, o.id, o.name),
+		})
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(res, func(a, b responseItem) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	respond(w, r, http.StatusOK, res)
+}
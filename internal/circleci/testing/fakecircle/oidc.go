@@ -0,0 +1,290 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// The bounds of an OIDC token's custom ttl claim.
+const (
+	oidcMinTTL = 5 * time.Minute
+	oidcMaxTTL = 24 * time.Hour
+)
+
+// OIDCClaims are the custom claims of an org's or project's OIDC tokens.
+// Unset claims are empty.
+type OIDCClaims struct {
+	Audience          []string  `json:"audience,omitempty" yaml:"audience,omitempty"`
+	AudienceUpdatedAt time.Time `json:"audience_updated_at,omitzero" yaml:"audience_updated_at,omitempty"`
+	TTL               string    `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	TTLUpdatedAt      time.Time `json:"ttl_updated_at,omitzero" yaml:"ttl_updated_at,omitempty"`
+}
+
+// oidcClaimsLocked returns the custom claims of the org, or of the project
+// in it if projectID is set. It requires s.mu to be held.
+func (s *Service) oidcClaimsLocked(orgID, projectID uuid.UUID) (*OIDCClaims, error) {
+	o, ok := s.orgs[orgID]
+	if !ok {
+		return nil, errNotFound
+	}
+	if projectID == uuid.Nil {
+		return &o.oidcClaims, nil
+	}
+
+	p, ok := o.projects[projectID]
+	if !ok {
+		return nil, errNotFound
+	}
+	return &p.OIDCClaims, nil
+}
+
+// OrgOIDCClaims returns the custom claims of an org's OIDC tokens.
+func (s *Service) OrgOIDCClaims(orgID uuid.UUID) (OIDCClaims, error) {
+	return s.getOIDCClaims(orgID, uuid.Nil)
+}
+
+// SetOrgOIDCClaims replaces the custom claims of an org's OIDC tokens, as if
+// they were changed outside of Terraform.
+func (s *Service) SetOrgOIDCClaims(orgID uuid.UUID, claims OIDCClaims) error {
+	return s.setOIDCClaims(orgID, uuid.Nil, claims)
+}
+
+// ProjectOIDCClaims returns the custom claims of a project's OIDC tokens.
+func (s *Service) ProjectOIDCClaims(projectID uuid.UUID) (OIDCClaims, error) {
+	s.mu.RLock()
+	p, ok := s.projects[projectID]
+	s.mu.RUnlock()
+	if !ok {
+		return OIDCClaims{}, errNotFound
+	}
+	return s.getOIDCClaims(p.Org.id, projectID)
+}
+
+// SetProjectOIDCClaims replaces the custom claims of a project's OIDC
+// tokens, as if they were changed outside of Terraform.
+func (s *Service) SetProjectOIDCClaims(projectID uuid.UUID, claims OIDCClaims) error {
+	s.mu.RLock()
+	p, ok := s.projects[projectID]
+	s.mu.RUnlock()
+	if !ok {
+		return errNotFound
+	}
+	return s.setOIDCClaims(p.Org.id, projectID, claims)
+}
+
+func (s *Service) getOIDCClaims(orgID, projectID uuid.UUID) (OIDCClaims, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, err := s.oidcClaimsLocked(orgID, projectID)
+	if err != nil {
+		return OIDCClaims{}, err
+	}
+	claims := *c
+	claims.Audience = slices.Clone(c.Audience)
+	return claims, nil
+}
+
+func (s *Service) setOIDCClaims(orgID, projectID uuid.UUID, claims OIDCClaims) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.oidcClaimsLocked(orgID, projectID)
+	if err != nil {
+		return err
+	}
+	*c = claims
+	c.Audience = slices.Clone(claims.Audience)
+	return nil
+}
+
+type oidcClaimsUpdate struct {
+	Audience []string `json:"audience"`
+	TTL      string   `json:"ttl"`
+}
+
+func (u oidcClaimsUpdate) validate() error {
+	if u.Audience == nil && u.TTL == "" {
+		return validationError("at least one of audience, ttl is required")
+	}
+	if u.Audience != nil && len(u.Audience) == 0 {
+		return validationError("audience must not be empty")
+	}
+	if slices.Contains(u.Audience, "") {
+		return validationError("audience must not contain empty values")
+	}
+	if u.TTL != "" {
+		ttl, err := time.ParseDuration(u.TTL)
+		if err != nil || ttl < oidcMinTTL || ttl > oidcMaxTTL {
+			return validationError("ttl must be a duration between 5m and 24h")
+		}
+	}
+	return nil
+}
+
+// updateOIDCClaims sets the claims in u.
+func (s *Service) updateOIDCClaims(orgID, projectID uuid.UUID, u oidcClaimsUpdate) (OIDCClaims, error) {
+	if err := u.validate(); err != nil {
+		return OIDCClaims{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.oidcClaimsLocked(orgID, projectID)
+	if err != nil {
+		return OIDCClaims{}, err
+	}
+
+	now := time.Now()
+	if u.Audience != nil {
+		c.Audience = slices.Clone(u.Audience)
+		c.AudienceUpdatedAt = now
+	}
+	if u.TTL != "" {
+		c.TTL = u.TTL
+		c.TTLUpdatedAt = now
+	}
+
+	claims := *c
+	claims.Audience = slices.Clone(c.Audience)
+	return claims, nil
+}
+
+// deleteOIDCClaims unsets the named claims.
+func (s *Service) deleteOIDCClaims(orgID, projectID uuid.UUID, names []string) (OIDCClaims, error) {
+	if len(names) == 0 {
+		return OIDCClaims{}, validationError("claims is required")
+	}
+	for _, name := range names {
+		if name != "audience" && name != "ttl" {
+			return OIDCClaims{}, validationError("claims must be a list of audience, ttl")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.oidcClaimsLocked(orgID, projectID)
+	if err != nil {
+		return OIDCClaims{}, err
+	}
+
+	if slices.Contains(names, "audience") {
+		c.Audience = nil
+		c.AudienceUpdatedAt = time.Time{}
+	}
+	if slices.Contains(names, "ttl") {
+		c.TTL = ""
+		c.TTLUpdatedAt = time.Time{}
+	}
+
+	claims := *c
+	claims.Audience = slices.Clone(c.Audience)
+	return claims, nil
+}
+
+// handlers below here
+
+type oidcClaimsResponse struct {
+	Audience          []string  `json:"audience,omitempty"`
+	AudienceUpdatedAt time.Time `json:"audience_updated_at,omitzero"`
+	OrgID             string    `json:"org_id"`
+	ProjectID         string    `json:"project_id,omitempty"`
+	TTL               string    `json:"ttl,omitempty"`
+	TTLUpdatedAt      time.Time `json:"ttl_updated_at,omitzero"`
+}
+
+func toOIDCClaimsResponse(orgID, projectID uuid.UUID, c OIDCClaims) oidcClaimsResponse {
+	res := oidcClaimsResponse{
+		Audience:          c.Audience,
+		AudienceUpdatedAt: c.AudienceUpdatedAt,
+		OrgID:             orgID.String(),
+		TTL:               c.TTL,
+		TTLUpdatedAt:      c.TTLUpdatedAt,
+	}
+	if projectID != uuid.Nil {
+		res.ProjectID = projectID.String()
+	}
+	return res
+}
+
+// oidcClaimsParams reads the org ID, and the project ID on project routes,
+// from the request path.
+func oidcClaimsParams(w http.ResponseWriter, r *http.Request) (orgID, projectID uuid.UUID, ok bool) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "org-id"))
+	if badRequest(w, r, "bad org ID", err) {
+		return uuid.Nil, uuid.Nil, false
+	}
+	if id := chi.URLParam(r, "project-id"); id != "" {
+		projectID, err = uuid.Parse(id)
+		if badRequest(w, r, "bad project ID", err) {
+			return uuid.Nil, uuid.Nil, false
+		}
+	}
+	return orgID, projectID, true
+}
+
+func (s *Service) getOIDCClaimsHandler(w http.ResponseWriter, r *http.Request) {
+	orgID, projectID, ok := oidcClaimsParams(w, r)
+	if !ok {
+		return
+	}
+
+	c, err := s.getOIDCClaims(orgID, projectID)
+	if err != nil {
+		writeError(w, r, err, "not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, toOIDCClaimsResponse(orgID, projectID, c))
+}
+
+func (s *Service) patchOIDCClaims(w http.ResponseWriter, r *http.Request) {
+	orgID, projectID, ok := oidcClaimsParams(w, r)
+	if !ok {
+		return
+	}
+
+	var body oidcClaimsUpdate
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	c, err := s.updateOIDCClaims(orgID, projectID, body)
+	if err != nil {
+		writeError(w, r, err, "not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, toOIDCClaimsResponse(orgID, projectID, c))
+}
+
+func (s *Service) deleteOIDCClaimsHandler(w http.ResponseWriter, r *http.Request) {
+	orgID, projectID, ok := oidcClaimsParams(w, r)
+	if !ok {
+		return
+	}
+
+	var names []string
+	if q := r.URL.Query().Get("claims"); q != "" {
+		names = strings.Split(q, ",")
+	}
+
+	c, err := s.deleteOIDCClaims(orgID, projectID, names)
+	if err != nil {
+		writeError(w, r, err, "not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, toOIDCClaimsResponse(orgID, projectID, c))
+}
@@ -43,6 +43,12 @@ type org struct {
 	name     string
 	contexts map[uuid.UUID]*context
 	projects map[uuid.UUID]*project
+
+	oidcClaims OIDCClaims
+
+	policies        map[string]storedPolicy
+	policyEnabled   bool
+	policyDecisions []PolicyDecision
 }
 
 func (o *org) addProject(np NewProject) (*project, error) {
@@ -52,10 +58,16 @@ func (o *org) addProject(np NewProject) (*project, error) {
 		}
 	}
 
+	id := np.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
 	p := &project{
-		ID:   uuid.New(),
-		Org:  o,
-		Name: np.Name,
+		ID:       id,
+		Org:      o,
+		Name:     np.Name,
+		Settings: defaultProjectSettings(),
 	}
 
 	o.projects[p.ID] = p
@@ -67,6 +79,8 @@ func (o *org) deleteProject(id uuid.UUID) {
 }
 
 type NewOrg struct {
+	// ID is optional; a random ID is used if it is unset.
+	ID   uuid.UUID
 	Type string
 	Name string
 }
@@ -106,8 +120,15 @@ func (s *Service) AddOrg(newOrg NewOrg) (Org, error) {
 		}
 	}
 
+	id := newOrg.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	} else if _, ok := s.orgs[id]; ok {
+		return Org{}, errDuplicate
+	}
+
 	o := &org{
-		id:       uuid.New(),
+		id:       id,
 		typ:      newOrg.Type,
 		name:     newOrg.Name,
 		contexts: make(map[uuid.UUID]*context),
@@ -137,6 +158,9 @@ func (s *Service) deleteOrg(id uuid.UUID) error {
 			return err
 		}
 	}
+	for _, p := range o.projects {
+		s.deleteProjectLocked(p)
+	}
 
 	delete(s.orgs, id)
 	return nil
@@ -160,7 +184,7 @@ func (s *Service) postOrganization(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	o, err := s.AddOrg(NewOrg(body))
+	o, err := s.AddOrg(NewOrg{Type: body.Type, Name: body.Name})
 	switch {
 	case errors.Is(err, errDuplicate):
 		msg(w, r, http.StatusBadRequest, "duplicate org")
@@ -224,6 +248,7 @@ func (s *Service) getOrganizationByID(w http.ResponseWriter, r *http.Request) {
 	s.mu.RUnlock()
 
 	if !ok {
+		msg(w, r, http.StatusNotFound, "org not found")
 		return
 	}
 
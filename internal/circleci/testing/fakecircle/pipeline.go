@@ -0,0 +1,419 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// Providers a pipeline definition can read its config from or check out.
+const (
+	ProviderGitHubApp    = "github_app"
+	ProviderGitHubServer = "github_server"
+)
+
+type Repo struct {
+	FullName   string `json:"full_name" yaml:"full_name"`
+	ExternalID string `json:"external_id" yaml:"external_id"`
+}
+
+type ConfigSource struct {
+	Provider string `json:"provider" yaml:"provider"`
+	Repo     Repo   `json:"repo" yaml:"repo"`
+	FilePath string `json:"file_path" yaml:"file_path"`
+}
+
+type CheckoutSource struct {
+	Provider string `json:"provider" yaml:"provider"`
+	Repo     Repo   `json:"repo" yaml:"repo"`
+}
+
+type pipelineDefinition struct {
+	ID             uuid.UUID
+	Project        *project
+	Name           string
+	Description    string
+	ConfigSource   ConfigSource
+	CheckoutSource CheckoutSource
+	Triggers       []*trigger
+	CreatedAt      time.Time
+}
+
+func (pd *pipelineDefinition) toPipelineDefinition() PipelineDefinition {
+	return PipelineDefinition{
+		ID:             pd.ID,
+		ProjectID:      pd.Project.ID,
+		Name:           pd.Name,
+		Description:    pd.Description,
+		ConfigSource:   pd.ConfigSource,
+		CheckoutSource: pd.CheckoutSource,
+		CreatedAt:      pd.CreatedAt,
+	}
+}
+
+type NewPipelineDefinition struct {
+	// ID is optional; a random ID is used if it is unset.
+	ID             uuid.UUID
+	ProjectID      uuid.UUID
+	Name           string
+	Description    string
+	ConfigSource   ConfigSource
+	CheckoutSource CheckoutSource
+}
+
+type PipelineDefinition struct {
+	ID             uuid.UUID
+	ProjectID      uuid.UUID
+	Name           string
+	Description    string
+	ConfigSource   ConfigSource
+	CheckoutSource CheckoutSource
+	CreatedAt      time.Time
+}
+
+// validationError is returned for input the real API would reject with a 400.
+// Its message is passed through to the client.
+type validationError string
+
+func (e validationError) Error() string {
+	return string(e)
+}
+
+func validSourceProvider(provider string) bool {
+	return provider == ProviderGitHubApp || provider == ProviderGitHubServer
+}
+
+func validatePipelineDefinition(name string, cfg ConfigSource, checkout CheckoutSource) error {
+	switch {
+	case name == "":
+		return validationError("name is required")
+	case !validSourceProvider(cfg.Provider):
+		return validationError("config_source.provider must be one of github_app, github_server")
+	case cfg.Repo.ExternalID == "":
+		return validationError("config_source.repo.external_id is required")
+	case cfg.FilePath == "":
+		return validationError("config_source.file_path is required")
+	case !validSourceProvider(checkout.Provider):
+		return validationError("checkout_source.provider must be one of github_app, github_server")
+	case checkout.Repo.ExternalID == "":
+		return validationError("checkout_source.repo.external_id is required")
+	}
+	return nil
+}
+
+func (s *Service) AddPipelineDefinition(npd NewPipelineDefinition) (PipelineDefinition, error) {
+	if err := validatePipelineDefinition(npd.Name, npd.ConfigSource, npd.CheckoutSource); err != nil {
+		return PipelineDefinition{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[npd.ProjectID]
+	if !ok {
+		return PipelineDefinition{}, errNotFound
+	}
+
+	if slices.ContainsFunc(p.Pipelines, func(pd *pipelineDefinition) bool {
+		return pd.Name == npd.Name
+	}) {
+		return PipelineDefinition{}, errDuplicate
+	}
+
+	id := npd.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	} else if _, ok := s.pipelines[id]; ok {
+		return PipelineDefinition{}, errDuplicate
+	}
+
+	pd := &pipelineDefinition{
+		ID:             id,
+		Project:        p,
+		Name:           npd.Name,
+		Description:    npd.Description,
+		ConfigSource:   npd.ConfigSource,
+		CheckoutSource: npd.CheckoutSource,
+		CreatedAt:      time.Now(),
+	}
+	p.Pipelines = append(p.Pipelines, pd)
+	s.pipelines[pd.ID] = pd
+	return pd.toPipelineDefinition(), nil
+}
+
+// pipelineDefinitionLocked requires s.mu to be held. It only finds definitions
+// that belong to projectID, as the real API scopes them by project.
+func (s *Service) pipelineDefinitionLocked(projectID, id uuid.UUID) (*pipelineDefinition, error) {
+	if _, ok := s.projects[projectID]; !ok {
+		return nil, errNotFound
+	}
+
+	pd, ok := s.pipelines[id]
+	if !ok || pd.Project.ID != projectID {
+		return nil, errNotFound
+	}
+	return pd, nil
+}
+
+func (s *Service) pipelineDefinition(projectID, id uuid.UUID) (PipelineDefinition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pd, err := s.pipelineDefinitionLocked(projectID, id)
+	if err != nil {
+		return PipelineDefinition{}, err
+	}
+	return pd.toPipelineDefinition(), nil
+}
+
+func (s *Service) pipelineDefinitions(projectID uuid.UUID) ([]PipelineDefinition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	res := make([]PipelineDefinition, 0, len(p.Pipelines))
+	for _, pd := range p.Pipelines {
+		res = append(res, pd.toPipelineDefinition())
+	}
+	return res, nil
+}
+
+// pipelineDefinitionUpdate is the body of a PATCH. Nil fields are left
+// unchanged.
+type pipelineDefinitionUpdate struct {
+	Name           *string         `json:"name"`
+	Description    *string         `json:"description"`
+	ConfigSource   *ConfigSource   `json:"config_source"`
+	CheckoutSource *CheckoutSource `json:"checkout_source"`
+}
+
+func (s *Service) updatePipelineDefinition(projectID, id uuid.UUID, u pipelineDefinitionUpdate) (PipelineDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pd, err := s.pipelineDefinitionLocked(projectID, id)
+	if err != nil {
+		return PipelineDefinition{}, err
+	}
+
+	updated := *pd
+	if u.Name != nil {
+		updated.Name = *u.Name
+	}
+	if u.Description != nil {
+		updated.Description = *u.Description
+	}
+	if u.ConfigSource != nil {
+		// Only the file path can change; the repo is fixed at creation.
+		if u.ConfigSource.FilePath != "" {
+			updated.ConfigSource.FilePath = u.ConfigSource.FilePath
+		}
+	}
+	if u.CheckoutSource != nil {
+		if u.CheckoutSource.Provider != "" {
+			updated.CheckoutSource.Provider = u.CheckoutSource.Provider
+		}
+		if u.CheckoutSource.Repo.ExternalID != "" {
+			updated.CheckoutSource.Repo = u.CheckoutSource.Repo
+		}
+	}
+
+	err = validatePipelineDefinition(updated.Name, updated.ConfigSource, updated.CheckoutSource)
+	if err != nil {
+		return PipelineDefinition{}, err
+	}
+
+	*pd = updated
+	return pd.toPipelineDefinition(), nil
+}
+
+func (s *Service) deletePipelineDefinition(projectID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pd, err := s.pipelineDefinitionLocked(projectID, id)
+	if err != nil {
+		return err
+	}
+
+	s.deletePipelineDefinitionLocked(pd)
+	return nil
+}
+
+// deletePipelineDefinitionLocked requires s.mu to be held for writing. It also
+// deletes the definition's triggers.
+func (s *Service) deletePipelineDefinitionLocked(pd *pipelineDefinition) {
+	for _, t := range pd.Triggers {
+		delete(s.triggers, t.ID)
+	}
+	pd.Project.Pipelines = slices.DeleteFunc(pd.Project.Pipelines, func(other *pipelineDefinition) bool {
+		return other.ID == pd.ID
+	})
+	delete(s.pipelines, pd.ID)
+}
+
+// Handlers below here
+
+type pipelineDefinitionResponse struct {
+	ID             uuid.UUID      `json:"id"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ConfigSource   ConfigSource   `json:"config_source"`
+	CheckoutSource CheckoutSource `json:"checkout_source"`
+}
+
+func newPipelineDefinitionResponse(pd PipelineDefinition) pipelineDefinitionResponse {
+	return pipelineDefinitionResponse{
+		ID:             pd.ID,
+		Name:           pd.Name,
+		Description:    pd.Description,
+		CreatedAt:      pd.CreatedAt,
+		ConfigSource:   pd.ConfigSource,
+		CheckoutSource: pd.CheckoutSource,
+	}
+}
+
+// writeError maps the fake's internal errors onto the statuses the real API
+// uses. notFound is the message for errNotFound.
+func writeError(w http.ResponseWriter, r *http.Request, err error, notFound string) {
+	var vErr validationError
+	switch {
+	case errors.As(err, &vErr):
+		msg(w, r, http.StatusBadRequest, vErr.Error())
+	case errors.Is(err, errNotFound):
+		msg(w, r, http.StatusNotFound, notFound)
+	case errors.Is(err, errDuplicate):
+		msg(w, r, http.StatusConflict, "already exists")
+	default:
+		msg(w, r, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func (s *Service) getPipelineDefinitions(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "project-id"))
+	if badRequest(w, r, "bad project ID", err) {
+		return
+	}
+
+	pds, err := s.pipelineDefinitions(projectID)
+	if err != nil {
+		writeError(w, r, err, "project not found")
+		return
+	}
+
+	res := make([]pipelineDefinitionResponse, 0, len(pds))
+	for _, pd := range pds {
+		res = append(res, newPipelineDefinitionResponse(pd))
+	}
+	respondPage(w, r, res, int(s.pageSize.Load()))
+}
+
+func (s *Service) postPipelineDefinition(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "project-id"))
+	if badRequest(w, r, "bad project ID", err) {
+		return
+	}
+
+	var body struct {
+		Name           string         `json:"name"`
+		Description    string         `json:"description"`
+		ConfigSource   ConfigSource   `json:"config_source"`
+		CheckoutSource CheckoutSource `json:"checkout_source"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	pd, err := s.AddPipelineDefinition(NewPipelineDefinition{
+		ProjectID:      projectID,
+		Name:           body.Name,
+		Description:    body.Description,
+		ConfigSource:   body.ConfigSource,
+		CheckoutSource: body.CheckoutSource,
+	})
+	if err != nil {
+		writeError(w, r, err, "project not found")
+		return
+	}
+
+	respond(w, r, http.StatusCreated, newPipelineDefinitionResponse(pd))
+}
+
+// pipelineDefinitionParams reads the project-id and pipeline-definition-id
+// path segments.
+func pipelineDefinitionParams(w http.ResponseWriter, r *http.Request) (projectID, id uuid.UUID, ok bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "project-id"))
+	if badRequest(w, r, "bad project ID", err) {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err = uuid.Parse(chi.URLParam(r, "pipeline-definition-id"))
+	if badRequest(w, r, "bad pipeline definition ID", err) {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, id, true
+}
+
+func (s *Service) getPipelineDefinition(w http.ResponseWriter, r *http.Request) {
+	projectID, id, ok := pipelineDefinitionParams(w, r)
+	if !ok {
+		return
+	}
+
+	pd, err := s.pipelineDefinition(projectID, id)
+	if err != nil {
+		writeError(w, r, err, "pipeline definition not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, newPipelineDefinitionResponse(pd))
+}
+
+func (s *Service) patchPipelineDefinition(w http.ResponseWriter, r *http.Request) {
+	projectID, id, ok := pipelineDefinitionParams(w, r)
+	if !ok {
+		return
+	}
+
+	var body pipelineDefinitionUpdate
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	pd, err := s.updatePipelineDefinition(projectID, id, body)
+	if err != nil {
+		writeError(w, r, err, "pipeline definition not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, newPipelineDefinitionResponse(pd))
+}
+
+func (s *Service) deletePipelineDefinitionHandler(w http.ResponseWriter, r *http.Request) {
+	projectID, id, ok := pipelineDefinitionParams(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.deletePipelineDefinition(projectID, id)
+	if err != nil {
+		writeError(w, r, err, "pipeline definition not found")
+		return
+	}
+
+	msg(w, r, http.StatusOK, "ok")
+}
@@ -0,0 +1,440 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// The statuses of a policy decision.
+const (
+	PolicyStatusPass     = "PASS"
+	PolicyStatusSoftFail = "SOFT_FAIL"
+	PolicyStatusHardFail = "HARD_FAIL"
+	PolicyStatusError    = "ERROR"
+)
+
+// regoPackage matches the package declaration every rego file needs. The
+// fake does not otherwise check policies compile.
+var regoPackage = regexp.MustCompile(`(?m)^\s*package\s+\S`)
+
+// storedPolicy is a policy in an org's bundle.
+type storedPolicy struct {
+	content   string
+	createdAt time.Time
+	createdBy uuid.UUID
+}
+
+// PolicyFailure is a rule a config failed.
+type PolicyFailure struct {
+	Rule   string `json:"rule" yaml:"rule"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// PolicyDecision is an entry of an org's decision audit log.
+type PolicyDecision struct {
+	// ID and CreatedAt are set by AddPolicyDecision if they are unset.
+	ID           uuid.UUID       `json:"id,omitzero" yaml:"id,omitempty"`
+	CreatedAt    time.Time       `json:"created_at,omitzero" yaml:"created_at,omitempty"`
+	Status       string          `json:"status" yaml:"status"`
+	Reason       string          `json:"reason,omitempty" yaml:"reason,omitempty"`
+	EnabledRules []string        `json:"enabled_rules,omitempty" yaml:"enabled_rules,omitempty"`
+	HardFailures []PolicyFailure `json:"hard_failures,omitempty" yaml:"hard_failures,omitempty"`
+	SoftFailures []PolicyFailure `json:"soft_failures,omitempty" yaml:"soft_failures,omitempty"`
+	ProjectID    uuid.UUID       `json:"project_id,omitzero" yaml:"project_id,omitempty"`
+	Branch       string          `json:"branch,omitempty" yaml:"branch,omitempty"`
+	BuildNumber  int64           `json:"build_number,omitempty" yaml:"build_number,omitempty"`
+	TimeTaken    time.Duration   `json:"time_taken,omitempty" yaml:"time_taken,omitempty"`
+}
+
+// orgLocked returns the org with the given ID. It requires s.mu to be held.
+func (s *Service) orgLocked(id uuid.UUID) (*org, error) {
+	o, ok := s.orgs[id]
+	if !ok {
+		return nil, errNotFound
+	}
+	return o, nil
+}
+
+// PolicyBundle returns the content of each policy in an org's bundle, keyed
+// by name.
+func (s *Service) PolicyBundle(orgID uuid.UUID) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o, err := s.orgLocked(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[string]string, len(o.policies))
+	for name, p := range o.policies {
+		policies[name] = p.content
+	}
+	return policies, nil
+}
+
+// SetPolicyBundle replaces an org's bundle, as if it were pushed outside of
+// Terraform.
+func (s *Service) SetPolicyBundle(orgID uuid.UUID, policies map[string]string) error {
+	_, err := s.pushPolicyBundle(orgID, policies, false)
+	return err
+}
+
+// PolicyDecisionsEnabled returns whether an org's pipelines are checked
+// against its policies.
+func (s *Service) PolicyDecisionsEnabled(orgID uuid.UUID) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o, err := s.orgLocked(orgID)
+	if err != nil {
+		return false, err
+	}
+	return o.policyEnabled, nil
+}
+
+// SetPolicyDecisionsEnabled changes whether an org's pipelines are checked
+// against its policies, as if it were changed outside of Terraform.
+func (s *Service) SetPolicyDecisionsEnabled(orgID uuid.UUID, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.orgLocked(orgID)
+	if err != nil {
+		return err
+	}
+	o.policyEnabled = enabled
+	return nil
+}
+
+// AddPolicyDecision adds an entry to an org's decision audit log.
+func (s *Service) AddPolicyDecision(orgID uuid.UUID, d PolicyDecision) (PolicyDecision, error) {
+	switch d.Status {
+	case PolicyStatusPass, PolicyStatusSoftFail, PolicyStatusHardFail, PolicyStatusError:
+	default:
+		return PolicyDecision{}, validationError("status must be one of PASS, SOFT_FAIL, HARD_FAIL, ERROR")
+	}
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.orgLocked(orgID)
+	if err != nil {
+		return PolicyDecision{}, err
+	}
+	o.policyDecisions = append(o.policyDecisions, d)
+	return d, nil
+}
+
+type policyBundleDiff struct {
+	Created  []string `json:"created"`
+	Deleted  []string `json:"deleted"`
+	Modified []string `json:"modified"`
+}
+
+// pushPolicyBundle replaces an org's bundle with policies, unless dry is
+// set, and returns what changed.
+func (s *Service) pushPolicyBundle(orgID uuid.UUID, policies map[string]string, dry bool) (policyBundleDiff, error) {
+	for name, content := range policies {
+		if name == "" {
+			return policyBundleDiff{}, validationError("policy names must not be empty")
+		}
+		if !regoPackage.MatchString(content) {
+			return policyBundleDiff{}, validationError(name + ": policy must declare a package")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.orgLocked(orgID)
+	if err != nil {
+		return policyBundleDiff{}, err
+	}
+
+	diff := policyBundleDiff{Created: []string{}, Deleted: []string{}, Modified: []string{}}
+	next := make(map[string]storedPolicy, len(policies))
+	now := time.Now()
+	for name, content := range policies {
+		old, ok := o.policies[name]
+		switch {
+		case !ok:
+			diff.Created = append(diff.Created, name)
+		case old.content != content:
+			diff.Modified = append(diff.Modified, name)
+		default:
+			next[name] = old
+			continue
+		}
+		next[name] = storedPolicy{content: content, createdAt: now, createdBy: s.user.ID}
+	}
+	for name := range o.policies {
+		if _, ok := policies[name]; !ok {
+			diff.Deleted = append(diff.Deleted, name)
+		}
+	}
+	slices.Sort(diff.Created)
+	slices.Sort(diff.Deleted)
+	slices.Sort(diff.Modified)
+
+	if !dry {
+		o.policies = next
+	}
+	return diff, nil
+}
+
+// handlers below here
+
+type policyResponse struct {
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+type policyDecisionFailure struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+type policyDecisionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Decision  struct {
+		Status       string                  `json:"status"`
+		Reason       string                  `json:"reason,omitempty"`
+		EnabledRules []string                `json:"enabled_rules"`
+		HardFailures []policyDecisionFailure `json:"hard_failures,omitempty"`
+		SoftFailures []policyDecisionFailure `json:"soft_failures,omitempty"`
+	} `json:"decision"`
+	Metadata struct {
+		BuildNumber int64  `json:"build_number"`
+		ProjectID   string `json:"project_id"`
+		SSHRerun    bool   `json:"ssh_rerun"`
+		VCS         struct {
+			Branch string `json:"branch,omitempty"`
+		} `json:"vcs"`
+	} `json:"metadata"`
+	TimeTakenMS int64 `json:"time_taken_ms"`
+}
+
+func toPolicyDecisionResponse(d PolicyDecision) policyDecisionResponse {
+	failures := func(fs []PolicyFailure) []policyDecisionFailure {
+		res := make([]policyDecisionFailure, 0, len(fs))
+		for _, f := range fs {
+			res = append(res, policyDecisionFailure(f))
+		}
+		return res
+	}
+
+	var res policyDecisionResponse
+	res.ID = d.ID
+	res.CreatedAt = d.CreatedAt
+	res.Decision.Status = d.Status
+	res.Decision.Reason = d.Reason
+	res.Decision.EnabledRules = slices.Clone(d.EnabledRules)
+	if res.Decision.EnabledRules == nil {
+		res.Decision.EnabledRules = []string{}
+	}
+	res.Decision.HardFailures = failures(d.HardFailures)
+	res.Decision.SoftFailures = failures(d.SoftFailures)
+	res.Metadata.BuildNumber = d.BuildNumber
+	if d.ProjectID != uuid.Nil {
+		res.Metadata.ProjectID = d.ProjectID.String()
+	}
+	res.Metadata.VCS.Branch = d.Branch
+	res.TimeTakenMS = d.TimeTaken.Milliseconds()
+	return res
+}
+
+// policyOwnerParam reads the owner ID from the request path, and checks the
+// policy context is config, the only one there is.
+func policyOwnerParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	ownerID, err := uuid.Parse(chi.URLParam(r, "owner-id"))
+	if badRequest(w, r, "bad owner ID", err) {
+		return uuid.Nil, false
+	}
+	if chi.URLParam(r, "context") != "config" {
+		msg(w, r, http.StatusBadRequest, "invalid context")
+		return uuid.Nil, false
+	}
+	return ownerID, true
+}
+
+func (s *Service) getPolicyBundle(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := policyOwnerParam(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.RLock()
+	o, err := s.orgLocked(ownerID)
+	if err != nil {
+		s.mu.RUnlock()
+		writeError(w, r, err, "owner not found")
+		return
+	}
+	res := make(map[string][]policyResponse, len(o.policies))
+	for _, name := range slices.Sorted(maps.Keys(o.policies)) {
+		p := o.policies[name]
+		res[name] = []policyResponse{{
+			Name:      name,
+			Content:   p.content,
+			CreatedAt: p.createdAt,
+			CreatedBy: p.createdBy.String(),
+		}}
+	}
+	s.mu.RUnlock()
+
+	respond(w, r, http.StatusOK, res)
+}
+
+func (s *Service) postPolicyBundle(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := policyOwnerParam(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Policies map[string]string `json:"policies"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	dry, _ := strconv.ParseBool(r.URL.Query().Get("dry"))
+	diff, err := s.pushPolicyBundle(ownerID, body.Policies, dry)
+	if err != nil {
+		writeError(w, r, err, "owner not found")
+		return
+	}
+
+	status := http.StatusCreated
+	if dry {
+		status = http.StatusOK
+	}
+	respond(w, r, status, diff)
+}
+
+type policySettingsResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (s *Service) getPolicySettings(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := policyOwnerParam(w, r)
+	if !ok {
+		return
+	}
+
+	enabled, err := s.PolicyDecisionsEnabled(ownerID)
+	if err != nil {
+		writeError(w, r, err, "owner not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, policySettingsResponse{Enabled: enabled})
+}
+
+func (s *Service) patchPolicySettings(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := policyOwnerParam(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	s.mu.Lock()
+	o, err := s.orgLocked(ownerID)
+	if err != nil {
+		s.mu.Unlock()
+		writeError(w, r, err, "owner not found")
+		return
+	}
+	if body.Enabled != nil {
+		o.policyEnabled = *body.Enabled
+	}
+	enabled := o.policyEnabled
+	s.mu.Unlock()
+
+	respond(w, r, http.StatusOK, policySettingsResponse{Enabled: enabled})
+}
+
+func (s *Service) getPolicyDecisions(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := policyOwnerParam(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	var after, before time.Time
+	var offset int
+	var err error
+	if v := q.Get("after"); v != "" {
+		after, err = time.Parse(time.RFC3339, v)
+		if badRequest(w, r, "bad after", err) {
+			return
+		}
+	}
+	if v := q.Get("before"); v != "" {
+		before, err = time.Parse(time.RFC3339, v)
+		if badRequest(w, r, "bad before", err) {
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		offset, err = strconv.Atoi(v)
+		if badRequest(w, r, "bad offset", err) {
+			return
+		}
+	}
+
+	s.mu.RLock()
+	o, err := s.orgLocked(ownerID)
+	if err != nil {
+		s.mu.RUnlock()
+		writeError(w, r, err, "owner not found")
+		return
+	}
+	decisions := slices.Clone(o.policyDecisions)
+	s.mu.RUnlock()
+
+	// Newest first.
+	slices.Reverse(decisions)
+	decisions = slices.DeleteFunc(decisions, func(d PolicyDecision) bool {
+		return (q.Get("status") != "" && d.Status != q.Get("status")) ||
+			(q.Get("branch") != "" && d.Branch != q.Get("branch")) ||
+			(q.Get("project_id") != "" && d.ProjectID.String() != q.Get("project_id")) ||
+			(!after.IsZero() && !d.CreatedAt.After(after)) ||
+			(!before.IsZero() && !d.CreatedAt.Before(before))
+	})
+
+	offset = min(max(offset, 0), len(decisions))
+	end := min(offset+int(s.pageSize.Load()), len(decisions))
+	res := make([]policyDecisionResponse, 0, end-offset)
+	for _, d := range decisions[offset:end] {
+		res = append(res, toPolicyDecisionResponse(d))
+	}
+	respond(w, r, http.StatusOK, res)
+}
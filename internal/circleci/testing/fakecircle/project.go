@@ -16,10 +16,16 @@ import (
 )
 
 type project struct {
-	Org     *org
-	ID      uuid.UUID
-	Name    string
-	EnvVars []EnvVarProject
+	Org          *org
+	ID           uuid.UUID
+	Name         string
+	EnvVars      []EnvVarProject
+	Settings     ProjectSettings
+	Pipelines    []*pipelineDefinition
+	Webhooks     []*webhook
+	CheckoutKeys []CheckoutKey
+	SSHKeys      []SSHKey
+	OIDCClaims   OIDCClaims
 }
 
 func (p *project) ToProject() Project {
@@ -40,6 +46,8 @@ func (p *project) ToProject() Project {
 }
 
 type NewProject struct {
+	// ID is optional; a random ID is used if it is unset.
+	ID    uuid.UUID
 	OrgID uuid.UUID
 	Name  string
 }
@@ -61,6 +69,10 @@ func (s *Service) AddProject(np NewProject) (Project, error) {
 		return Project{}, errNotFound
 	}
 
+	if _, ok := s.projects[np.ID]; ok {
+		return Project{}, errDuplicate
+	}
+
 	p, err := o.addProject(np)
 	if err != nil {
 		return Project{}, err
@@ -111,15 +123,27 @@ func (s *Service) deleteProjectBySlug(orgType, orgName, projectName string) erro
 
 	p, err := s.projectBySlugLocked(orgType, orgName, projectName)
 	if err != nil {
-		return nil
+		return err
 	}
 
-	o := s.orgs[p.Org.ID]
-	o.deleteProject(p.ID)
-	delete(s.projects, p.ID)
+	s.deleteProjectLocked(s.projects[p.ID])
 	return nil
 }
 
+// deleteProjectLocked requires s.mu to be held for writing. It also deletes
+// everything that belongs to the project.
+func (s *Service) deleteProjectLocked(p *project) {
+	for _, pd := range slices.Clone(p.Pipelines) {
+		s.deletePipelineDefinitionLocked(pd)
+	}
+	for _, wh := range slices.Clone(p.Webhooks) {
+		s.deleteWebhookLocked(wh)
+	}
+
+	p.Org.deleteProject(p.ID)
+	delete(s.projects, p.ID)
+}
+
 // handlers below here
 
 func (s *Service) postProject(w http.ResponseWriter, r *http.Request) {
@@ -195,6 +219,24 @@ func orgTypeParam(w http.ResponseWriter, r *http.Request) (string, bool) {
 	}
 }
 
+// projectParam resolves the project slug in the request path, writing a 404
+// and returning false if there is none.
+func (s *Service) projectParam(w http.ResponseWriter, r *http.Request) (Project, bool) {
+	orgType, ok := orgTypeParam(w, r)
+	if !ok {
+		return Project{}, false
+	}
+
+	orgName := chi.URLParam(r, "org-name")
+	projectName := chi.URLParam(r, "project-name")
+	prj, err := s.projectBySlug(orgType, orgName, projectName)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return Project{}, false
+	}
+	return prj, true
+}
+
 func (s *Service) getProject(w http.ResponseWriter, r *http.Request) {
 	type response struct {
 		ID   uuid.UUID `json:"id"`
@@ -291,6 +333,21 @@ func (p *project) addEnv(ev NewEnvVarProject) (EnvVarProject, error) {
 	return e, nil
 }
 
+// putEnv sets an environment variable's value, adding it if it is new, as
+// the API's POST does.
+func (p *project) putEnv(ev NewEnvVarProject) EnvVarProject {
+	i := slices.IndexFunc(p.EnvVars, func(e EnvVarProject) bool {
+		return e.Name == ev.Name
+	})
+	if i == -1 {
+		e, _ := p.addEnv(ev)
+		return e
+	}
+
+	p.EnvVars[i].Value = ev.Value
+	return p.EnvVars[i]
+}
+
 func (p *project) deleteEnv(ev string) {
 	p.EnvVars = slices.DeleteFunc(p.EnvVars, func(e EnvVarProject) bool {
 		return e.Name == ev
@@ -309,6 +366,20 @@ func (s *Service) AddProjectEnv(projectID uuid.UUID, ev NewEnvVarProject) (EnvVa
 	return envPrj.addEnv(ev)
 }
 
+// PutProjectEnv sets a project environment variable's value, adding it if
+// it is new.
+func (s *Service) PutProjectEnv(projectID uuid.UUID, ev NewEnvVarProject) (EnvVarProject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	envPrj, ok := s.projects[projectID]
+	if !ok {
+		return EnvVarProject{}, errNotFound
+	}
+
+	return envPrj.putEnv(ev), nil
+}
+
 // projectEnv returns a copy of a project's environment variables.
 func (s *Service) projectEnv(id uuid.UUID) ([]EnvVarProject, bool) {
 	s.mu.RLock()
@@ -369,7 +440,51 @@ func (s *Service) getProjectEnv(w http.ResponseWriter, r *http.Request) {
 			Value:     ev.Value,
 		})
 	}
-	respond(w, r, http.StatusOK, newListResponse(res))
+	respondPage(w, r, res, int(s.pageSize.Load()))
+}
+
+// getProjectEnvVar returns one environment variable, with its value masked
+// as the API does.
+func (s *Service) getProjectEnvVar(w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		Name      string    `json:"name"`
+		Value     string    `json:"value"`
+		CreatedAt time.Time `json:"created-at"`
+	}
+
+	orgType, ok := orgTypeParam(w, r)
+	if !ok {
+		return
+	}
+
+	orgName := chi.URLParam(r, "org-name")
+	projectName := chi.URLParam(r, "project-name")
+	prj, err := s.projectBySlug(orgType, orgName, projectName)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	envVars, ok := s.projectEnv(prj.ID)
+	if !ok {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	i := slices.IndexFunc(envVars, func(e EnvVarProject) bool {
+		return e.Name == chi.URLParam(r, "env-var")
+	})
+	if i == -1 {
+		msg(w, r, http.StatusNotFound, "env var not found")
+		return
+	}
+
+	ev := envVars[i]
+	respond(w, r, http.StatusOK, response{
+		Name:      ev.Name,
+		Value:     "xxxx" + ev.Value[max(len(ev.Value)-4, 0):],
+		CreatedAt: ev.CreatedAt,
+	})
 }
 
 func (s *Service) postProjectEnv(w http.ResponseWriter, r *http.Request) {
@@ -401,7 +516,7 @@ func (s *Service) postProjectEnv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ev, err := s.AddProjectEnv(prj.ID, NewEnvVarProject{
+	ev, err := s.PutProjectEnv(prj.ID, NewEnvVarProject{
 		Name:  body.Name,
 		Value: body.Value,
 	})
@@ -409,9 +524,6 @@ func (s *Service) postProjectEnv(w http.ResponseWriter, r *http.Request) {
 	case errors.Is(err, errNotFound):
 		msg(w, r, http.StatusBadRequest, "project not found")
 		return
-	case errors.Is(err, errDuplicate):
-		msg(w, r, http.StatusBadRequest, "env var already exists")
-		return
 	case err != nil:
 		msg(w, r, http.StatusInternalServerError, err.Error())
 		return
@@ -452,3 +564,162 @@ func (s *Service) deleteProjectEnv(w http.ResponseWriter, r *http.Request) {
 
 	msg(w, r, http.StatusOK, "ok")
 }
+
+// ProjectSettings are a project's advanced settings, as served by the
+// settings endpoint.
+type ProjectSettings struct {
+	AutocancelBuilds           bool     `json:"autocancel_builds" yaml:"autocancel_builds"`
+	BuildForkPRs               bool     `json:"build_fork_prs" yaml:"build_fork_prs"`
+	DisableSSH                 bool     `json:"disable_ssh" yaml:"disable_ssh"`
+	ForksReceiveSecretEnvVars  bool     `json:"forks_receive_secret_env_vars" yaml:"forks_receive_secret_env_vars"`
+	OSS                        bool     `json:"oss" yaml:"oss"`
+	SetGitHubStatus            bool     `json:"set_github_status" yaml:"set_github_status"`
+	SetupWorkflows             bool     `json:"setup_workflows" yaml:"setup_workflows"`
+	WriteSettingsRequiresAdmin bool     `json:"write_settings_requires_admin" yaml:"write_settings_requires_admin"`
+	PROnlyBranchOverrides      []string `json:"pr_only_branch_overrides" yaml:"pr_only_branch_overrides"`
+}
+
+// defaultProjectSettings are the settings of a newly created project.
+func defaultProjectSettings() ProjectSettings {
+	return ProjectSettings{
+		SetGitHubStatus:       true,
+		PROnlyBranchOverrides: []string{"main"},
+	}
+}
+
+// projectSettingsUpdate is the advanced section of a settings PATCH. Nil
+// fields are left unchanged.
+type projectSettingsUpdate struct {
+	AutocancelBuilds           *bool    `json:"autocancel_builds"`
+	BuildForkPRs               *bool    `json:"build_fork_prs"`
+	DisableSSH                 *bool    `json:"disable_ssh"`
+	ForksReceiveSecretEnvVars  *bool    `json:"forks_receive_secret_env_vars"`
+	OSS                        *bool    `json:"oss"`
+	SetGitHubStatus            *bool    `json:"set_github_status"`
+	SetupWorkflows             *bool    `json:"setup_workflows"`
+	WriteSettingsRequiresAdmin *bool    `json:"write_settings_requires_admin"`
+	PROnlyBranchOverrides      []string `json:"pr_only_branch_overrides"`
+}
+
+func (s *Service) ProjectSettings(id uuid.UUID) (ProjectSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.projects[id]
+	if !ok {
+		return ProjectSettings{}, errNotFound
+	}
+
+	settings := p.Settings
+	settings.PROnlyBranchOverrides = slices.Clone(settings.PROnlyBranchOverrides)
+	return settings, nil
+}
+
+// SetProjectSettings replaces all of a project's settings.
+func (s *Service) SetProjectSettings(id uuid.UUID, settings ProjectSettings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[id]
+	if !ok {
+		return errNotFound
+	}
+
+	settings.PROnlyBranchOverrides = slices.Clone(settings.PROnlyBranchOverrides)
+	p.Settings = settings
+	return nil
+}
+
+func (s *Service) updateProjectSettings(id uuid.UUID, u projectSettingsUpdate) (ProjectSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[id]
+	if !ok {
+		return ProjectSettings{}, errNotFound
+	}
+
+	set := func(dst *bool, src *bool) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	settings := &p.Settings
+	set(&settings.AutocancelBuilds, u.AutocancelBuilds)
+	set(&settings.BuildForkPRs, u.BuildForkPRs)
+	set(&settings.DisableSSH, u.DisableSSH)
+	set(&settings.ForksReceiveSecretEnvVars, u.ForksReceiveSecretEnvVars)
+	set(&settings.OSS, u.OSS)
+	set(&settings.SetGitHubStatus, u.SetGitHubStatus)
+	set(&settings.SetupWorkflows, u.SetupWorkflows)
+	set(&settings.WriteSettingsRequiresAdmin, u.WriteSettingsRequiresAdmin)
+	if u.PROnlyBranchOverrides != nil {
+		settings.PROnlyBranchOverrides = slices.Clone(u.PROnlyBranchOverrides)
+	}
+
+	res := *settings
+	res.PROnlyBranchOverrides = slices.Clone(res.PROnlyBranchOverrides)
+	return res, nil
+}
+
+type projectSettingsResponse struct {
+	Advanced ProjectSettings `json:"advanced"`
+}
+
+func (s *Service) getProjectSettings(w http.ResponseWriter, r *http.Request) {
+	orgType, ok := orgTypeParam(w, r)
+	if !ok {
+		return
+	}
+
+	orgName := chi.URLParam(r, "org-name")
+	projectName := chi.URLParam(r, "project-name")
+	prj, err := s.projectBySlug(orgType, orgName, projectName)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	settings, err := s.ProjectSettings(prj.ID)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, projectSettingsResponse{Advanced: settings})
+}
+
+func (s *Service) patchProjectSettings(w http.ResponseWriter, r *http.Request) {
+	orgType, ok := orgTypeParam(w, r)
+	if !ok {
+		return
+	}
+
+	orgName := chi.URLParam(r, "org-name")
+	projectName := chi.URLParam(r, "project-name")
+
+	var body struct {
+		Advanced *projectSettingsUpdate `json:"advanced"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+	if body.Advanced == nil {
+		msg(w, r, http.StatusBadRequest, "advanced is required")
+		return
+	}
+
+	prj, err := s.projectBySlug(orgType, orgName, projectName)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	settings, err := s.updateProjectSettings(prj.ID, *body.Advanced)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, projectSettingsResponse{Advanced: settings})
+}
@@ -4,8 +4,11 @@
 package fakecircle
 
 import (
+	"encoding/base64"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/render"
 )
@@ -45,3 +48,56 @@ type listResponse[T any] struct {
 func newListResponse[T any](items []T) listResponse[T] {
 	return listResponse[T]{Items: items}
 }
+
+// defaultPageSize is how many items a paginated list returns per page, unless
+// changed with SetPageSize.
+const defaultPageSize = 20
+
+var errBadPageToken = errors.New("invalid page token")
+
+// SetPageSize changes how many items each paginated list returns per page, so
+// tests can exercise pagination without creating dozens of objects. A size
+// below 1 restores the default, since an empty page would never advance.
+func (s *Service) SetPageSize(n int) {
+	if n < 1 {
+		n = defaultPageSize
+	}
+	s.pageSize.Store(int64(n))
+}
+
+// paginate returns the page of items starting at pageToken, which is either
+// empty for the first page or a token from a previous page. Callers must pass
+// items in a stable order.
+func paginate[T any](items []T, pageToken string, pageSize int) (listResponse[T], error) {
+	offset := 0
+	if pageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil {
+			return listResponse[T]{}, errBadPageToken
+		}
+		offset, err = strconv.Atoi(string(b))
+		if err != nil || offset < 0 || offset > len(items) {
+			return listResponse[T]{}, errBadPageToken
+		}
+	}
+
+	end := min(offset+pageSize, len(items))
+	res := listResponse[T]{Items: items[offset:end]}
+	if end < len(items) {
+		next := base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+		res.NextPageToken = &next
+	}
+	return res, nil
+}
+
+// respondPage writes the page of items selected by the request's page-token
+// query parameter, or a 400 if the token is not one the fake issued.
+func respondPage[T any](w http.ResponseWriter, r *http.Request, items []T, pageSize int) {
+	page, err := paginate(items, r.URL.Query().Get("page-token"), pageSize)
+	if err != nil {
+		msg(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respond(w, r, http.StatusOK, page)
+}
@@ -30,15 +30,15 @@ type token struct {
 }
 
 type runner struct {
-	Name           string
-	Hostname       string
-	IP             string
-	Version        string
-	Status         string
-	ResourceClass  string
-	FirstConnected string
-	LastConnected  string
-	LastUsed       string
+	Name           string `json:"name"`
+	Hostname       string `json:"hostname"`
+	IP             string `json:"ip"`
+	Version        string `json:"version"`
+	Status         string `json:"status"`
+	ResourceClass  string `json:"resource_class"`
+	FirstConnected string `json:"first_connected"`
+	LastConnected  string `json:"last_connected"`
+	LastUsed       string `json:"last_used"`
 }
 
 func (s *Service) setupRunnerRoutes(r chi.Router) {
@@ -69,6 +69,47 @@ func (s *Service) AddResourceClass(id, resourceClassIn, description string) erro
 	return nil
 }
 
+// addRunnerToken adds t to the resource class it names.
+func (s *Service) addRunnerToken(t token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tokens[t.ID]; exists {
+		return errDuplicate
+	}
+	for _, rc := range s.resourceClasses {
+		if rc.ResourceClass == t.ResourceClass {
+			s.tokens[t.ID] = &t
+			rc.Tokens = append(rc.Tokens, &t)
+			return nil
+		}
+	}
+	return errNotFound
+}
+
+// Runner is a runner agent, as listed by the runner API.
+type Runner struct {
+	Name           string
+	Hostname       string
+	IP             string
+	Version        string
+	Status         string
+	ResourceClass  string
+	FirstConnected string
+	LastConnected  string
+	LastUsed       string
+}
+
+// AddRunner adds a connected runner agent. Agents are never created through
+// the API, so this is the only way to make the runner list non-empty.
+func (s *Service) AddRunner(r Runner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rn := runner(r)
+	s.runners = append(s.runners, &rn)
+}
+
 func (s *Service) listRunners(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	resourceClass := query.Get("resource-class")
@@ -94,7 +135,7 @@ func (s *Service) listRunners(w http.ResponseWriter, r *http.Request) {
 		filtered = append(filtered, *rn)
 	}
 
-	respond(w, r, http.StatusOK, filtered)
+	respond(w, r, http.StatusOK, newListResponse(filtered))
 }
 
 func (s *Service) listResourceClasses(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,558 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Seed is a snapshot of the fake's state. LoadSeed adds one to a fake and Dump
+// takes one, so a dump can be used as the seed for a later run. It is tagged
+// for both JSON and YAML.
+//
+// IDs are optional when loading; random ones are used where they are unset.
+// Creation and update times are not kept, except in OIDC claims and policy
+// decisions, where they are part of what the API serves. The user, org
+// tokens, injected faults and the call journal are not part of the state, and
+// context restrictions are not faked at all.
+type Seed struct {
+	Orgs            []SeedOrg           `json:"orgs,omitempty" yaml:"orgs,omitempty"`
+	ResourceClasses []SeedResourceClass `json:"resource_classes,omitempty" yaml:"resource_classes,omitempty"`
+	Runners         []SeedRunner        `json:"runners,omitempty" yaml:"runners,omitempty"`
+}
+
+type SeedOrg struct {
+	ID       uuid.UUID     `json:"id,omitzero" yaml:"id,omitempty"`
+	Type     string        `json:"type" yaml:"type"`
+	Name     string        `json:"name" yaml:"name"`
+	Slug     string        `json:"slug,omitempty" yaml:"slug,omitempty"`
+	Contexts []SeedContext `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+	Projects []SeedProject `json:"projects,omitempty" yaml:"projects,omitempty"`
+
+	OIDCClaims *OIDCClaims `json:"oidc_claims,omitempty" yaml:"oidc_claims,omitempty"`
+
+	// Policies is the content of each policy in the org's bundle, keyed by
+	// name.
+	Policies               map[string]string `json:"policies,omitempty" yaml:"policies,omitempty"`
+	PolicyDecisionsEnabled bool              `json:"policy_decisions_enabled,omitempty" yaml:"policy_decisions_enabled,omitempty"`
+	PolicyDecisions        []PolicyDecision  `json:"policy_decisions,omitempty" yaml:"policy_decisions,omitempty"`
+}
+
+type SeedContext struct {
+	ID      uuid.UUID         `json:"id,omitzero" yaml:"id,omitempty"`
+	Name    string            `json:"name" yaml:"name"`
+	EnvVars map[string]string `json:"env_vars,omitempty" yaml:"env_vars,omitempty"`
+}
+
+type SeedProject struct {
+	ID       uuid.UUID         `json:"id,omitzero" yaml:"id,omitempty"`
+	Name     string            `json:"name" yaml:"name"`
+	Slug     string            `json:"slug,omitempty" yaml:"slug,omitempty"`
+	EnvVars  map[string]string `json:"env_vars,omitempty" yaml:"env_vars,omitempty"`
+	Settings *ProjectSettings  `json:"settings,omitempty" yaml:"settings,omitempty"`
+
+	Pipelines    []SeedPipeline    `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
+	Webhooks     []SeedWebhook     `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+	CheckoutKeys []SeedCheckoutKey `json:"checkout_keys,omitempty" yaml:"checkout_keys,omitempty"`
+	SSHKeys      []SeedSSHKey      `json:"ssh_keys,omitempty" yaml:"ssh_keys,omitempty"`
+	OIDCClaims   *OIDCClaims       `json:"oidc_claims,omitempty" yaml:"oidc_claims,omitempty"`
+}
+
+type SeedPipeline struct {
+	ID             uuid.UUID      `json:"id,omitzero" yaml:"id,omitempty"`
+	Name           string         `json:"name" yaml:"name"`
+	Description    string         `json:"description,omitempty" yaml:"description,omitempty"`
+	ConfigSource   ConfigSource   `json:"config_source" yaml:"config_source"`
+	CheckoutSource CheckoutSource `json:"checkout_source" yaml:"checkout_source"`
+	Triggers       []SeedTrigger  `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+}
+
+type SeedTrigger struct {
+	ID          uuid.UUID       `json:"id,omitzero" yaml:"id,omitempty"`
+	EventSource SeedEventSource `json:"event_source" yaml:"event_source"`
+	EventName   string          `json:"event_name,omitempty" yaml:"event_name,omitempty"`
+	EventPreset string          `json:"event_preset,omitempty" yaml:"event_preset,omitempty"`
+	CheckoutRef string          `json:"checkout_ref,omitempty" yaml:"checkout_ref,omitempty"`
+	ConfigRef   string          `json:"config_ref,omitempty" yaml:"config_ref,omitempty"`
+	Disabled    bool            `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	Parameters  map[string]any  `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+type SeedEventSource struct {
+	Provider string         `json:"provider" yaml:"provider"`
+	Repo     Repo           `json:"repo,omitzero" yaml:"repo,omitempty"`
+	Webhook  TriggerWebhook `json:"webhook,omitzero" yaml:"webhook,omitempty"`
+	// CronExpression and AttributionActor are set for schedule triggers.
+	// AttributionActor takes the same values as the API.
+	CronExpression   string `json:"cron_expression,omitempty" yaml:"cron_expression,omitempty"`
+	AttributionActor string `json:"attribution_actor,omitempty" yaml:"attribution_actor,omitempty"`
+}
+
+type SeedWebhook struct {
+	ID            uuid.UUID `json:"id,omitzero" yaml:"id,omitempty"`
+	Name          string    `json:"name" yaml:"name"`
+	URL           string    `json:"url" yaml:"url"`
+	VerifyTLS     bool      `json:"verify_tls,omitempty" yaml:"verify_tls,omitempty"`
+	SigningSecret string    `json:"signing_secret" yaml:"signing_secret"`
+	Events        []string  `json:"events" yaml:"events"`
+}
+
+// SeedCheckoutKey is a project checkout key. A new keypair is generated when
+// PublicKey is unset.
+type SeedCheckoutKey struct {
+	Type        string `json:"type" yaml:"type"`
+	PublicKey   string `json:"public_key,omitempty" yaml:"public_key,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+}
+
+// SeedSSHKey is a project's additional SSH key. The fake keeps only the
+// public half of a key, so dumps have PublicKey and Fingerprint, while seeds
+// may set PrivateKey instead.
+type SeedSSHKey struct {
+	Hostname    string `json:"hostname" yaml:"hostname"`
+	PrivateKey  string `json:"private_key,omitempty" yaml:"private_key,omitempty"`
+	PublicKey   string `json:"public_key,omitempty" yaml:"public_key,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+}
+
+type SeedResourceClass struct {
+	ID            string            `json:"id,omitempty" yaml:"id,omitempty"`
+	ResourceClass string            `json:"resource_class" yaml:"resource_class"`
+	Description   string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Tokens        []SeedRunnerToken `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+}
+
+type SeedRunnerToken struct {
+	ID        string `json:"id,omitempty" yaml:"id,omitempty"`
+	Nickname  string `json:"nickname" yaml:"nickname"`
+	Token     string `json:"token,omitempty" yaml:"token,omitempty"`
+	CreatedAt string `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+}
+
+type SeedRunner struct {
+	Name          string `json:"name" yaml:"name"`
+	Hostname      string `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	IP            string `json:"ip,omitempty" yaml:"ip,omitempty"`
+	Version       string `json:"version,omitempty" yaml:"version,omitempty"`
+	Status        string `json:"status,omitempty" yaml:"status,omitempty"`
+	ResourceClass string `json:"resource_class" yaml:"resource_class"`
+
+	FirstConnected string `json:"first_connected,omitempty" yaml:"first_connected,omitempty"`
+	LastConnected  string `json:"last_connected,omitempty" yaml:"last_connected,omitempty"`
+	LastUsed       string `json:"last_used,omitempty" yaml:"last_used,omitempty"`
+}
+
+// LoadSeed adds everything in seed to the fake. Slugs in the seed are ignored,
+// as they are derived from the type, name and ID.
+func (s *Service) LoadSeed(seed Seed) error {
+	for _, so := range seed.Orgs {
+		o, err := s.AddOrg(NewOrg{
+			ID:   so.ID,
+			Type: so.Type,
+			Name: so.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("org %q: %w", so.Name, err)
+		}
+
+		for _, sc := range so.Contexts {
+			c, err := s.AddContext(NewContext{
+				ID:    sc.ID,
+				OrgID: o.ID,
+				Name:  sc.Name,
+			})
+			if err != nil {
+				return fmt.Errorf("org %q: context %q: %w", so.Name, sc.Name, err)
+			}
+			for _, name := range slices.Sorted(maps.Keys(sc.EnvVars)) {
+				_, err := s.AddContextEnv(c.ID, NewEnvVarContext{
+					Variable: name,
+					Value:    sc.EnvVars[name],
+				})
+				if err != nil {
+					return fmt.Errorf("org %q: context %q: env var %q: %w", so.Name, sc.Name, name, err)
+				}
+			}
+		}
+
+		for _, sp := range so.Projects {
+			if err := s.loadSeedProject(o.ID, sp); err != nil {
+				return fmt.Errorf("org %q: project %q: %w", so.Name, sp.Name, err)
+			}
+		}
+
+		if so.OIDCClaims != nil {
+			if err := s.SetOrgOIDCClaims(o.ID, *so.OIDCClaims); err != nil {
+				return fmt.Errorf("org %q: oidc claims: %w", so.Name, err)
+			}
+		}
+		if len(so.Policies) > 0 {
+			if err := s.SetPolicyBundle(o.ID, so.Policies); err != nil {
+				return fmt.Errorf("org %q: policies: %w", so.Name, err)
+			}
+		}
+		if err := s.SetPolicyDecisionsEnabled(o.ID, so.PolicyDecisionsEnabled); err != nil {
+			return fmt.Errorf("org %q: policy settings: %w", so.Name, err)
+		}
+		for _, d := range so.PolicyDecisions {
+			if _, err := s.AddPolicyDecision(o.ID, d); err != nil {
+				return fmt.Errorf("org %q: policy decision %s: %w", so.Name, d.ID, err)
+			}
+		}
+	}
+
+	for _, rc := range seed.ResourceClasses {
+		id := rc.ID
+		if id == "" {
+			id = uuid.NewString()
+		}
+		if err := s.AddResourceClass(id, rc.ResourceClass, rc.Description); err != nil {
+			return fmt.Errorf("resource class %q: %w", rc.ResourceClass, err)
+		}
+		for _, st := range rc.Tokens {
+			t := token{
+				ID:            st.ID,
+				Nickname:      st.Nickname,
+				ResourceClass: rc.ResourceClass,
+				Token:         st.Token,
+				CreatedAt:     st.CreatedAt,
+			}
+			if t.ID == "" {
+				t.ID = uuid.NewString()
+			}
+			if t.Token == "" {
+				t.Token = "token_" + uuid.NewString()
+			}
+			if err := s.addRunnerToken(t); err != nil {
+				return fmt.Errorf("resource class %q: token %q: %w", rc.ResourceClass, st.Nickname, err)
+			}
+		}
+	}
+
+	for _, r := range seed.Runners {
+		s.AddRunner(Runner{
+			Name:           r.Name,
+			Hostname:       r.Hostname,
+			IP:             r.IP,
+			Version:        r.Version,
+			Status:         r.Status,
+			ResourceClass:  r.ResourceClass,
+			FirstConnected: r.FirstConnected,
+			LastConnected:  r.LastConnected,
+			LastUsed:       r.LastUsed,
+		})
+	}
+
+	return nil
+}
+
+// loadSeedProject adds sp and everything in it to the org with the given ID.
+func (s *Service) loadSeedProject(orgID uuid.UUID, sp SeedProject) error {
+	p, err := s.AddProject(NewProject{
+		ID:    sp.ID,
+		OrgID: orgID,
+		Name:  sp.Name,
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(sp.EnvVars)) {
+		_, err := s.AddProjectEnv(p.ID, NewEnvVarProject{
+			Name:  name,
+			Value: sp.EnvVars[name],
+		})
+		if err != nil {
+			return fmt.Errorf("env var %q: %w", name, err)
+		}
+	}
+	if sp.Settings != nil {
+		if err := s.SetProjectSettings(p.ID, *sp.Settings); err != nil {
+			return fmt.Errorf("settings: %w", err)
+		}
+	}
+
+	for _, spd := range sp.Pipelines {
+		pd, err := s.AddPipelineDefinition(NewPipelineDefinition{
+			ID:             spd.ID,
+			ProjectID:      p.ID,
+			Name:           spd.Name,
+			Description:    spd.Description,
+			ConfigSource:   spd.ConfigSource,
+			CheckoutSource: spd.CheckoutSource,
+		})
+		if err != nil {
+			return fmt.Errorf("pipeline %q: %w", spd.Name, err)
+		}
+		for _, st := range spd.Triggers {
+			_, err := s.AddTrigger(NewTrigger{
+				ID:                   st.ID,
+				ProjectID:            p.ID,
+				PipelineDefinitionID: pd.ID,
+				EventSource: EventSource{
+					Provider: st.EventSource.Provider,
+					Repo:     st.EventSource.Repo,
+					Webhook:  st.EventSource.Webhook,
+					Schedule: TriggerSchedule{CronExpression: st.EventSource.CronExpression},
+				},
+				AttributionActor: st.EventSource.AttributionActor,
+				EventName:        st.EventName,
+				EventPreset:      st.EventPreset,
+				CheckoutRef:      st.CheckoutRef,
+				ConfigRef:        st.ConfigRef,
+				Disabled:         st.Disabled,
+				Parameters:       st.Parameters,
+			})
+			if err != nil {
+				return fmt.Errorf("pipeline %q: trigger %s: %w", spd.Name, st.ID, err)
+			}
+		}
+	}
+
+	for _, sw := range sp.Webhooks {
+		_, err := s.AddWebhook(NewWebhook{
+			ID:            sw.ID,
+			ProjectID:     p.ID,
+			Name:          sw.Name,
+			URL:           sw.URL,
+			VerifyTLS:     sw.VerifyTLS,
+			SigningSecret: sw.SigningSecret,
+			Events:        sw.Events,
+		})
+		if err != nil {
+			return fmt.Errorf("webhook %q: %w", sw.Name, err)
+		}
+	}
+
+	for _, sk := range sp.CheckoutKeys {
+		if sk.PublicKey == "" {
+			_, err = s.AddCheckoutKey(p.ID, sk.Type)
+		} else {
+			err = s.addExistingCheckoutKey(p.ID, CheckoutKey{
+				Type:        sk.Type,
+				PublicKey:   sk.PublicKey,
+				Fingerprint: sk.Fingerprint,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("checkout key %q: %w", sk.Fingerprint, err)
+		}
+	}
+
+	for _, sk := range sp.SSHKeys {
+		if sk.PrivateKey != "" {
+			_, err = s.AddSSHKey(p.ID, sk.Hostname, sk.PrivateKey)
+		} else {
+			err = s.addExistingSSHKey(p.ID, SSHKey{
+				Hostname:    sk.Hostname,
+				PublicKey:   sk.PublicKey,
+				Fingerprint: sk.Fingerprint,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("ssh key for %q: %w", sk.Hostname, err)
+		}
+	}
+
+	if sp.OIDCClaims != nil {
+		if err := s.SetProjectOIDCClaims(p.ID, *sp.OIDCClaims); err != nil {
+			return fmt.Errorf("oidc claims: %w", err)
+		}
+	}
+	return nil
+}
+
+// Dump returns the fake's current state. Orgs, contexts, projects and
+// resource classes are sorted by name, and everything else is kept in the
+// order it was added, so dumps of the same state are identical.
+func (s *Service) Dump() Seed {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var seed Seed
+	for _, o := range s.orgs {
+		so := SeedOrg{
+			ID:                     o.id,
+			Type:                   o.typ,
+			Name:                   o.name,
+			Slug:                   fmtOrgSlug(o.typ, o.id, o.name),
+			OIDCClaims:             dumpOIDCClaims(o.oidcClaims),
+			PolicyDecisionsEnabled: o.policyEnabled,
+			PolicyDecisions:        slices.Clone(o.policyDecisions),
+		}
+		for name, p := range o.policies {
+			if so.Policies == nil {
+				so.Policies = make(map[string]string)
+			}
+			so.Policies[name] = p.content
+		}
+
+		for _, c := range o.contexts {
+			sc := SeedContext{
+				ID:   c.ID,
+				Name: c.Name,
+			}
+			for _, ev := range c.EnvVars {
+				if sc.EnvVars == nil {
+					sc.EnvVars = make(map[string]string)
+				}
+				sc.EnvVars[ev.Variable] = ev.Value
+			}
+			so.Contexts = append(so.Contexts, sc)
+		}
+		slices.SortFunc(so.Contexts, func(a, b SeedContext) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+
+		for _, p := range o.projects {
+			so.Projects = append(so.Projects, dumpProject(p))
+		}
+		slices.SortFunc(so.Projects, func(a, b SeedProject) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+
+		seed.Orgs = append(seed.Orgs, so)
+	}
+	slices.SortFunc(seed.Orgs, func(a, b SeedOrg) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	for _, rc := range s.resourceClasses {
+		src := SeedResourceClass{
+			ID:            rc.ID,
+			ResourceClass: rc.ResourceClass,
+			Description:   rc.Description,
+		}
+		for _, t := range rc.Tokens {
+			src.Tokens = append(src.Tokens, SeedRunnerToken{
+				ID:        t.ID,
+				Nickname:  t.Nickname,
+				Token:     t.Token,
+				CreatedAt: t.CreatedAt,
+			})
+		}
+		seed.ResourceClasses = append(seed.ResourceClasses, src)
+	}
+	slices.SortFunc(seed.ResourceClasses, func(a, b SeedResourceClass) int {
+		return cmp.Compare(a.ResourceClass, b.ResourceClass)
+	})
+
+	for _, r := range s.runners {
+		seed.Runners = append(seed.Runners, SeedRunner{
+			Name:           r.Name,
+			Hostname:       r.Hostname,
+			IP:             r.IP,
+			Version:        r.Version,
+			Status:         r.Status,
+			ResourceClass:  r.ResourceClass,
+			FirstConnected: r.FirstConnected,
+			LastConnected:  r.LastConnected,
+			LastUsed:       r.LastUsed,
+		})
+	}
+
+	return seed
+}
+
+// dumpProject returns p and everything in it. It requires s.mu to be held.
+func dumpProject(p *project) SeedProject {
+	settings := p.Settings
+	settings.PROnlyBranchOverrides = slices.Clone(settings.PROnlyBranchOverrides)
+	sp := SeedProject{
+		ID:         p.ID,
+		Name:       p.Name,
+		Slug:       p.ToProject().Slug,
+		Settings:   &settings,
+		OIDCClaims: dumpOIDCClaims(p.OIDCClaims),
+	}
+	for _, ev := range p.EnvVars {
+		if sp.EnvVars == nil {
+			sp.EnvVars = make(map[string]string)
+		}
+		sp.EnvVars[ev.Name] = ev.Value
+	}
+
+	for _, pd := range p.Pipelines {
+		spd := SeedPipeline{
+			ID:             pd.ID,
+			Name:           pd.Name,
+			Description:    pd.Description,
+			ConfigSource:   pd.ConfigSource,
+			CheckoutSource: pd.CheckoutSource,
+		}
+		for _, t := range pd.Triggers {
+			spd.Triggers = append(spd.Triggers, SeedTrigger{
+				ID: t.ID,
+				EventSource: SeedEventSource{
+					Provider:         t.EventSource.Provider,
+					Repo:             t.EventSource.Repo,
+					Webhook:          t.EventSource.Webhook,
+					CronExpression:   t.EventSource.Schedule.CronExpression,
+					AttributionActor: actorName(t.EventSource.Schedule.AttributionActor),
+				},
+				EventName:   t.EventName,
+				EventPreset: t.EventPreset,
+				CheckoutRef: t.CheckoutRef,
+				ConfigRef:   t.ConfigRef,
+				Disabled:    t.Disabled,
+				Parameters:  maps.Clone(t.Parameters),
+			})
+		}
+		sp.Pipelines = append(sp.Pipelines, spd)
+	}
+
+	for _, wh := range p.Webhooks {
+		sp.Webhooks = append(sp.Webhooks, SeedWebhook{
+			ID:            wh.ID,
+			Name:          wh.Name,
+			URL:           wh.URL,
+			VerifyTLS:     wh.VerifyTLS,
+			SigningSecret: wh.SigningSecret,
+			Events:        slices.Clone(wh.Events),
+		})
+	}
+
+	for _, k := range p.CheckoutKeys {
+		sp.CheckoutKeys = append(sp.CheckoutKeys, SeedCheckoutKey{
+			Type:        k.Type,
+			PublicKey:   k.PublicKey,
+			Fingerprint: k.Fingerprint,
+		})
+	}
+
+	for _, k := range p.SSHKeys {
+		sp.SSHKeys = append(sp.SSHKeys, SeedSSHKey{
+			Hostname:    k.Hostname,
+			PublicKey:   k.PublicKey,
+			Fingerprint: k.Fingerprint,
+		})
+	}
+	return sp
+}
+
+// dumpOIDCClaims returns a copy of claims, or nil if none are set.
+func dumpOIDCClaims(claims OIDCClaims) *OIDCClaims {
+	if len(claims.Audience) == 0 && claims.TTL == "" && claims.AudienceUpdatedAt.IsZero() && claims.TTLUpdatedAt.IsZero() {
+		return nil
+	}
+	claims.Audience = slices.Clone(claims.Audience)
+	return &claims
+}
+
+// actorName returns the API's name for a schedule trigger's attribution
+// actor, the reverse of resolveActor.
+func actorName(id uuid.UUID) string {
+	switch id {
+	case SystemActorID:
+		return ActorSystem
+	case CurrentActorID:
+		return ActorCurrent
+	default:
+		return ""
+	}
+}
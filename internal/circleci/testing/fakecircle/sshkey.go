@@ -0,0 +1,272 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"crypto/ed25519"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+var errBadPrivateKey = validationError("private_key must be a PEM-encoded RSA or ed25519 key without a passphrase")
+
+// sshPublicKey returns the OpenSSH public key of the given type made of
+// fields, and its MD5 fingerprint as colon-separated hex. The SSH wire format
+// of a public key is its type and then its fields, each prefixed with its
+// length.
+func sshPublicKey(keyType string, fields ...[]byte) (publicKey, fingerprint string) {
+	var blob []byte
+	for _, field := range append([][]byte{[]byte(keyType)}, fields...) {
+		blob = binary.BigEndian.AppendUint32(blob, uint32(len(field)))
+		blob = append(blob, field...)
+	}
+
+	sum := md5.Sum(blob)
+	hexSum := hex.EncodeToString(sum[:])
+	pairs := make([]string, 0, len(sum))
+	for i := 0; i < len(hexSum); i += 2 {
+		pairs = append(pairs, hexSum[i:i+2])
+	}
+
+	return keyType + " " + base64.StdEncoding.EncodeToString(blob), strings.Join(pairs, ":")
+}
+
+// sshMPInt encodes n as an SSH mpint, which is two's complement, so a
+// positive number whose top bit is set gets a leading zero byte.
+func sshMPInt(n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+// parseSSHPrivateKey returns the OpenSSH public key and fingerprint of a
+// PEM-encoded private key, as the API works out when a key is added.
+func parseSSHPrivateKey(privateKey string) (publicKey, fingerprint string, err error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return "", "", errBadPrivateKey
+	}
+
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		err = errBadPrivateKey
+	}
+	if err != nil {
+		return "", "", errBadPrivateKey
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		publicKey, fingerprint = sshPublicKey("ssh-rsa", sshMPInt(big.NewInt(int64(key.E))), sshMPInt(key.N))
+	case ed25519.PrivateKey:
+		pub, ok := key.Public().(ed25519.PublicKey)
+		if !ok {
+			return "", "", errBadPrivateKey
+		}
+		publicKey, fingerprint = sshPublicKey("ssh-ed25519", pub)
+	default:
+		return "", "", errBadPrivateKey
+	}
+	return publicKey, fingerprint, nil
+}
+
+// NewSSHPrivateKey returns a new PEM-encoded ed25519 private key, for tests
+// that add SSH keys.
+func NewSSHPrivateKey() (string, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// SSHKey is a project's additional SSH key. The fake keeps only what the
+// API returns, not the private key.
+type SSHKey struct {
+	Hostname    string
+	PublicKey   string
+	Fingerprint string
+}
+
+// AddSSHKey adds privateKey to a project as the SSH key for hostname.
+func (s *Service) AddSSHKey(projectID uuid.UUID, hostname, privateKey string) (SSHKey, error) {
+	publicKey, fingerprint, err := parseSSHPrivateKey(privateKey)
+	if err != nil {
+		return SSHKey{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return SSHKey{}, errNotFound
+	}
+
+	k := SSHKey{
+		Hostname:    hostname,
+		PublicKey:   publicKey,
+		Fingerprint: fingerprint,
+	}
+	if slices.Contains(p.SSHKeys, k) {
+		return SSHKey{}, errDuplicate
+	}
+	p.SSHKeys = append(p.SSHKeys, k)
+	return k, nil
+}
+
+// addExistingSSHKey adds an SSH key whose private key is not known, such as
+// one from an earlier run, to a project.
+func (s *Service) addExistingSSHKey(projectID uuid.UUID, k SSHKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return errNotFound
+	}
+
+	if slices.Contains(p.SSHKeys, k) {
+		return errDuplicate
+	}
+	p.SSHKeys = append(p.SSHKeys, k)
+	return nil
+}
+
+// SSHKeys returns a project's additional SSH keys, oldest first.
+func (s *Service) SSHKeys(projectID uuid.UUID) ([]SSHKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return slices.Clone(p.SSHKeys), nil
+}
+
+// DeleteSSHKey removes the project's SSH key for hostname with the given
+// fingerprint.
+func (s *Service) DeleteSSHKey(projectID uuid.UUID, hostname, fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return errNotFound
+	}
+
+	i := slices.IndexFunc(p.SSHKeys, func(k SSHKey) bool {
+		return k.Hostname == hostname && k.Fingerprint == fingerprint
+	})
+	if i == -1 {
+		return errNotFound
+	}
+
+	p.SSHKeys = slices.Delete(p.SSHKeys, i, i+1)
+	return nil
+}
+
+// handlers below here
+
+// getProjectSettingsV1 serves the v1.1 project settings, of which the fake
+// only has the SSH keys.
+func (s *Service) getProjectSettingsV1(w http.ResponseWriter, r *http.Request) {
+	type sshKey struct {
+		Hostname    string `json:"hostname"`
+		PublicKey   string `json:"public_key"`
+		Fingerprint string `json:"fingerprint"`
+	}
+	type response struct {
+		SSHKeys []sshKey `json:"ssh_keys"`
+	}
+
+	prj, ok := s.projectParam(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := s.SSHKeys(prj.ID)
+	if err != nil {
+		msg(w, r, http.StatusNotFound, "project not found")
+		return
+	}
+
+	res := response{SSHKeys: make([]sshKey, 0, len(keys))}
+	for _, k := range keys {
+		res.SSHKeys = append(res.SSHKeys, sshKey(k))
+	}
+	respond(w, r, http.StatusOK, res)
+}
+
+func (s *Service) postSSHKey(w http.ResponseWriter, r *http.Request) {
+	prj, ok := s.projectParam(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Hostname   string `json:"hostname"`
+		PrivateKey string `json:"private_key"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	_, err := s.AddSSHKey(prj.ID, body.Hostname, body.PrivateKey)
+	if err != nil {
+		writeError(w, r, err, "project not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Service) deleteSSHKey(w http.ResponseWriter, r *http.Request) {
+	prj, ok := s.projectParam(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Hostname    string `json:"hostname"`
+		Fingerprint string `json:"fingerprint"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	err := s.DeleteSSHKey(prj.ID, body.Hostname, body.Fingerprint)
+	if err != nil {
+		writeError(w, r, err, "ssh key not found")
+		return
+	}
+
+	msg(w, r, http.StatusOK, "ok")
+}
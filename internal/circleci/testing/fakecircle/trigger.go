@@ -0,0 +1,550 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// Event source providers a trigger accepts, besides the pipeline definition
+// providers.
+const (
+	ProviderWebhook  = "webhook"
+	ProviderSchedule = "schedule"
+)
+
+// Attribution actors a scheduled trigger can be created with. The API
+// resolves them to a user ID.
+const (
+	ActorSystem  = "system"
+	ActorCurrent = "current"
+)
+
+var (
+	// SystemActorID and CurrentActorID are the user IDs the fake resolves
+	// the attribution actors to.
+	SystemActorID  = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	CurrentActorID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+)
+
+var eventPresets = []string{
+	"all-pushes",
+	"only-tags",
+	"default-branch-pushes",
+	"only-build-prs",
+	"only-open-prs",
+	"only-labeled-prs",
+	"only-merged-prs",
+	"only-ready-for-review-prs",
+	"only-branch-delete",
+	"only-build-pushes-to-non-draft-prs",
+	"only-merged-or-closed-prs",
+	"pr-comment-equals-run-ci",
+	"non-draft-pr-opened",
+	"pushes-to-merge-queues",
+}
+
+type TriggerWebhook struct {
+	URL    string `json:"url,omitempty" yaml:"url,omitempty"`
+	Sender string `json:"sender,omitempty" yaml:"sender,omitempty"`
+}
+
+type TriggerSchedule struct {
+	CronExpression   string
+	AttributionActor uuid.UUID
+}
+
+type EventSource struct {
+	Provider string
+	Repo     Repo
+	Webhook  TriggerWebhook
+	Schedule TriggerSchedule
+}
+
+type trigger struct {
+	ID          uuid.UUID
+	Definition  *pipelineDefinition
+	EventSource EventSource
+	EventName   string
+	EventPreset string
+	CheckoutRef string
+	ConfigRef   string
+	Disabled    bool
+	Parameters  map[string]any
+	CreatedAt   time.Time
+}
+
+func (t *trigger) toTrigger() Trigger {
+	return Trigger{
+		ID:                   t.ID,
+		ProjectID:            t.Definition.Project.ID,
+		PipelineDefinitionID: t.Definition.ID,
+		EventSource:          t.EventSource,
+		EventName:            t.EventName,
+		EventPreset:          t.EventPreset,
+		CheckoutRef:          t.CheckoutRef,
+		ConfigRef:            t.ConfigRef,
+		Disabled:             t.Disabled,
+		Parameters:           t.Parameters,
+		CreatedAt:            t.CreatedAt,
+	}
+}
+
+// NewTrigger is a trigger to add. Schedule.AttributionActor is ignored in
+// favour of AttributionActor, which takes the same values as the API.
+type NewTrigger struct {
+	// ID is optional; a random ID is used if it is unset.
+	ID                   uuid.UUID
+	ProjectID            uuid.UUID
+	PipelineDefinitionID uuid.UUID
+	EventSource          EventSource
+	AttributionActor     string
+	EventName            string
+	EventPreset          string
+	CheckoutRef          string
+	ConfigRef            string
+	Disabled             bool
+	Parameters           map[string]any
+}
+
+type Trigger struct {
+	ID                   uuid.UUID
+	ProjectID            uuid.UUID
+	PipelineDefinitionID uuid.UUID
+	EventSource          EventSource
+	EventName            string
+	EventPreset          string
+	CheckoutRef          string
+	ConfigRef            string
+	Disabled             bool
+	Parameters           map[string]any
+	CreatedAt            time.Time
+}
+
+func resolveActor(actor string) (uuid.UUID, error) {
+	switch actor {
+	case ActorSystem:
+		return SystemActorID, nil
+	case ActorCurrent:
+		return CurrentActorID, nil
+	default:
+		return uuid.Nil, validationError("event_source.schedule.attribution_actor must be one of system, current")
+	}
+}
+
+// validateTrigger checks the fields that depend on the event source provider.
+func validateTrigger(t *trigger) error {
+	switch t.EventSource.Provider {
+	case ProviderGitHubApp, ProviderGitHubServer:
+		switch {
+		case t.EventSource.Repo.ExternalID == "":
+			return validationError("event_source.repo.external_id is required")
+		case !slices.Contains(eventPresets, t.EventPreset):
+			return validationError("event_preset is invalid")
+		case t.EventName != "":
+			return validationError("event_name is not supported for " + t.EventSource.Provider + " triggers")
+		}
+	case ProviderWebhook:
+		switch {
+		case t.EventName == "":
+			return validationError("event_name is required")
+		case t.EventSource.Webhook.Sender == "":
+			return validationError("event_source.webhook.sender is required")
+		}
+	case ProviderSchedule:
+		switch {
+		case t.EventName == "":
+			return validationError("event_name is required")
+		case t.EventSource.Schedule.CronExpression == "":
+			return validationError("event_source.schedule.cron_expression is required")
+		case t.CheckoutRef == "":
+			return validationError("checkout_ref is required")
+		case t.ConfigRef == "":
+			return validationError("config_ref is required")
+		}
+	default:
+		return validationError("event_source.provider must be one of github_app, github_server, webhook, schedule")
+	}
+
+	if t.EventSource.Provider != ProviderSchedule && len(t.Parameters) > 0 {
+		return validationError("parameters are only supported for schedule triggers")
+	}
+	return nil
+}
+
+func (s *Service) AddTrigger(nt NewTrigger) (Trigger, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pd, err := s.pipelineDefinitionLocked(nt.ProjectID, nt.PipelineDefinitionID)
+	if err != nil {
+		return Trigger{}, err
+	}
+
+	id := nt.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	} else if _, ok := s.triggers[id]; ok {
+		return Trigger{}, errDuplicate
+	}
+
+	t := &trigger{
+		ID:          id,
+		Definition:  pd,
+		EventSource: nt.EventSource,
+		EventName:   nt.EventName,
+		EventPreset: nt.EventPreset,
+		CheckoutRef: nt.CheckoutRef,
+		ConfigRef:   nt.ConfigRef,
+		Disabled:    nt.Disabled,
+		Parameters:  nt.Parameters,
+		CreatedAt:   time.Now(),
+	}
+
+	switch t.EventSource.Provider {
+	case ProviderSchedule:
+		t.EventSource.Schedule.AttributionActor, err = resolveActor(nt.AttributionActor)
+		if err != nil {
+			return Trigger{}, err
+		}
+	case ProviderWebhook:
+		if t.EventSource.Webhook.URL == "" {
+			t.EventSource.Webhook.URL = "https://internal.circleci.com/private/soc/e/" + t.ID.String()
+		}
+	}
+
+	if err := validateTrigger(t); err != nil {
+		return Trigger{}, err
+	}
+
+	pd.Triggers = append(pd.Triggers, t)
+	s.triggers[t.ID] = t
+	return t.toTrigger(), nil
+}
+
+// triggerLocked requires s.mu to be held. It only finds triggers that belong
+// to projectID, as the real API scopes them by project.
+func (s *Service) triggerLocked(projectID, id uuid.UUID) (*trigger, error) {
+	if _, ok := s.projects[projectID]; !ok {
+		return nil, errNotFound
+	}
+
+	t, ok := s.triggers[id]
+	if !ok || t.Definition.Project.ID != projectID {
+		return nil, errNotFound
+	}
+	return t, nil
+}
+
+func (s *Service) trigger(projectID, id uuid.UUID) (Trigger, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, err := s.triggerLocked(projectID, id)
+	if err != nil {
+		return Trigger{}, err
+	}
+	return t.toTrigger(), nil
+}
+
+func (s *Service) triggersFor(projectID, pipelineDefinitionID uuid.UUID) ([]Trigger, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pd, err := s.pipelineDefinitionLocked(projectID, pipelineDefinitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Trigger, 0, len(pd.Triggers))
+	for _, t := range pd.Triggers {
+		res = append(res, t.toTrigger())
+	}
+	return res, nil
+}
+
+// triggerUpdate is the body of a PATCH. Nil fields are left unchanged.
+type triggerUpdate struct {
+	EventName   *string        `json:"event_name"`
+	EventPreset *string        `json:"event_preset"`
+	CheckoutRef *string        `json:"checkout_ref"`
+	ConfigRef   *string        `json:"config_ref"`
+	Disabled    *bool          `json:"disabled"`
+	Parameters  map[string]any `json:"parameters"`
+	EventSource *struct {
+		Webhook *struct {
+			Sender *string `json:"sender"`
+		} `json:"webhook"`
+		Schedule *struct {
+			CronExpression   *string `json:"cron_expression"`
+			AttributionActor *string `json:"attribution_actor"`
+		} `json:"schedule"`
+	} `json:"event_source"`
+}
+
+func (s *Service) updateTrigger(projectID, id uuid.UUID, u triggerUpdate) (Trigger, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.triggerLocked(projectID, id)
+	if err != nil {
+		return Trigger{}, err
+	}
+
+	updated := *t
+	if u.EventName != nil {
+		updated.EventName = *u.EventName
+	}
+	if u.EventPreset != nil {
+		updated.EventPreset = *u.EventPreset
+	}
+	if u.CheckoutRef != nil {
+		updated.CheckoutRef = *u.CheckoutRef
+	}
+	if u.ConfigRef != nil {
+		updated.ConfigRef = *u.ConfigRef
+	}
+	if u.Disabled != nil {
+		updated.Disabled = *u.Disabled
+	}
+	if u.Parameters != nil {
+		updated.Parameters = u.Parameters
+	}
+	if es := u.EventSource; es != nil {
+		if es.Webhook != nil && es.Webhook.Sender != nil {
+			updated.EventSource.Webhook.Sender = *es.Webhook.Sender
+		}
+		if es.Schedule != nil && es.Schedule.CronExpression != nil {
+			updated.EventSource.Schedule.CronExpression = *es.Schedule.CronExpression
+		}
+		if es.Schedule != nil && es.Schedule.AttributionActor != nil {
+			updated.EventSource.Schedule.AttributionActor, err = resolveActor(*es.Schedule.AttributionActor)
+			if err != nil {
+				return Trigger{}, err
+			}
+		}
+	}
+
+	if err := validateTrigger(&updated); err != nil {
+		return Trigger{}, err
+	}
+
+	*t = updated
+	return t.toTrigger(), nil
+}
+
+func (s *Service) deleteTrigger(projectID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.triggerLocked(projectID, id)
+	if err != nil {
+		return err
+	}
+
+	t.Definition.Triggers = slices.DeleteFunc(t.Definition.Triggers, func(other *trigger) bool {
+		return other.ID == t.ID
+	})
+	delete(s.triggers, t.ID)
+	return nil
+}
+
+// Handlers below here
+
+type triggerResponse struct {
+	ID          uuid.UUID `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	CheckoutRef string    `json:"checkout_ref,omitempty"`
+	ConfigRef   string    `json:"config_ref,omitempty"`
+	EventSource struct {
+		Provider string          `json:"provider"`
+		Repo     *Repo           `json:"repo,omitempty"`
+		Webhook  *TriggerWebhook `json:"webhook,omitempty"`
+		Schedule *struct {
+			CronExpression   string `json:"cron_expression"`
+			AttributionActor struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"attribution_actor"`
+		} `json:"schedule,omitempty"`
+	} `json:"event_source"`
+	EventName   string         `json:"event_name,omitempty"`
+	EventPreset string         `json:"event_preset,omitempty"`
+	Disabled    bool           `json:"disabled"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+func newTriggerResponse(t Trigger) triggerResponse {
+	res := triggerResponse{
+		ID:          t.ID,
+		CreatedAt:   t.CreatedAt,
+		CheckoutRef: t.CheckoutRef,
+		ConfigRef:   t.ConfigRef,
+		EventName:   t.EventName,
+		EventPreset: t.EventPreset,
+		Disabled:    t.Disabled,
+		Parameters:  t.Parameters,
+	}
+
+	es := t.EventSource
+	res.EventSource.Provider = es.Provider
+	switch es.Provider {
+	case ProviderGitHubApp, ProviderGitHubServer:
+		res.EventSource.Repo = &es.Repo
+	case ProviderWebhook:
+		res.EventSource.Webhook = &es.Webhook
+	case ProviderSchedule:
+		res.EventSource.Schedule = &struct {
+			CronExpression   string `json:"cron_expression"`
+			AttributionActor struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"attribution_actor"`
+		}{CronExpression: es.Schedule.CronExpression}
+		res.EventSource.Schedule.AttributionActor.ID = es.Schedule.AttributionActor
+	}
+	return res
+}
+
+func (s *Service) getTriggers(w http.ResponseWriter, r *http.Request) {
+	projectID, pipelineDefinitionID, ok := pipelineDefinitionParams(w, r)
+	if !ok {
+		return
+	}
+
+	ts, err := s.triggersFor(projectID, pipelineDefinitionID)
+	if err != nil {
+		writeError(w, r, err, "pipeline definition not found")
+		return
+	}
+
+	res := make([]triggerResponse, 0, len(ts))
+	for _, t := range ts {
+		res = append(res, newTriggerResponse(t))
+	}
+	respondPage(w, r, res, int(s.pageSize.Load()))
+}
+
+func (s *Service) postTrigger(w http.ResponseWriter, r *http.Request) {
+	projectID, pipelineDefinitionID, ok := pipelineDefinitionParams(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		EventSource struct {
+			Provider string         `json:"provider"`
+			Repo     Repo           `json:"repo"`
+			Webhook  TriggerWebhook `json:"webhook"`
+			Schedule struct {
+				CronExpression   string `json:"cron_expression"`
+				AttributionActor string `json:"attribution_actor"`
+			} `json:"schedule"`
+		} `json:"event_source"`
+		EventName   string         `json:"event_name"`
+		EventPreset string         `json:"event_preset"`
+		CheckoutRef string         `json:"checkout_ref"`
+		ConfigRef   string         `json:"config_ref"`
+		Disabled    bool           `json:"disabled"`
+		Parameters  map[string]any `json:"parameters"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	t, err := s.AddTrigger(NewTrigger{
+		ProjectID:            projectID,
+		PipelineDefinitionID: pipelineDefinitionID,
+		EventSource: EventSource{
+			Provider: body.EventSource.Provider,
+			Repo:     body.EventSource.Repo,
+			Webhook:  body.EventSource.Webhook,
+			Schedule: TriggerSchedule{
+				CronExpression: body.EventSource.Schedule.CronExpression,
+			},
+		},
+		AttributionActor: body.EventSource.Schedule.AttributionActor,
+		EventName:        body.EventName,
+		EventPreset:      body.EventPreset,
+		CheckoutRef:      body.CheckoutRef,
+		ConfigRef:        body.ConfigRef,
+		Disabled:         body.Disabled,
+		Parameters:       body.Parameters,
+	})
+	if err != nil {
+		writeError(w, r, err, "pipeline definition not found")
+		return
+	}
+
+	respond(w, r, http.StatusCreated, newTriggerResponse(t))
+}
+
+// triggerParams reads the project-id and trigger-id path segments.
+func triggerParams(w http.ResponseWriter, r *http.Request) (projectID, id uuid.UUID, ok bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "project-id"))
+	if badRequest(w, r, "bad project ID", err) {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err = uuid.Parse(chi.URLParam(r, "trigger-id"))
+	if badRequest(w, r, "bad trigger ID", err) {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, id, true
+}
+
+func (s *Service) getTrigger(w http.ResponseWriter, r *http.Request) {
+	projectID, id, ok := triggerParams(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := s.trigger(projectID, id)
+	if err != nil {
+		writeError(w, r, err, "trigger not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, newTriggerResponse(t))
+}
+
+func (s *Service) patchTrigger(w http.ResponseWriter, r *http.Request) {
+	projectID, id, ok := triggerParams(w, r)
+	if !ok {
+		return
+	}
+
+	var body triggerUpdate
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	t, err := s.updateTrigger(projectID, id, body)
+	if err != nil {
+		writeError(w, r, err, "trigger not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, newTriggerResponse(t))
+}
+
+func (s *Service) deleteTriggerHandler(w http.ResponseWriter, r *http.Request) {
+	projectID, id, ok := triggerParams(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.deleteTrigger(projectID, id)
+	if err != nil {
+		writeError(w, r, err, "trigger not found")
+		return
+	}
+
+	msg(w, r, http.StatusOK, "ok")
+}
@@ -0,0 +1,379 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package fakecircle
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// Webhook events a webhook can subscribe to.
+const (
+	EventWorkflowCompleted = "workflow-completed"
+	EventJobCompleted      = "job-completed"
+)
+
+type webhook struct {
+	ID            uuid.UUID
+	Project       *project
+	Name          string
+	URL           string
+	VerifyTLS     bool
+	SigningSecret string
+	Events        []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (wh *webhook) toWebhook() Webhook {
+	return Webhook{
+		ID:            wh.ID,
+		ProjectID:     wh.Project.ID,
+		Name:          wh.Name,
+		URL:           wh.URL,
+		VerifyTLS:     wh.VerifyTLS,
+		SigningSecret: wh.SigningSecret,
+		Events:        slices.Clone(wh.Events),
+		CreatedAt:     wh.CreatedAt,
+		UpdatedAt:     wh.UpdatedAt,
+	}
+}
+
+type NewWebhook struct {
+	// ID is optional; a random ID is used if it is unset.
+	ID            uuid.UUID
+	ProjectID     uuid.UUID
+	Name          string
+	URL           string
+	VerifyTLS     bool
+	SigningSecret string
+	Events        []string
+}
+
+type Webhook struct {
+	ID            uuid.UUID
+	ProjectID     uuid.UUID
+	Name          string
+	URL           string
+	VerifyTLS     bool
+	SigningSecret string
+	Events        []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func validateWebhook(wh *webhook) error {
+	if wh.Name == "" {
+		return validationError("name is required")
+	}
+
+	u, err := url.Parse(wh.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return validationError("url must be a valid https URL")
+	}
+
+	if wh.SigningSecret == "" {
+		return validationError("signing-secret is required")
+	}
+
+	if len(wh.Events) == 0 {
+		return validationError("events must not be empty")
+	}
+	for _, e := range wh.Events {
+		if e != EventWorkflowCompleted && e != EventJobCompleted {
+			return validationError("events must be one of workflow-completed, job-completed")
+		}
+	}
+	return nil
+}
+
+func (s *Service) AddWebhook(nw NewWebhook) (Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[nw.ProjectID]
+	if !ok {
+		return Webhook{}, errNotFound
+	}
+
+	id := nw.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	} else if _, ok := s.webhooks[id]; ok {
+		return Webhook{}, errDuplicate
+	}
+
+	now := time.Now()
+	wh := &webhook{
+		ID:            id,
+		Project:       p,
+		Name:          nw.Name,
+		URL:           nw.URL,
+		VerifyTLS:     nw.VerifyTLS,
+		SigningSecret: nw.SigningSecret,
+		Events:        slices.Clone(nw.Events),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := validateWebhook(wh); err != nil {
+		return Webhook{}, err
+	}
+
+	p.Webhooks = append(p.Webhooks, wh)
+	s.webhooks[wh.ID] = wh
+	return wh.toWebhook(), nil
+}
+
+func (s *Service) webhook(id uuid.UUID) (Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wh, ok := s.webhooks[id]
+	if !ok {
+		return Webhook{}, errNotFound
+	}
+	return wh.toWebhook(), nil
+}
+
+func (s *Service) projectWebhooks(projectID uuid.UUID) ([]Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	res := make([]Webhook, 0, len(p.Webhooks))
+	for _, wh := range p.Webhooks {
+		res = append(res, wh.toWebhook())
+	}
+	return res, nil
+}
+
+// webhookUpdate is the body of a PUT. Despite the method, the API treats it
+// as a partial update: nil fields are left unchanged.
+type webhookUpdate struct {
+	Name          *string  `json:"name"`
+	URL           *string  `json:"url"`
+	VerifyTLS     *bool    `json:"verify-tls"`
+	SigningSecret *string  `json:"signing-secret"`
+	Events        []string `json:"events"`
+}
+
+func (s *Service) updateWebhook(id uuid.UUID, u webhookUpdate) (Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wh, ok := s.webhooks[id]
+	if !ok {
+		return Webhook{}, errNotFound
+	}
+
+	updated := *wh
+	if u.Name != nil {
+		updated.Name = *u.Name
+	}
+	if u.URL != nil {
+		updated.URL = *u.URL
+	}
+	if u.VerifyTLS != nil {
+		updated.VerifyTLS = *u.VerifyTLS
+	}
+	if u.SigningSecret != nil {
+		updated.SigningSecret = *u.SigningSecret
+	}
+	if u.Events != nil {
+		updated.Events = slices.Clone(u.Events)
+	}
+	if err := validateWebhook(&updated); err != nil {
+		return Webhook{}, err
+	}
+
+	updated.UpdatedAt = time.Now()
+	*wh = updated
+	return wh.toWebhook(), nil
+}
+
+func (s *Service) deleteWebhook(id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wh, ok := s.webhooks[id]
+	if !ok {
+		return errNotFound
+	}
+
+	s.deleteWebhookLocked(wh)
+	return nil
+}
+
+// deleteWebhookLocked requires s.mu to be held for writing.
+func (s *Service) deleteWebhookLocked(wh *webhook) {
+	wh.Project.Webhooks = slices.DeleteFunc(wh.Project.Webhooks, func(other *webhook) bool {
+		return other.ID == wh.ID
+	})
+	delete(s.webhooks, wh.ID)
+}
+
+// Handlers below here
+
+type webhookScope struct {
+	ID   uuid.UUID `json:"id"`
+	Type string    `json:"type"`
+}
+
+type webhookResponse struct {
+	ID            uuid.UUID    `json:"id"`
+	Name          string       `json:"name"`
+	URL           string       `json:"url"`
+	VerifyTLS     bool         `json:"verify-tls"`
+	SigningSecret string       `json:"signing-secret"`
+	Events        []string     `json:"events"`
+	Scope         webhookScope `json:"scope"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+func newWebhookResponse(wh Webhook) webhookResponse {
+	return webhookResponse{
+		ID:   wh.ID,
+		Name: wh.Name,
+		URL:  wh.URL,
+		// The real API masks the secret after creation.
+		SigningSecret: "****" + wh.SigningSecret[max(0, len(wh.SigningSecret)-4):],
+		VerifyTLS:     wh.VerifyTLS,
+		Events:        wh.Events,
+		Scope: webhookScope{
+			ID:   wh.ProjectID,
+			Type: "project",
+		},
+		CreatedAt: wh.CreatedAt,
+		UpdatedAt: wh.UpdatedAt,
+	}
+}
+
+func (s *Service) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	if scopeType := r.URL.Query().Get("scope-type"); scopeType != "project" {
+		msg(w, r, http.StatusBadRequest, "scope-type must be project")
+		return
+	}
+
+	projectID, err := uuid.Parse(r.URL.Query().Get("scope-id"))
+	if badRequest(w, r, "bad scope-id", err) {
+		return
+	}
+
+	whs, err := s.projectWebhooks(projectID)
+	if err != nil {
+		writeError(w, r, err, "project not found")
+		return
+	}
+
+	res := make([]webhookResponse, 0, len(whs))
+	for _, wh := range whs {
+		res = append(res, newWebhookResponse(wh))
+	}
+	respondPage(w, r, res, int(s.pageSize.Load()))
+}
+
+func (s *Service) postWebhook(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name          string   `json:"name"`
+		URL           string   `json:"url"`
+		VerifyTLS     bool     `json:"verify-tls"`
+		SigningSecret string   `json:"signing-secret"`
+		Events        []string `json:"events"`
+		Scope         struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"scope"`
+	}
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	if body.Scope.Type != "project" {
+		msg(w, r, http.StatusBadRequest, "scope.type must be project")
+		return
+	}
+
+	projectID, err := uuid.Parse(body.Scope.ID)
+	if badRequest(w, r, "bad scope.id", err) {
+		return
+	}
+
+	wh, err := s.AddWebhook(NewWebhook{
+		ProjectID:     projectID,
+		Name:          body.Name,
+		URL:           body.URL,
+		VerifyTLS:     body.VerifyTLS,
+		SigningSecret: body.SigningSecret,
+		Events:        body.Events,
+	})
+	if err != nil {
+		writeError(w, r, err, "project not found")
+		return
+	}
+
+	res := newWebhookResponse(wh)
+	res.SigningSecret = wh.SigningSecret
+	respond(w, r, http.StatusCreated, res)
+}
+
+func (s *Service) getWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "webhook-id"))
+	if badRequest(w, r, "bad webhook ID", err) {
+		return
+	}
+
+	wh, err := s.webhook(id)
+	if err != nil {
+		writeError(w, r, err, "webhook not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, newWebhookResponse(wh))
+}
+
+func (s *Service) putWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "webhook-id"))
+	if badRequest(w, r, "bad webhook ID", err) {
+		return
+	}
+
+	var body webhookUpdate
+	if badRequest(w, r, "bad request", render.DecodeJSON(r.Body, &body)) {
+		return
+	}
+
+	wh, err := s.updateWebhook(id, body)
+	if err != nil {
+		writeError(w, r, err, "webhook not found")
+		return
+	}
+
+	respond(w, r, http.StatusOK, newWebhookResponse(wh))
+}
+
+func (s *Service) deleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "webhook-id"))
+	if badRequest(w, r, "bad webhook ID", err) {
+		return
+	}
+
+	err = s.deleteWebhook(id)
+	if err != nil {
+		writeError(w, r, err, "webhook not found")
+		return
+	}
+
+	msg(w, r, http.StatusOK, "ok")
+}
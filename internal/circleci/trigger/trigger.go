@@ -40,10 +40,6 @@ type TriggerItems struct {
 	Items []Trigger `json:"items"`
 }
 
-type TriggerResponseItems struct {
-	Items []TriggerResponse `json:"items"`
-}
-
 type TriggerService struct {
 	client *client.Client
 }
@@ -63,13 +59,7 @@ func (s *TriggerService) Get(ctx context.Context, projectID, triggerID string) (
 }
 
 func (s *TriggerService) List(ctx context.Context, projectID, pipelineID string) (_ []TriggerResponse, err error) {
-	var triggerResponseItems TriggerResponseItems
-	_, err = s.client.RequestHelper(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/pipeline-definitions/%s/triggers", projectID, pipelineID), nil, &triggerResponseItems)
-	if err != nil {
-		return nil, err
-	}
-
-	return triggerResponseItems.Items, nil
+	return client.Collect(client.Paginate[TriggerResponse](ctx, s.client, fmt.Sprintf("/projects/%s/pipeline-definitions/%s/triggers", projectID, pipelineID)))
 }
 
 func (s *TriggerService) Create(ctx context.Context, newTrigger Trigger, projectID, pipelineID string) (_ *TriggerResponse, err error) {
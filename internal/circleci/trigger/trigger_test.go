@@ -0,0 +1,270 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package trigger_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/common"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+	"terraform-provider-circleci/internal/circleci/trigger"
+)
+
+const testTok = "d3a1f9e2-7b6c-4c8d-9e0f-1a2b3c4d5e6f"
+
+func setup(t *testing.T) (*fakecircle.Service, *trigger.TriggerService, fakecircle.PipelineDefinition) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+	repo := fakecircle.Repo{FullName: "test-org/test-repo", ExternalID: "123456"}
+	pd, err := fc.AddPipelineDefinition(fakecircle.NewPipelineDefinition{
+		ProjectID: prj.ID,
+		Name:      "test pipeline",
+		ConfigSource: fakecircle.ConfigSource{
+			Provider: fakecircle.ProviderGitHubApp,
+			Repo:     repo,
+			FilePath: ".circleci/config.yml",
+		},
+		CheckoutSource: fakecircle.CheckoutSource{
+			Provider: fakecircle.ProviderGitHubApp,
+			Repo:     repo,
+		},
+	})
+	assert.Assert(t, err)
+
+	return fc, trigger.NewTriggerService(c), pd
+}
+
+func TestTriggerService(t *testing.T) {
+	ctx := context.TODO()
+	fc, ts, pd := setup(t)
+	projectID, pipelineID := pd.ProjectID.String(), pd.ID.String()
+
+	var created *trigger.TriggerResponse
+	t.Run("create", func(t *testing.T) {
+		var err error
+		created, err = ts.Create(ctx, trigger.Trigger{
+			EventSource: common.EventSource{
+				Provider: "github_app",
+				Repo:     common.Repo{ExternalId: "123456"},
+			},
+			EventPreset: "all-pushes",
+			Disabled:    common.Bool(false),
+		}, projectID, pipelineID)
+		assert.Assert(t, err)
+		assert.Check(t, created.ID != "")
+		assert.Check(t, cmp.Equal(created.EventSource.Provider, "github_app"))
+		assert.Check(t, cmp.Equal(created.EventSource.Repo.ExternalId, "123456"))
+		assert.Check(t, cmp.Equal(created.EventPreset, "all-pushes"))
+		assert.Check(t, cmp.DeepEqual(created.Disabled, common.Bool(false)))
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ts.Get(ctx, projectID, created.ID)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(got, created))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		fc.ResetCalls()
+		got, err := ts.Update(ctx, trigger.Trigger{
+			EventPreset: "only-tags",
+			Disabled:    common.Bool(true),
+		}, projectID, created.ID)
+		assert.Assert(t, err)
+		fc.AssertCallCount(t, "PATCH", "/projects/*/triggers/*", 1)
+		fc.AssertNotCalled(t, "DELETE", "")
+		fc.AssertNotCalled(t, "POST", "")
+
+		calls := fc.CallsMatching("PATCH", "/projects/*/triggers/*")
+		assert.Assert(t, cmp.Len(calls, 1))
+		assert.Check(t, calls[0].Authenticated)
+		assert.Check(t, cmp.DeepEqual(calls[0].Body, map[string]any{
+			"event_preset": "only-tags",
+			"disabled":     true,
+		}))
+		assert.Check(t, cmp.Equal(got.EventPreset, "only-tags"))
+		assert.Check(t, cmp.DeepEqual(got.Disabled, common.Bool(true)))
+		assert.Check(t, cmp.Equal(got.EventSource.Repo.ExternalId, "123456"))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := ts.List(ctx, projectID, pipelineID)
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(got, 1))
+		assert.Check(t, cmp.Equal(got[0].ID, created.ID))
+	})
+
+	t.Run("list_pages", func(t *testing.T) {
+		fc.SetPageSize(1)
+		t.Cleanup(func() { fc.SetPageSize(20) })
+		other, err := ts.Create(ctx, trigger.Trigger{
+			EventSource: common.EventSource{
+				Provider: "github_app",
+				Repo:     common.Repo{ExternalId: "123456"},
+			},
+			EventPreset: "only-tags",
+		}, projectID, pipelineID)
+		assert.Assert(t, err)
+		t.Cleanup(func() { _ = ts.Delete(ctx, projectID, other.ID) })
+
+		got, err := ts.List(ctx, projectID, pipelineID)
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(got, 2))
+		assert.Check(t, cmp.Equal(got[1].ID, other.ID))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := ts.Delete(ctx, projectID, created.ID)
+		assert.Assert(t, err)
+
+		_, err = ts.Get(ctx, projectID, created.ID)
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
+
+func TestTriggerService_Schedule(t *testing.T) {
+	ctx := context.TODO()
+	_, ts, pd := setup(t)
+
+	got, err := ts.Create(ctx, trigger.Trigger{
+		EventSource: common.EventSource{
+			Provider: "schedule",
+			Schedule: common.Schedule{
+				CronExpression:   "0 9 * * 1",
+				AttributionActor: "system",
+			},
+		},
+		EventName:   "nightly",
+		CheckoutRef: "main",
+		ConfigRef:   "main",
+		Parameters:  map[string]any{"deploy": true},
+	}, pd.ProjectID.String(), pd.ID.String())
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Equal(got.EventSource.Schedule.CronExpression, "0 9 * * 1"))
+	assert.Check(t, cmp.Equal(got.EventSource.Schedule.AttributionActor.Id, fakecircle.SystemActorID.String()))
+	assert.Check(t, cmp.DeepEqual(got.Parameters, map[string]any{"deploy": true}))
+}
+
+func TestTriggerService_Webhook(t *testing.T) {
+	ctx := context.TODO()
+	_, ts, pd := setup(t)
+
+	got, err := ts.Create(ctx, trigger.Trigger{
+		EventSource: common.EventSource{
+			Provider: "webhook",
+			Webhook:  common.Webhook{Sender: "sentry"},
+		},
+		EventName: "alert",
+	}, pd.ProjectID.String(), pd.ID.String())
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Equal(got.EventSource.Webhook.Sender, "sentry"))
+	assert.Check(t, got.EventSource.Webhook.Url != "")
+}
+
+func TestTriggerService_Errors(t *testing.T) {
+	ctx := context.TODO()
+	_, ts, pd := setup(t)
+	projectID, pipelineID := pd.ProjectID.String(), pd.ID.String()
+
+	tests := []struct {
+		name    string
+		trigger trigger.Trigger
+		wantErr string
+	}{
+		{
+			name: "bad_provider",
+			trigger: trigger.Trigger{
+				EventSource: common.EventSource{Provider: "gitlab"},
+			},
+			wantErr: "event_source.provider",
+		},
+		{
+			name: "bad_event_preset",
+			trigger: trigger.Trigger{
+				EventSource: common.EventSource{
+					Provider: "github_app",
+					Repo:     common.Repo{ExternalId: "123456"},
+				},
+				EventPreset: "every-push",
+			},
+			wantErr: "event_preset is invalid",
+		},
+		{
+			name: "schedule_bad_actor",
+			trigger: trigger.Trigger{
+				EventSource: common.EventSource{
+					Provider: "schedule",
+					Schedule: common.Schedule{
+						CronExpression:   "0 9 * * 1",
+						AttributionActor: "someone",
+					},
+				},
+				EventName:   "nightly",
+				CheckoutRef: "main",
+				ConfigRef:   "main",
+			},
+			wantErr: "attribution_actor",
+		},
+		{
+			name: "parameters_on_github",
+			trigger: trigger.Trigger{
+				EventSource: common.EventSource{
+					Provider: "github_app",
+					Repo:     common.Repo{ExternalId: "123456"},
+				},
+				EventPreset: "all-pushes",
+				Parameters:  map[string]any{"a": "b"},
+			},
+			wantErr: "parameters are only supported for schedule triggers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ts.Create(ctx, tt.trigger, projectID, pipelineID)
+			assert.Check(t, client.HasStatus(err, 400))
+			assert.Check(t, cmp.ErrorContains(err, tt.wantErr))
+		})
+	}
+
+	t.Run("unknown_pipeline", func(t *testing.T) {
+		_, err := ts.List(ctx, projectID, "5e2a3c1f-0000-4000-8000-000000000000")
+		assert.Check(t, client.IsNotFound(err))
+	})
+
+	t.Run("wrong_project", func(t *testing.T) {
+		created, err := ts.Create(ctx, trigger.Trigger{
+			EventSource: common.EventSource{
+				Provider: "github_app",
+				Repo:     common.Repo{ExternalId: "123456"},
+			},
+			EventPreset: "all-pushes",
+		}, projectID, pipelineID)
+		assert.Assert(t, err)
+
+		_, err = ts.Get(ctx, "5e2a3c1f-0000-4000-8000-000000000000", created.ID)
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
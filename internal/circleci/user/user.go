@@ -0,0 +1,55 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+// User is the user an API token belongs to.
+type User struct {
+	Id    string `json:"id,omitempty"`
+	Login string `json:"login,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// Collaboration is an organization the user is a member of.
+type Collaboration struct {
+	Id        string `json:"id,omitempty"`
+	VcsType   string `json:"vcs-type,omitempty"`
+	Name      string `json:"name,omitempty"`
+	AvatarUrl string `json:"avatar_url,omitempty"`
+	Slug      string `json:"slug,omitempty"`
+}
+
+type UserService struct {
+	client *client.Client
+}
+
+func NewUserService(c *client.Client) *UserService {
+	return &UserService{client: c}
+}
+
+// Me returns the user the client's token belongs to.
+func (s *UserService) Me(ctx context.Context) (_ *User, err error) {
+	var u User
+	_, err = s.client.RequestHelper(ctx, http.MethodGet, "/me", nil, &u)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// Collaborations returns the organizations the user is a member of.
+func (s *UserService) Collaborations(ctx context.Context) (_ []Collaboration, err error) {
+	var collaborations []Collaboration
+	_, err = s.client.RequestHelper(ctx, http.MethodGet, "/me/collaborations", nil, &collaborations)
+	if err != nil {
+		return nil, err
+	}
+	return collaborations, nil
+}
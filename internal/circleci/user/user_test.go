@@ -0,0 +1,72 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package user_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+	"terraform-provider-circleci/internal/circleci/user"
+)
+
+const testTok = "8e1f2a3b-4c5d-4e6f-8a9b-0c1d2e3f4a5b"
+
+func setup(t *testing.T) (*fakecircle.Service, *user.UserService) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+	return fc, user.NewUserService(c)
+}
+
+func TestUserService_Me(t *testing.T) {
+	ctx := context.TODO()
+	fc, us := setup(t)
+
+	got, err := us.Me(ctx)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.DeepEqual(got, &user.User{
+		Id:    fakecircle.DefaultUser.ID.String(),
+		Login: fakecircle.DefaultUser.Login,
+		Name:  fakecircle.DefaultUser.Name,
+	}))
+
+	fc.SetUser(fakecircle.User{
+		ID:    fakecircle.SystemActorID,
+		Login: "someone-else",
+	})
+	got, err = us.Me(ctx)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Equal(got.Login, "someone-else"))
+}
+
+func TestUserService_Collaborations(t *testing.T) {
+	ctx := context.TODO()
+	fc, us := setup(t)
+
+	got, err := us.Collaborations(ctx)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.Len(got, 0))
+
+	b, err := fc.AddOrg(fakecircle.NewOrg{Type: fakecircle.TypeGitHub, Name: "b-org"})
+	assert.Assert(t, err)
+	a, err := fc.AddOrg(fakecircle.NewOrg{Type: fakecircle.TypeCircleCI, Name: "a-org"})
+	assert.Assert(t, err)
+
+	got, err = us.Collaborations(ctx)
+	assert.Assert(t, err)
+	assert.Check(t, cmp.DeepEqual(got, []user.Collaboration{
+		{Id: a.ID.String(), VcsType: a.Type, Name: a.Name, Slug: a.Slug},
+		{Id: b.ID.String(), VcsType: b.Type, Name: b.Name, Slug: b.Slug},
+	}))
+}
@@ -42,26 +42,7 @@ func (s *WebhookService) Get(ctx context.Context, webhookID string) (_ *Webhook,
 }
 
 func (s *WebhookService) List(ctx context.Context, scopeID string) (_ []Webhook, err error) {
-	var nextPageToken string
-	var webhookList []Webhook
-	for {
-		var response common.PaginatedResponse[Webhook]
-		_, err = s.client.RequestHelper(ctx, http.MethodGet,
-			fmt.Sprintf("/webhook?scope-id=%s&scope-type=project&page-token=%s", scopeID, nextPageToken),
-			nil,
-			&response,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		webhookList = append(webhookList, response.Items...)
-		if response.NextPageToken == "" {
-			break
-		}
-		nextPageToken = response.NextPageToken
-	}
-	return webhookList, nil
+	return client.Collect(client.Paginate[Webhook](ctx, s.client, fmt.Sprintf("/webhook?scope-id=%s&scope-type=project", scopeID)))
 }
 
 func (s *WebhookService) Create(ctx context.Context, newWebhook Webhook) (_ *Webhook, err error) {
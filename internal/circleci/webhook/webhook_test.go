@@ -0,0 +1,256 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/common"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+	"terraform-provider-circleci/internal/circleci/webhook"
+)
+
+const testTok = "4c2e8b1a-93d7-4f6e-b0a5-7d8c9e1f2a3b"
+
+func setup(t *testing.T) (*fakecircle.Service, *webhook.WebhookService, fakecircle.Project) {
+	t.Helper()
+
+	fc := fakecircle.New(testTok)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL+"/api/v2", testTok, "terraform-provider-circleci/test")
+
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "test org",
+	})
+	assert.Assert(t, err)
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "test project",
+	})
+	assert.Assert(t, err)
+
+	return fc, webhook.NewWebhookService(c), prj
+}
+
+func newWebhook(projectID, name string) webhook.Webhook {
+	return webhook.Webhook{
+		Name:          name,
+		Url:           "https://example.com/hook",
+		VerifyTls:     common.Bool(true),
+		SigningSecret: "super-secret",
+		Scope: common.Scope{
+			Id:   projectID,
+			Type: "project",
+		},
+		Events: []string{"workflow-completed"},
+	}
+}
+
+func TestWebhookService(t *testing.T) {
+	ctx := context.TODO()
+	_, ws, prj := setup(t)
+
+	var created *webhook.Webhook
+	t.Run("create", func(t *testing.T) {
+		var err error
+		created, err = ws.Create(ctx, newWebhook(prj.ID.String(), "test webhook"))
+		assert.Assert(t, err)
+		want := newWebhook(prj.ID.String(), "test webhook")
+		assert.Check(t, cmp.DeepEqual(created, &want, cmpopts.IgnoreFields(webhook.Webhook{}, "Id", "CreatedAt", "UpdatedAt")))
+		assert.Check(t, created.Id != "")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ws.Get(ctx, created.Id)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(got.Name, "test webhook"))
+		assert.Check(t, cmp.Equal(got.SigningSecret, "****cret"))
+		assert.Check(t, cmp.DeepEqual(got.Scope, created.Scope))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		got, err := ws.Update(ctx, webhook.Webhook{
+			Name:   "renamed",
+			Events: []string{"workflow-completed", "job-completed"},
+		}, created.Id)
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Equal(got.Name, "renamed"))
+		assert.Check(t, cmp.DeepEqual(got.Events, []string{"workflow-completed", "job-completed"}))
+		assert.Check(t, cmp.Equal(got.Url, "https://example.com/hook"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := ws.Delete(ctx, created.Id)
+		assert.Assert(t, err)
+
+		_, err = ws.Get(ctx, created.Id)
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
+
+func TestWebhookService_List(t *testing.T) {
+	ctx := context.TODO()
+	fc, ws, prj := setup(t)
+	fc.SetPageSize(2)
+
+	for i := range 5 {
+		_, err := ws.Create(ctx, newWebhook(prj.ID.String(), fmt.Sprintf("webhook %d", i)))
+		assert.Assert(t, err)
+	}
+
+	t.Run("all_pages", func(t *testing.T) {
+		got, err := ws.List(ctx, prj.ID.String())
+		assert.Assert(t, err)
+		assert.Assert(t, cmp.Len(got, 5))
+		for i, wh := range got {
+			assert.Check(t, cmp.Equal(wh.Name, fmt.Sprintf("webhook %d", i)))
+		}
+	})
+
+	t.Run("other_project", func(t *testing.T) {
+		other, err := fc.AddProject(fakecircle.NewProject{
+			OrgID: prj.Org.ID,
+			Name:  "other project",
+		})
+		assert.Assert(t, err)
+
+		got, err := ws.List(ctx, other.ID.String())
+		assert.Assert(t, err)
+		assert.Check(t, cmp.Len(got, 0))
+	})
+}
+
+// TestWebhookService_ListFaults checks that a failure part-way through the
+// pages is retried without skipping or repeating any webhooks.
+func TestWebhookService_ListFaults(t *testing.T) {
+	ctx := context.TODO()
+	fc, ws, prj := setup(t)
+	fc.SetPageSize(2)
+
+	var want []string
+	for i := range 5 {
+		name := fmt.Sprintf("webhook %d", i)
+		_, err := ws.Create(ctx, newWebhook(prj.ID.String(), name))
+		assert.Assert(t, err)
+		want = append(want, name)
+	}
+
+	names := func(whs []webhook.Webhook) []string {
+		var res []string
+		for _, wh := range whs {
+			res = append(res, wh.Name)
+		}
+		return res
+	}
+
+	t.Run("second_page_503", func(t *testing.T) {
+		t.Cleanup(fc.ClearFaults)
+		fc.InjectFault(fakecircle.Fault{
+			Method: http.MethodGet,
+			Path:   "/webhook",
+			After:  1,
+			Times:  2,
+			Status: http.StatusServiceUnavailable,
+			Header: http.Header{"Retry-After": {"0"}},
+		})
+
+		got, err := ws.List(ctx, prj.ID.String())
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(names(got), want))
+		assert.Check(t, cmp.Equal(fc.PendingFaults(), 0))
+	})
+
+	t.Run("last_page_dropped", func(t *testing.T) {
+		t.Cleanup(fc.ClearFaults)
+		fc.InjectFault(fakecircle.Fault{
+			Method:         http.MethodGet,
+			Path:           "/webhook",
+			After:          2,
+			Times:          1,
+			DropConnection: true,
+		})
+
+		got, err := ws.List(ctx, prj.ID.String())
+		assert.Assert(t, err)
+		assert.Check(t, cmp.DeepEqual(names(got), want))
+		assert.Check(t, cmp.Equal(fc.PendingFaults(), 0))
+	})
+
+	t.Run("cancelled_mid_list", func(t *testing.T) {
+		t.Cleanup(fc.ClearFaults)
+		fc.InjectFault(fakecircle.Fault{
+			Method:  http.MethodGet,
+			Path:    "/webhook",
+			After:   1,
+			Latency: time.Minute,
+		})
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		got, err := ws.List(ctx, prj.ID.String())
+		assert.Check(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Check(t, cmp.Nil(got))
+	})
+}
+
+func TestWebhookService_Errors(t *testing.T) {
+	ctx := context.TODO()
+	_, ws, prj := setup(t)
+
+	tests := []struct {
+		name    string
+		modify  func(wh *webhook.Webhook)
+		wantErr string
+	}{
+		{
+			name:    "missing_name",
+			modify:  func(wh *webhook.Webhook) { wh.Name = "" },
+			wantErr: "name is required",
+		},
+		{
+			name:    "http_url",
+			modify:  func(wh *webhook.Webhook) { wh.Url = "http://example.com/hook" },
+			wantErr: "url must be a valid https URL",
+		},
+		{
+			name:    "bad_event",
+			modify:  func(wh *webhook.Webhook) { wh.Events = []string{"pipeline-started"} },
+			wantErr: "events must be one of",
+		},
+		{
+			name:    "bad_scope_type",
+			modify:  func(wh *webhook.Webhook) { wh.Scope.Type = "organization" },
+			wantErr: "scope.type must be project",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wh := newWebhook(prj.ID.String(), "test webhook")
+			tt.modify(&wh)
+			_, err := ws.Create(ctx, wh)
+			assert.Check(t, client.HasStatus(err, 400))
+			assert.Check(t, cmp.ErrorContains(err, tt.wantErr))
+		})
+	}
+
+	t.Run("unknown_project", func(t *testing.T) {
+		_, err := ws.Create(ctx, newWebhook("5e2a3c1f-0000-4000-8000-000000000000", "test webhook"))
+		assert.Check(t, client.IsNotFound(err))
+	})
+}
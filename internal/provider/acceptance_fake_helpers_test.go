@@ -0,0 +1,128 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+// testAccFakeToken is the only Circle-Token the fake accepts.
+const testAccFakeToken = "CCIPAT_fake-acceptance-token"
+
+// testAccFake is a fakecircle server for acceptance tests that do not need a
+// CircleCI account. Tests seed it through the embedded Service, point the
+// provider at it with ProviderConfig, and use Client to make out-of-band
+// changes between steps.
+type testAccFake struct {
+	*fakecircle.Service
+
+	URL    string
+	Client *client.Client
+}
+
+// testAccFakeCircle starts a fakecircle server that lives for the rest of t.
+func testAccFakeCircle(t *testing.T) *testAccFake {
+	t.Helper()
+
+	fc := fakecircle.New(testAccFakeToken)
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	return &testAccFake{
+		Service: fc,
+		URL:     srv.URL,
+		Client:  client.NewClient(srv.URL+"/api/v2", testAccFakeToken, "terraform-provider-circleci/test"),
+	}
+}
+
+// ProviderConfig returns a provider block that sends both the v2 and the
+// runner API to the fake.
+func (f *testAccFake) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+  key         = %[2]q
+}
+`, f.URL, testAccFakeToken)
+}
+
+// testAccCheckResourceDisappears deletes the named resource behind Terraform's
+// back, so that the next refresh has to cope with it being gone. del receives
+// the resource's attributes from state.
+//
+// A step using it must set ExpectNonEmptyPlan, since the post-apply plan will
+// want to recreate the resource.
+func testAccCheckResourceDisappears(name string, del func(ctx context.Context, attrs map[string]string) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		if err := del(context.Background(), rs.Primary.Attributes); err != nil {
+			return fmt.Errorf("deleting %s out-of-band: %w", name, err)
+		}
+		return nil
+	}
+}
+
+// CheckCallCount returns a check that exactly want requests to the fake
+// matched method and pattern since its journal was last reset. Steps that use
+// it usually reset the journal in PreConfig.
+func (f *testAccFake) CheckCallCount(method, pattern string, want int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if got := f.CallCount(method, pattern); got != want {
+			return fmt.Errorf("expected %d %s %s requests, got %d", want, method, pattern, got)
+		}
+		return nil
+	}
+}
+
+// CheckLastBody checks the JSON body of the latest method request matching
+// pattern has want as its key field.
+func (f *testAccFake) CheckLastBody(method, pattern, key string, want any) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		calls := f.CallsMatching(method, pattern)
+		if len(calls) == 0 {
+			return fmt.Errorf("expected a %s %s request, got none", method, pattern)
+		}
+		body, _ := calls[len(calls)-1].Body.(map[string]any)
+		if got := body[key]; got != want {
+			return fmt.Errorf("expected the last %s %s request to have %s %v, got %v", method, pattern, key, want, got)
+		}
+		return nil
+	}
+}
+
+// AddOrgProject adds an org and a project, both named name, failing the test
+// if it cannot.
+func (f *testAccFake) AddOrgProject(t *testing.T, name string) fakecircle.Project {
+	t.Helper()
+
+	org, err := f.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := f.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prj
+}
@@ -0,0 +1,259 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/checkout"
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &checkoutKeyResource{}
+	_ resource.ResourceWithConfigure   = &checkoutKeyResource{}
+	_ resource.ResourceWithImportState = &checkoutKeyResource{}
+)
+
+// checkoutKeyResourceModel maps the resource schema.
+type checkoutKeyResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	ProjectSlug types.String `tfsdk:"project_slug"`
+	Type        types.String `tfsdk:"type"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	PublicKey   types.String `tfsdk:"public_key"`
+	Preferred   types.Bool   `tfsdk:"preferred"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+// setKey maps a checkout key onto the model.
+func (m *checkoutKeyResourceModel) setKey(key *checkout.Key) {
+	m.Id = types.StringValue(key.Fingerprint)
+	m.Type = types.StringValue(key.Type)
+	m.Fingerprint = types.StringValue(key.Fingerprint)
+	m.PublicKey = types.StringValue(key.PublicKey)
+	m.Preferred = types.BoolValue(key.Preferred)
+	if !key.CreatedAt.IsZero() {
+		m.CreatedAt = types.StringValue(formatTimestamp(key.CreatedAt))
+	} else {
+		m.CreatedAt = types.StringValue("")
+	}
+}
+
+// NewCheckoutKeyResource is a helper function to simplify the provider implementation.
+func NewCheckoutKeyResource() resource.Resource {
+	return &checkoutKeyResource{}
+}
+
+// checkoutKeyResource is the resource implementation.
+type checkoutKeyResource struct {
+	client *checkout.Service
+}
+
+// Metadata returns the resource type name.
+func (r *checkoutKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_checkout_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *checkoutKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a CircleCI project checkout key, the SSH key CircleCI uses to check out the project's code. CircleCI generates the keypair and keeps the private key; only the public key is returned.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The fingerprint of the checkout key.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "The project slug in the format `vcs-type/org-name/repo-name`. Changing this value forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of checkout key: `deploy-key`, which is added to the repository and can only read it, or `user-key`, which is added to the user the API token belongs to and has their access to every repository. Changing this value forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(checkout.TypeDeployKey, checkout.TypeUserKey),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The MD5 fingerprint of the public key.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The public key, in OpenSSH format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"preferred": schema.BoolAttribute{
+				MarkdownDescription: "Whether CircleCI checks out the project with this key. Only one of a project's keys is preferred.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the checkout key was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *checkoutKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_checkout_key", "Create")
+	defer end(&resp.Diagnostics)
+
+	// Retrieve values from plan
+	var plan checkoutKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.Create(ctx, plan.ProjectSlug.ValueString(), plan.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating CircleCI checkout key",
+			"Could not create CircleCI checkout key, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.setKey(key)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *checkoutKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_checkout_key", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state checkoutKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.Get(ctx, state.ProjectSlug.ValueString(), state.Id.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading CircleCI Checkout Key",
+			"Could not read checkout key "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.setKey(key)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called with changes, as changing any argument replaces
+// the checkout key.
+func (r *checkoutKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *checkoutKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_checkout_key", "Delete")
+	defer end(&resp.Diagnostics)
+
+	// Retrieve values from state
+	var state checkoutKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Delete(ctx, state.ProjectSlug.ValueString(), state.Id.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting CircleCI Checkout Key",
+			"Could not delete checkout key, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *checkoutKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.CheckoutKeyService
+}
+
+// ImportState imports an existing resource into Terraform state.
+// Expected import ID format: "project_slug/fingerprint".
+// e.g. "circleci/org_id/project_id/a1:b2:...".
+func (r *checkoutKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The project slug contains slashes (e.g. "circleci/org/project"),
+	// so split from the right to extract the fingerprint.
+	lastSlash := strings.LastIndex(req.ID, "/")
+	if lastSlash == -1 || lastSlash == 0 || lastSlash == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			fmt.Sprintf("Expected import ID format: 'project_slug/fingerprint' (e.g. 'circleci/org_id/project_id/a1:b2:c3:...'). Got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), req.ID[:lastSlash])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID[lastSlash+1:])...)
+}
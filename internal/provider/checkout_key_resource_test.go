@@ -0,0 +1,134 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/checkout"
+)
+
+func TestAccCheckoutKeyResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "checkout-key")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fc.ProviderConfig() + testAccCheckoutKeyResourceConfig(prj.Slug, "deploy-key"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_checkout_key.test",
+						tfjsonpath.New("type"),
+						knownvalue.StringExact("deploy-key"),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_checkout_key.test",
+						tfjsonpath.New("public_key"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^ssh-ed25519 `)),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_checkout_key.test",
+						tfjsonpath.New("fingerprint"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^([0-9a-f]{2}:){15}[0-9a-f]{2}$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_checkout_key.test",
+						tfjsonpath.New("preferred"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_checkout_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["circleci_checkout_key.test"]
+					return rs.Primary.Attributes["project_slug"] + "/" + rs.Primary.Attributes["fingerprint"], nil
+				},
+			},
+			// Changing the type replaces the key
+			{
+				Config: fc.ProviderConfig() + testAccCheckoutKeyResourceConfig(prj.Slug, "user-key"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_checkout_key.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_checkout_key.test",
+						tfjsonpath.New("type"),
+						knownvalue.StringExact("user-key"),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccCheckoutKeyResource_invalidType(t *testing.T) {
+	fc := testAccFakeCircle(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fc.ProviderConfig() + testAccCheckoutKeyResourceConfig("circleci/org/project", "github-app"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
+
+func TestAccCheckoutKeyResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "disappears")
+	config := fc.ProviderConfig() + testAccCheckoutKeyResourceConfig(prj.Slug, "deploy-key")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_checkout_key.test", func(ctx context.Context, attrs map[string]string) error {
+					return checkout.NewService(fc.Client).Delete(ctx, attrs["project_slug"], attrs["fingerprint"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_checkout_key.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckoutKeyResourceConfig(projectSlug, keyType string) string {
+	return fmt.Sprintf(`
+resource "circleci_checkout_key" "test" {
+  project_slug = %[1]q
+  type         = %[2]q
+}
+`, projectSlug, keyType)
+}
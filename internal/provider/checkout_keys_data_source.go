@@ -0,0 +1,149 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/checkout"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &checkoutKeysDataSource{}
+	_ datasource.DataSourceWithConfigure = &checkoutKeysDataSource{}
+)
+
+// checkoutKeysDataSourceModel maps the data source schema.
+type checkoutKeysDataSourceModel struct {
+	ProjectSlug types.String                 `tfsdk:"project_slug"`
+	Keys        []checkoutKeyDataSourceModel `tfsdk:"keys"`
+}
+
+type checkoutKeyDataSourceModel struct {
+	Type        types.String `tfsdk:"type"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	PublicKey   types.String `tfsdk:"public_key"`
+	Preferred   types.Bool   `tfsdk:"preferred"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+// NewCheckoutKeysDataSource is a helper function to simplify the provider implementation.
+func NewCheckoutKeysDataSource() datasource.DataSource {
+	return &checkoutKeysDataSource{}
+}
+
+// checkoutKeysDataSource is the data source implementation.
+type checkoutKeysDataSource struct {
+	client *checkout.Service
+}
+
+// Metadata returns the data source type name.
+func (d *checkoutKeysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_checkout_keys"
+}
+
+// Schema defines the schema for the data source.
+func (d *checkoutKeysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the checkout keys of a CircleCI project.",
+		Attributes: map[string]schema.Attribute{
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "The project slug in the format `vcs-type/org-name/repo-name`.",
+				Required:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The project's checkout keys.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of checkout key, `deploy-key` or `user-key`.",
+							Computed:            true,
+						},
+						"fingerprint": schema.StringAttribute{
+							MarkdownDescription: "The MD5 fingerprint of the public key.",
+							Computed:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "The public key, in OpenSSH format.",
+							Computed:            true,
+						},
+						"preferred": schema.BoolAttribute{
+							MarkdownDescription: "Whether CircleCI checks out the project with this key.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the checkout key was created.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read fetches the project's checkout keys from the API.
+func (d *checkoutKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_checkout_keys", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state checkoutKeysDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := d.client.List(ctx, state.ProjectSlug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading CircleCI Checkout Keys",
+			"Could not list checkout keys of project "+state.ProjectSlug.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Keys = make([]checkoutKeyDataSourceModel, 0, len(keys))
+	for _, key := range keys {
+		k := checkoutKeyDataSourceModel{
+			Type:        types.StringValue(key.Type),
+			Fingerprint: types.StringValue(key.Fingerprint),
+			PublicKey:   types.StringValue(key.PublicKey),
+			Preferred:   types.BoolValue(key.Preferred),
+			CreatedAt:   types.StringValue(""),
+		}
+		if !key.CreatedAt.IsZero() {
+			k.CreatedAt = types.StringValue(formatTimestamp(key.CreatedAt))
+		}
+		state.Keys = append(state.Keys, k)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *checkoutKeysDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client.CheckoutKeyService
+}
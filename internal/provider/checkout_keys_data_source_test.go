@@ -0,0 +1,62 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccCheckoutKeysDataSource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "checkout-keys")
+	deploy, err := fc.AddCheckoutKey(prj.ID, fakecircle.CheckoutKeyTypeDeploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := fc.AddCheckoutKey(prj.ID, fakecircle.CheckoutKeyTypeUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fc.ProviderConfig() + fmt.Sprintf(`
+data "circleci_checkout_keys" "test" {
+  project_slug = %[1]q
+}
+`, prj.Slug),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.circleci_checkout_keys.test",
+						tfjsonpath.New("keys"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"type":        knownvalue.StringExact("deploy-key"),
+								"fingerprint": knownvalue.StringExact(deploy.Fingerprint),
+								"public_key":  knownvalue.StringExact(deploy.PublicKey),
+								"preferred":   knownvalue.Bool(false),
+							}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"type":        knownvalue.StringExact("user-key"),
+								"fingerprint": knownvalue.StringExact(user.Fingerprint),
+								"public_key":  knownvalue.StringExact(user.PublicKey),
+								"preferred":   knownvalue.Bool(true),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
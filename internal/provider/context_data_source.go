@@ -102,6 +102,9 @@ func (d *ContextDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_context", "Read")
+	defer end(&resp.Diagnostics)
+
 	var contextState contextDataSourceModel
 	diags := req.Config.Get(ctx, &contextState)
 	if diags != nil {
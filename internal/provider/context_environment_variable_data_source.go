@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/envcontext"
 )
 
@@ -26,6 +27,8 @@ type contextEnvironmentVariableDataSourceModel struct {
 	UpdatedAt types.String `tfsdk:"updated_at"`
 	CreatedAt types.String `tfsdk:"created_at"`
 	ContextId types.String `tfsdk:"context_id"`
+
+	OrganizationId types.String `tfsdk:"organization_id"`
 }
 
 // NewContextEnvironmentVariableDataSource is a helper function to simplify the provider implementation.
@@ -64,12 +67,19 @@ func (d *ContextEnvironmentVariableDataSource) Schema(_ context.Context, _ datas
 				MarkdownDescription: "The timestamp when the environment variable was created.",
 				Computed:            true,
 			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID or slug of the organization that owns the context. Requests for the context then use the organization's `credentials` block, if the provider has one, in place of `key`.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (d *ContextEnvironmentVariableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_context_environment_variable", "Read")
+	defer end(&resp.Diagnostics)
+
 	var contextEnvironmentVariableState contextEnvironmentVariableDataSourceModel
 	diags := req.Config.Get(ctx, &contextEnvironmentVariableState)
 	if diags != nil {
@@ -93,23 +103,24 @@ func (d *ContextEnvironmentVariableDataSource) Read(ctx context.Context, req dat
 		return
 	}
 
-	contextEnvironmentVariables, err := d.client.List(ctx, contextEnvironmentVariableState.ContextId.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read CircleCI context environment variable with context id "+contextEnvironmentVariableState.ContextId.ValueString(),
-			err.Error(),
-		)
-		return
-	}
-
 	// Fill restrictions
-	for _, elem := range contextEnvironmentVariables {
+	ctx = client.WithOrganization(ctx, contextEnvironmentVariableState.OrganizationId.ValueString())
+	for elem, err := range d.client.All(ctx, contextEnvironmentVariableState.ContextId.ValueString()) {
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read CircleCI context environment variable with context id "+contextEnvironmentVariableState.ContextId.ValueString(),
+				err.Error(),
+			)
+			return
+		}
 		if elem.Variable == contextEnvironmentVariableState.Name.ValueString() {
 			contextEnvironmentVariableState = contextEnvironmentVariableDataSourceModel{
 				Name:      types.StringValue(elem.Variable),
 				UpdatedAt: types.StringValue(elem.UpdatedAt.Format("2006-01-02T15:04:05.000Z")),
 				CreatedAt: types.StringValue(elem.CreatedAt.Format("2006-01-02T15:04:05.000Z")),
 				ContextId: types.StringValue(elem.ContextId),
+
+				OrganizationId: contextEnvironmentVariableState.OrganizationId,
 			}
 			break
 		}
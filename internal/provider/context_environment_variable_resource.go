@@ -5,33 +5,91 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/envcontext"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &contextEnvironmentVariableResource{}
-	_ resource.ResourceWithConfigure   = &contextEnvironmentVariableResource{}
-	_ resource.ResourceWithImportState = &contextEnvironmentVariableResource{}
+	_ resource.Resource                     = &contextEnvironmentVariableResource{}
+	_ resource.ResourceWithConfigure        = &contextEnvironmentVariableResource{}
+	_ resource.ResourceWithConfigValidators = &contextEnvironmentVariableResource{}
+	_ resource.ResourceWithImportState      = &contextEnvironmentVariableResource{}
+	_ resource.ResourceWithModifyPlan       = &contextEnvironmentVariableResource{}
 )
 
+// contextEnvironmentVariablePrivateKey is the private state key under which
+// Create, Update and the first Read record the variable's updated_at.
+const contextEnvironmentVariablePrivateKey = "written"
+
+// contextEnvironmentVariableWritten is what Create and Update record, so
+// that Read can tell when the value was changed by someone else. CircleCI
+// never returns values, so only updated_at can be compared.
+type contextEnvironmentVariableWritten struct {
+	// UpdatedAt is the variable's updated_at after the write, or when it was
+	// first read if Terraform has not written it.
+	UpdatedAt time.Time `json:"updated_at"`
+	// Drifted is set by Read when updated_at has since moved forward.
+	Drifted bool `json:"drifted,omitempty"`
+}
+
+// privateState is the private state of a resource request or response.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// readContextEnvironmentVariableWritten returns what was recorded in
+// private, or nil if nothing was yet, as right after an import.
+func readContextEnvironmentVariableWritten(ctx context.Context, private privateState) (*contextEnvironmentVariableWritten, diag.Diagnostics) {
+	b, diags := private.GetKey(ctx, contextEnvironmentVariablePrivateKey)
+	if diags.HasError() || len(b) == 0 {
+		return nil, diags
+	}
+
+	var written contextEnvironmentVariableWritten
+	if err := json.Unmarshal(b, &written); err != nil {
+		diags.AddError("Error Reading CircleCI Context Environment Variable Private State", err.Error())
+		return nil, diags
+	}
+	return &written, diags
+}
+
+// marshalContextEnvironmentVariableWritten returns the record of a write
+// that left the variable with updatedAt, for the private state.
+func marshalContextEnvironmentVariableWritten(updatedAt time.Time) []byte {
+	// Marshalling a struct of a time and a bool cannot fail.
+	b, _ := json.Marshal(contextEnvironmentVariableWritten{UpdatedAt: updatedAt})
+	return b
+}
+
 // contextEnvironmentVariableResourceModel maps the output schema.
 type contextEnvironmentVariableResourceModel struct {
-	Name      types.String `tfsdk:"name"`
-	Value     types.String `tfsdk:"value"`
-	UpdatedAt types.String `tfsdk:"updated_at"`
-	CreatedAt types.String `tfsdk:"created_at"`
-	ContextId types.String `tfsdk:"context_id"`
+	Name                  types.String `tfsdk:"name"`
+	Value                 types.String `tfsdk:"value"`
+	ValueWo               types.String `tfsdk:"value_wo"`
+	ValueWoVersion        types.Int64  `tfsdk:"value_wo_version"`
+	DetectExternalChanges types.Bool   `tfsdk:"detect_external_changes"`
+	UpdatedAt             types.String `tfsdk:"updated_at"`
+	CreatedAt             types.String `tfsdk:"created_at"`
+	ContextId             types.String `tfsdk:"context_id"`
+	OrganizationId        types.String `tfsdk:"organization_id"`
 }
 
 // NewContextEnvironmentVariableResource is a helper function to simplify the provider implementation.
@@ -62,9 +120,32 @@ func (r *contextEnvironmentVariableResource) Schema(_ context.Context, _ resourc
 				},
 			},
 			"value": schema.StringAttribute{
-				MarkdownDescription: "The value of the environment variable.",
-				Required:            true,
+				MarkdownDescription: "The value of the environment variable. It is stored in state; use `value_wo` to keep it out. Exactly one of `value` or `value_wo` must be set.",
+				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				MarkdownDescription: "The value of the environment variable, which is never stored in state or plan files. Requires Terraform 1.11 or later. Terraform cannot tell when it changes, so change `value_wo_version` to write a new value.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("value")),
+				},
+			},
+			"value_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version for `value_wo`. Changing it writes the current `value_wo` to CircleCI.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("value_wo")),
+				},
+			},
+			"detect_external_changes": schema.BoolAttribute{
+				MarkdownDescription: "Whether to write the value again when it has been changed outside of Terraform, such as in the CircleCI UI. CircleCI does not return values, so a change is detected by `updated_at` moving on since Terraform last wrote the value, or since it was imported; values are not compared. Defaults to `false`.",
+				Optional:            true,
 			},
 			"updated_at": schema.StringAttribute{
 				MarkdownDescription: "The timestamp when the environment variable was last updated.",
@@ -81,12 +162,26 @@ func (r *contextEnvironmentVariableResource) Schema(_ context.Context, _ resourc
 				MarkdownDescription: "The timestamp when the environment variable was created.",
 				Computed:            true,
 			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID or slug of the organization that owns the context. Requests for the context then use the organization's `credentials` block, if the provider has one, in place of `key`.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
+// ConfigValidators returns the validators that check the resource's configuration as a whole.
+func (r *contextEnvironmentVariableResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(path.MatchRoot("value"), path.MatchRoot("value_wo")),
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *contextEnvironmentVariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variable", "Create")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan contextEnvironmentVariableResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -95,8 +190,15 @@ func (r *contextEnvironmentVariableResource) Create(ctx context.Context, req res
 		return
 	}
 
+	value, diags := writeOnlyValue(ctx, req.Config, plan.Value, path.Root("value_wo"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create new context
-	newContextEnvironmentVariable, err := r.client.Create(ctx, plan.ContextId.ValueString(), plan.Value.ValueString(), plan.Name.ValueString())
+	ctx = client.WithOrganization(ctx, plan.OrganizationId.ValueString())
+	newContextEnvironmentVariable, err := r.client.Create(ctx, plan.ContextId.ValueString(), value, plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating CircleCI context environment variable",
@@ -108,6 +210,7 @@ func (r *contextEnvironmentVariableResource) Create(ctx context.Context, req res
 	// Map response body to schema and populate Computed attribute values
 	plan.CreatedAt = types.StringValue(newContextEnvironmentVariable.CreatedAt.Format("2006-01-02T15:04:05.000Z"))
 	plan.UpdatedAt = types.StringValue(newContextEnvironmentVariable.UpdatedAt.Format("2006-01-02T15:04:05.000Z"))
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, contextEnvironmentVariablePrivateKey, marshalContextEnvironmentVariableWritten(newContextEnvironmentVariable.UpdatedAt))...)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -119,6 +222,9 @@ func (r *contextEnvironmentVariableResource) Create(ctx context.Context, req res
 
 // Read refreshes the Terraform state with the latest data.
 func (r *contextEnvironmentVariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variable", "Read")
+	defer end(&resp.Diagnostics)
+
 	var contextEnvironmentVariableState contextEnvironmentVariableResourceModel
 	diags := req.State.Get(ctx, &contextEnvironmentVariableState)
 	if diags != nil {
@@ -142,23 +248,28 @@ func (r *contextEnvironmentVariableResource) Read(ctx context.Context, req resou
 		return
 	}
 
-	contextEnvironmentVariables, err := r.client.List(ctx, contextEnvironmentVariableState.ContextId.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read CircleCI context environment variable with context id "+contextEnvironmentVariableState.ContextId.ValueString(),
-			err.Error(),
-		)
-		return
-	}
-
+	ctx = client.WithOrganization(ctx, contextEnvironmentVariableState.OrganizationId.ValueString())
 	var found bool
-	for _, elem := range contextEnvironmentVariables {
+	for elem, err := range r.client.All(ctx, contextEnvironmentVariableState.ContextId.ValueString()) {
+		if client.IsNotFound(err) {
+			// The whole context was deleted outside of Terraform.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read CircleCI context environment variable with context id "+contextEnvironmentVariableState.ContextId.ValueString(),
+				err.Error(),
+			)
+			return
+		}
 		if elem.Variable == contextEnvironmentVariableState.Name.ValueString() {
 			contextEnvironmentVariableState.Name = types.StringValue(elem.Variable)
 			contextEnvironmentVariableState.UpdatedAt = types.StringValue(elem.UpdatedAt.Format("2006-01-02T15:04:05.000Z"))
 			contextEnvironmentVariableState.CreatedAt = types.StringValue(elem.CreatedAt.Format("2006-01-02T15:04:05.000Z"))
 			contextEnvironmentVariableState.ContextId = types.StringValue(elem.ContextId)
 			found = true
+			r.checkExternalChange(ctx, req.Private, resp, elem.UpdatedAt, contextEnvironmentVariableState.DetectExternalChanges.ValueBool())
 			break
 		}
 	}
@@ -176,8 +287,72 @@ func (r *contextEnvironmentVariableResource) Read(ctx context.Context, req resou
 	}
 }
 
+// checkExternalChange marks the private state as drifted when detect is set
+// and updatedAt is later than it was after Terraform last wrote the value.
+// A variable Terraform has not written, as after an import or an upgrade
+// from a version without detection, has updatedAt recorded instead, so that
+// later changes are detected.
+func (r *contextEnvironmentVariableResource) checkExternalChange(ctx context.Context, private privateState, resp *resource.ReadResponse, updatedAt time.Time, detect bool) {
+	written, diags := readContextEnvironmentVariableWritten(ctx, private)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	if written == nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, contextEnvironmentVariablePrivateKey, marshalContextEnvironmentVariableWritten(updatedAt))...)
+		return
+	}
+	if !detect || written.Drifted || !updatedAt.After(written.UpdatedAt) {
+		return
+	}
+
+	written.Drifted = true
+	// Marshalling the struct read above cannot fail.
+	b, _ := json.Marshal(written)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, contextEnvironmentVariablePrivateKey, b)...)
+}
+
+// ModifyPlan plans an update when Read found the value was changed outside
+// of Terraform, so that the configured value is written again. Marking
+// updated_at unknown is enough for Terraform to plan the update, whether the
+// value is in value or value_wo.
+func (r *contextEnvironmentVariableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var detect types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("detect_external_changes"), &detect)...)
+	if resp.Diagnostics.HasError() || !detect.ValueBool() {
+		return
+	}
+
+	written, diags := readContextEnvironmentVariableWritten(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if written == nil || !written.Drifted {
+		return
+	}
+
+	var name, valueWo types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_wo"), &valueWo)...)
+	valuePath := path.Root("value")
+	if !valueWo.IsNull() {
+		valuePath = path.Root("value_wo")
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		valuePath,
+		"Context Environment Variable Changed Outside of Terraform",
+		fmt.Sprintf("%s was updated after Terraform last wrote it, so its configured value will be written again.", name.ValueString()),
+	)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("updated_at"), types.StringUnknown())...)
+}
+
 // Update calls the PUT upsert endpoint, which atomically overwrites the value in place.
 func (r *contextEnvironmentVariableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variable", "Update")
+	defer end(&resp.Diagnostics)
+
 	var plan contextEnvironmentVariableResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -185,7 +360,14 @@ func (r *contextEnvironmentVariableResource) Update(ctx context.Context, req res
 		return
 	}
 
-	updated, err := r.client.Create(ctx, plan.ContextId.ValueString(), plan.Value.ValueString(), plan.Name.ValueString())
+	value, diags := writeOnlyValue(ctx, req.Config, plan.Value, path.Root("value_wo"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = client.WithOrganization(ctx, plan.OrganizationId.ValueString())
+	updated, err := r.client.Create(ctx, plan.ContextId.ValueString(), value, plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating CircleCI context environment variable",
@@ -196,6 +378,7 @@ func (r *contextEnvironmentVariableResource) Update(ctx context.Context, req res
 
 	plan.CreatedAt = types.StringValue(updated.CreatedAt.Format("2006-01-02T15:04:05.000Z"))
 	plan.UpdatedAt = types.StringValue(updated.UpdatedAt.Format("2006-01-02T15:04:05.000Z"))
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, contextEnvironmentVariablePrivateKey, marshalContextEnvironmentVariableWritten(updated.UpdatedAt))...)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -203,6 +386,9 @@ func (r *contextEnvironmentVariableResource) Update(ctx context.Context, req res
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *contextEnvironmentVariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variable", "Delete")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state contextEnvironmentVariableResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -212,8 +398,9 @@ func (r *contextEnvironmentVariableResource) Delete(ctx context.Context, req res
 	}
 
 	// Delete existing order
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
 	err := r.client.Delete(ctx, state.ContextId.ValueString(), state.Name.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCi Context Environment Variable",
 			"Could not delete context, unexpected error: "+err.Error(),
@@ -259,6 +446,6 @@ func (r *contextEnvironmentVariableResource) ImportState(ctx context.Context, re
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
 	resp.Diagnostics.AddWarning(
 		"Context environment variable value cannot be read from API",
-		"CircleCI does not expose context environment variable values. Ensure the resource 'value' is defined in your Terraform configuration.",
+		"CircleCI does not expose context environment variable values. Ensure the resource 'value' or 'value_wo' is defined in your Terraform configuration.",
 	)
 }
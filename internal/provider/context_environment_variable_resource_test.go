@@ -4,16 +4,25 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	ccicontext "terraform-provider-circleci/internal/circleci/context"
+	"terraform-provider-circleci/internal/circleci/envcontext"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
 )
 
 func TestAccContextEnvironmentVariableResource(t *testing.T) {
@@ -123,3 +132,326 @@ resource "circleci_context_environment_variable" "test_env" {
 }
 `, name, value)
 }
+
+func TestAccContextEnvironmentVariableResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgCtx, err := fc.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context_environment_variable" "test_env" {
+  context_id = %[1]q
+  name       = "DISAPPEARS"
+  value      = "value"
+}
+`, orgCtx.ID)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_context_environment_variable.test_env", func(ctx context.Context, attrs map[string]string) error {
+					return envcontext.NewEnvService(fc.Client).Delete(ctx, attrs["context_id"], attrs["name"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_context_environment_variable.test_env", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// TestAccContextEnvironmentVariableResource_contextDisappears checks that the
+// variable is dropped from state, rather than failing the refresh, when its
+// whole context is deleted.
+func TestAccContextEnvironmentVariableResource_contextDisappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context" "test_context" {
+  name            = "disappears"
+  organization_id = %[1]q
+}
+
+resource "circleci_context_environment_variable" "test_env" {
+  context_id = circleci_context.test_context.id
+  name       = "DISAPPEARS"
+  value      = "value"
+}
+`, org.ID)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_context.test_context", func(ctx context.Context, attrs map[string]string) error {
+					return ccicontext.NewContextService(fc.Client).Delete(ctx, attrs["id"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_context.test_context", plancheck.ResourceActionCreate),
+						plancheck.ExpectResourceAction("circleci_context_environment_variable.test_env", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccContextEnvironmentVariableResource_writeOnly(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "write-only",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgCtx, err := fc.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "write-only",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(value string, version int) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context_environment_variable" "test_env" {
+  context_id       = %[1]q
+  name             = "WRITE_ONLY"
+  value_wo         = %[2]q
+  value_wo_version = %[3]d
+}
+`, orgCtx.ID, value, version)
+	}
+	const putPattern = "/context/*/environment-variable/*"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("first", 1),
+				Check:  fc.CheckLastBody(http.MethodPut, putPattern, "value", "first"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_context_environment_variable.test_env",
+						tfjsonpath.New("value"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_context_environment_variable.test_env",
+						tfjsonpath.New("value_wo"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			// Without a new version the new value is not written.
+			{
+				Config: config("second", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: config("second", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_context_environment_variable.test_env", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: fc.CheckLastBody(http.MethodPut, putPattern, "value", "second"),
+			},
+			{
+				Config: fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context_environment_variable" "test_env" {
+  context_id = %[1]q
+  name       = "WRITE_ONLY"
+  value      = "plain"
+  value_wo   = "secret"
+}
+`, orgCtx.ID),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccContextEnvironmentVariableResource_detectExternalChanges(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "drift",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgCtx, err := fc.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "drift",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(detect bool) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context_environment_variable" "test_env" {
+  context_id              = %[1]q
+  name                    = "DRIFT"
+  value                   = "configured"
+  detect_external_changes = %[2]t
+}
+`, orgCtx.ID, detect)
+	}
+	// rotate changes the value behind Terraform's back, as in the UI.
+	rotate := func(*terraform.State) error {
+		_, err := envcontext.NewEnvService(fc.Client).Create(context.Background(), orgCtx.ID.String(), "rotated", "DRIFT")
+		return err
+	}
+	const putPattern = "/context/*/environment-variable/*"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             config(true),
+				Check:              rotate,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_context_environment_variable.test_env", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: fc.CheckLastBody(http.MethodPut, putPattern, "value", "configured"),
+			},
+			// Without detect_external_changes a change goes unnoticed.
+			{
+				Config: config(false),
+				Check:  rotate,
+			},
+			{
+				Config: config(false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccContextEnvironmentVariableResource_detectExternalChangesImported(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "drift-imported",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgCtx, err := fc.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "drift-imported",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	envs := envcontext.NewEnvService(fc.Client)
+	if _, err := envs.Create(context.Background(), orgCtx.ID.String(), "existing", "DRIFT"); err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context_environment_variable" "test_env" {
+  context_id              = %[1]q
+  name                    = "DRIFT"
+  value_wo                = "configured"
+  detect_external_changes = true
+}
+`, orgCtx.ID)
+	const putPattern = "/context/*/environment-variable/*"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "circleci_context_environment_variable.test_env",
+				ImportState:        true,
+				ImportStateId:      orgCtx.ID.String() + "/DRIFT",
+				ImportStatePersist: true,
+			},
+			// The import recorded updated_at, so nothing is written until
+			// the variable changes.
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if n := len(fc.CallsMatching(http.MethodPut, putPattern)); n != 0 {
+						return fmt.Errorf("got %d PUT calls, want none", n)
+					}
+					_, err := envs.Create(context.Background(), orgCtx.ID.String(), "rotated", "DRIFT")
+					return err
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_context_environment_variable.test_env", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: fc.CheckLastBody(http.MethodPut, putPattern, "value", "configured"),
+			},
+		},
+	})
+}
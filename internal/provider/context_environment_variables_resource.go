@@ -0,0 +1,346 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/envcontext"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &contextEnvironmentVariablesResource{}
+	_ resource.ResourceWithConfigure   = &contextEnvironmentVariablesResource{}
+	_ resource.ResourceWithImportState = &contextEnvironmentVariablesResource{}
+)
+
+// contextEnvironmentVariablesResourceModel maps the resource schema.
+type contextEnvironmentVariablesResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	ContextId types.String `tfsdk:"context_id"`
+	Variables types.Map    `tfsdk:"variables"`
+	Exclusive types.Bool   `tfsdk:"exclusive"`
+
+	OrganizationId types.String `tfsdk:"organization_id"`
+}
+
+// NewContextEnvironmentVariablesResource is a helper function to simplify the provider implementation.
+func NewContextEnvironmentVariablesResource() resource.Resource {
+	return &contextEnvironmentVariablesResource{}
+}
+
+// contextEnvironmentVariablesResource is the resource implementation.
+type contextEnvironmentVariablesResource struct {
+	client *envcontext.EnvService
+}
+
+// Metadata returns the resource type name.
+func (r *contextEnvironmentVariablesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context_environment_variables"
+}
+
+// Schema defines the schema for the resource.
+func (r *contextEnvironmentVariablesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of environment variables stored in a CircleCI context.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the context.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"context_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the context that owns the environment variables. Changing this value forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "The environment variables, keyed by name.",
+				Required:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"exclusive": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the context's other environment variables, those not in `variables`. Defaults to `false`, which leaves them alone.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID or slug of the organization that owns the context. Requests for the context then use the organization's `credentials` block, if the provider has one, in place of `key`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *contextEnvironmentVariablesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variables", "Create")
+	defer end(&resp.Diagnostics)
+
+	var plan contextEnvironmentVariablesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vars, diags := r.apply(ctx, nil, plan)
+	resp.Diagnostics.Append(diags...)
+	if vars == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, vars)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *contextEnvironmentVariablesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variables", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state contextEnvironmentVariablesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
+	names, err := r.names(ctx, state.ContextId.ValueString())
+	if client.IsNotFound(err) {
+		// The context was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read CircleCI context environment variables with context id "+state.ContextId.ValueString(),
+			err.Error(),
+		)
+		return
+	}
+
+	var vars map[string]string
+	resp.Diagnostics.Append(state.Variables.ElementsAs(ctx, &vars, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// CircleCI does not return values, so the values in state are kept, and
+	// variables only CircleCI knows about are given an empty one. Those are
+	// only kept when exclusive, so that the plan deletes them. Otherwise they
+	// are not Terraform's, even right after an import, which is never
+	// exclusive: the first apply then writes just the configured variables.
+	adopt := state.Exclusive.ValueBool()
+	refreshed := make(map[string]string, len(names))
+	for _, name := range names {
+		if value, ok := vars[name]; ok {
+			refreshed[name] = value
+		} else if adopt {
+			refreshed[name] = ""
+		}
+	}
+
+	state.Id = state.ContextId
+	state.Variables, diags = types.MapValueFrom(ctx, types.StringType, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *contextEnvironmentVariablesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variables", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan, state contextEnvironmentVariablesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var current map[string]string
+	resp.Diagnostics.Append(state.Variables.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vars, diags := r.apply(ctx, current, plan)
+	resp.Diagnostics.Append(diags...)
+	if vars == nil {
+		// Nothing was written, so the state is unchanged.
+		resp.State.Raw = req.State.Raw
+		return
+	}
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, vars)...)
+}
+
+// apply writes the variables in plan, deletes those in current that are not,
+// and when exclusive deletes any others the context has. It returns the
+// variables as they now are, even if some of the requests failed, or nil if
+// none were made.
+func (r *contextEnvironmentVariablesResource) apply(ctx context.Context, current map[string]string, plan contextEnvironmentVariablesResourceModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	contextID := plan.ContextId.ValueString()
+	ctx = client.WithOrganization(ctx, plan.OrganizationId.ValueString())
+
+	var vars map[string]string
+	diags.Append(plan.Variables.ElementsAs(ctx, &vars, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var extra []string
+	if plan.Exclusive.ValueBool() {
+		var err error
+		extra, err = r.names(ctx, contextID)
+		if err != nil {
+			diags.AddError(
+				"Unable to Read CircleCI context environment variables with context id "+contextID,
+				err.Error(),
+			)
+			return nil, diags
+		}
+	}
+
+	applied, err := applyEnvironmentVariables(ctx, current, diffEnvironmentVariables(current, vars, extra),
+		func(ctx context.Context, name, value string) error {
+			_, err := r.client.Create(ctx, contextID, value, name)
+			return err
+		},
+		r.deleter(contextID),
+	)
+	if err != nil {
+		diags.AddError(
+			"Error Writing CircleCI Context Environment Variables",
+			"Could not write every environment variable of context "+contextID+": "+err.Error(),
+		)
+	}
+	return applied, diags
+}
+
+// setState sets state to plan with the variables in vars.
+func (r *contextEnvironmentVariablesResource) setState(ctx context.Context, state *tfsdk.State, plan contextEnvironmentVariablesResourceModel, vars map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	plan.Id = plan.ContextId
+	plan.Variables, diags = types.MapValueFrom(ctx, types.StringType, vars)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(state.Set(ctx, &plan)...)
+	return diags
+}
+
+// deleter returns a func that deletes a variable of the context, if it is
+// still there.
+func (r *contextEnvironmentVariablesResource) deleter(contextID string) func(context.Context, string) error {
+	return func(ctx context.Context, name string) error {
+		err := r.client.Delete(ctx, contextID, name)
+		if client.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *contextEnvironmentVariablesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_environment_variables", "Delete")
+	defer end(&resp.Diagnostics)
+
+	var state contextEnvironmentVariablesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var vars map[string]string
+	resp.Diagnostics.Append(state.Variables.ElementsAs(ctx, &vars, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contextID := state.ContextId.ValueString()
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
+	_, err := applyEnvironmentVariables(ctx, vars, diffEnvironmentVariables(vars, nil, nil), nil, r.deleter(contextID))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting CircleCI Context Environment Variables",
+			"Could not delete every environment variable of context "+contextID+": "+err.Error(),
+		)
+	}
+}
+
+// names returns the names of the context's environment variables.
+func (r *contextEnvironmentVariablesResource) names(ctx context.Context, contextID string) ([]string, error) {
+	var names []string
+	for elem, err := range r.client.All(ctx, contextID) {
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, elem.Variable)
+	}
+	return names, nil
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *contextEnvironmentVariablesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.EnvironmentVariableService
+}
+
+// ImportState imports a context's environment variables, by its ID.
+func (r *contextEnvironmentVariablesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			"Expected import ID format: 'context_id'. Got an empty ID.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("context_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exclusive"), false)...)
+	resp.Diagnostics.AddWarning(
+		"Context environment variable values cannot be read from API",
+		"CircleCI does not expose context environment variable values, so no variables are imported, and the first apply writes every value in your configuration. The context's other variables are left alone, unless exclusive is set, when the first apply deletes them.",
+	)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/envcontext"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccContextEnvironmentVariablesResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "bulk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgCtx, err := fc.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "bulk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fc.AddContextEnv(orgCtx.ID, fakecircle.NewEnvVarContext{Variable: "UNMANAGED", Value: "x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(exclusive bool, vars string) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context_environment_variables" "test" {
+  context_id = %[1]q
+  exclusive  = %[2]t
+  variables  = {
+%[3]s
+  }
+}
+`, orgCtx.ID, exclusive, vars)
+	}
+	// checkNames checks the context has exactly the named variables.
+	checkNames := func(want ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			vars, err := envcontext.NewEnvService(fc.Client).List(t.Context(), orgCtx.ID.String())
+			if err != nil {
+				return err
+			}
+			var got []string
+			for _, v := range vars {
+				got = append(got, v.Variable)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, want) {
+				return fmt.Errorf("context has the variables %v, want %v", got, want)
+			}
+			return nil
+		}
+	}
+	const putPattern = "/context/*/environment-variable/*"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: fc.ResetCalls,
+				Config: config(false, `
+    A = "1"
+    B = "2"
+    C = "3"`),
+				Check: resource.ComposeTestCheckFunc(
+					checkNames("A", "B", "C", "UNMANAGED"),
+					fc.CheckCallCount(http.MethodPut, putPattern, 3),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_context_environment_variables.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(orgCtx.ID.String()),
+					),
+				},
+			},
+			// Only what changed is written.
+			{
+				PreConfig: fc.ResetCalls,
+				Config: config(false, `
+    A = "1"
+    B = "two"
+    D = "4"`),
+				Check: resource.ComposeTestCheckFunc(
+					checkNames("A", "B", "D", "UNMANAGED"),
+					fc.CheckCallCount(http.MethodPut, putPattern, 2),
+					fc.CheckCallCount(http.MethodDelete, putPattern, 1),
+				),
+			},
+			// exclusive deletes the variables Terraform does not manage.
+			{
+				Config: config(true, `
+    A = "1"
+    B = "two"
+    D = "4"`),
+				Check: checkNames("A", "B", "D"),
+			},
+			{
+				PreConfig: func() {
+					if _, err := fc.AddContextEnv(orgCtx.ID, fakecircle.NewEnvVarContext{Variable: "LATER", Value: "y"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(true, `
+    A = "1"
+    B = "two"
+    D = "4"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_context_environment_variables.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkNames("A", "B", "D"),
+			},
+			{
+				ResourceName:            "circleci_context_environment_variables.test",
+				ImportState:             true,
+				ImportStateId:           orgCtx.ID.String(),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclusive", "variables"},
+			},
+		},
+		CheckDestroy: checkNames(),
+	})
+}
+
+func TestAccContextEnvironmentVariablesResource_importNonExclusive(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "bulk-import",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgCtx, err := fc.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "bulk-import",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"A", "UNMANAGED"} {
+		if _, err := fc.AddContextEnv(orgCtx.ID, fakecircle.NewEnvVarContext{Variable: name, Value: "x"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context_environment_variables" "test" {
+  context_id = %[1]q
+  variables  = {
+    A = "1"
+    B = "2"
+  }
+}
+`, orgCtx.ID)
+	const putPattern = "/context/*/environment-variable/*"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "circleci_context_environment_variables.test",
+				ImportState:        true,
+				ImportStateId:      orgCtx.ID.String(),
+				ImportStatePersist: true,
+			},
+			// The first apply writes the configured variables and leaves the
+			// others alone.
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					fc.CheckCallCount(http.MethodPut, putPattern, 2),
+					fc.CheckCallCount(http.MethodDelete, putPattern, 0),
+					func(*terraform.State) error {
+						vars, err := envcontext.NewEnvService(fc.Client).List(t.Context(), orgCtx.ID.String())
+						if err != nil {
+							return err
+						}
+						var got []string
+						for _, v := range vars {
+							got = append(got, v.Variable)
+						}
+						slices.Sort(got)
+						if want := []string{"A", "B", "UNMANAGED"}; !slices.Equal(got, want) {
+							return fmt.Errorf("context has the variables %v, want %v", got, want)
+						}
+						return nil
+					},
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_context_environment_variables.test",
+						tfjsonpath.New("variables"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"A": knownvalue.StringExact("1"),
+							"B": knownvalue.StringExact("2"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	ccicontext "terraform-provider-circleci/internal/circleci/context"
 )
 
@@ -22,6 +23,7 @@ import (
 var (
 	_ resource.Resource                = &contextResource{}
 	_ resource.ResourceWithConfigure   = &contextResource{}
+	_ resource.ResourceWithModifyPlan  = &contextResource{}
 	_ resource.ResourceWithImportState = &contextResource{}
 )
 
@@ -40,7 +42,8 @@ func NewContextResource() resource.Resource {
 
 // contextResource is the resource implementation.
 type contextResource struct {
-	client *ccicontext.ContextService
+	client   *ccicontext.ContextService
+	defaults providerDefaults
 }
 
 // Metadata returns the resource type name.
@@ -54,8 +57,12 @@ func (r *contextResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 		MarkdownDescription: "Manages a CircleCI context. Contexts provide a mechanism for securing and sharing environment variables across projects.",
 		Attributes: map[string]schema.Attribute{
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the organization that owns this context.",
-				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns this context. Defaults to the provider's `default_organization_id`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique identifier of the context.",
@@ -79,6 +86,9 @@ func (r *contextResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *contextResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context", "Create")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan contextResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -111,6 +121,9 @@ func (r *contextResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (r *contextResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context", "Read")
+	defer end(&resp.Diagnostics)
+
 	var contextState contextResourceModel
 	diags := req.State.Get(ctx, &contextState)
 	if diags != nil {
@@ -126,37 +139,22 @@ func (r *contextResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// Use the token for the context's org, if the provider has one.
+	ctx = client.WithOrganization(ctx, contextState.OrganizationId.ValueString())
 	context, err := r.client.Get(ctx, contextState.Id.ValueString())
+	if client.IsNotFound(err) {
+		// The context was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		// Safely retrieve the ID string for the error message
-		// Use .ValueString() only after checking IsNull() if this was the first access,
-		// but since we rely on it being set, let's simplify the error message to avoid the panic risk.
-
-		contextID := "unknown ID"
-		if !contextState.Id.IsNull() {
-			contextID = contextState.Id.ValueString()
-		}
-
 		resp.Diagnostics.AddError(
-			"Unable to Read CircleCI context with id "+contextID,
+			"Unable to Read CircleCI context with id "+contextState.Id.ValueString(),
 			err.Error(),
 		)
 		return
 	}
 
-	// ⚠️ CRITICAL FIX: Handle successful transport but no resource returned (nil context)
-	if context == nil {
-		// This often happens if the context was deleted just before import,
-		// or if the API client returns nil instead of an error for a 404.
-		resp.Diagnostics.AddWarning(
-			"Context not found during Read",
-			fmt.Sprintf("Context ID %s could not be retrieved from CircleCI. Removing from state.", contextState.Id.ValueString()),
-		)
-		// Mark resource for removal from state
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
 	// Map response body to model
 	contextState = contextResourceModel{
 		Id:             types.StringValue(context.ID),
@@ -179,6 +177,9 @@ func (r *contextResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *contextResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context", "Delete")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state contextResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -188,8 +189,9 @@ func (r *contextResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	// Delete existing order
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
 	err := r.client.Delete(ctx, state.Id.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCi Context",
 			"Could not delete context, unexpected error: "+err.Error(),
@@ -198,6 +200,15 @@ func (r *contextResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *contextResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, false)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *contextResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -216,6 +227,7 @@ func (r *contextResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 	r.client = client.ContextService
+	r.defaults = client.Defaults
 }
 
 func (r *contextResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
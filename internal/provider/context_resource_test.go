@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -12,9 +13,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	ccicontext "terraform-provider-circleci/internal/circleci/context"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
 )
 
 func TestAccContextResource(t *testing.T) {
@@ -83,3 +88,43 @@ func testAccContextResourceConfig(name string) string {
 }
 `, name)
 }
+
+func TestAccContextResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_context" "test_context" {
+  name            = "disappears"
+  organization_id = %[1]q
+}
+`, org.ID)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_context.test_context", func(ctx context.Context, attrs map[string]string) error {
+					return ccicontext.NewContextService(fc.Client).Delete(ctx, attrs["id"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			// The refresh drops the deleted context, so it is planned for creation.
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_context.test_context", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	ccicontext "terraform-provider-circleci/internal/circleci/context"
 )
 
@@ -33,6 +34,8 @@ type contextRestrictionResourceModel struct {
 	Name      types.String `tfsdk:"name"`
 	Type      types.String `tfsdk:"type"`
 	Value     types.String `tfsdk:"value"`
+
+	OrganizationId types.String `tfsdk:"organization_id"`
 }
 
 // NewContextResource is a helper function to simplify the provider implementation.
@@ -91,12 +94,19 @@ func (r *contextRestrictionResource) Schema(_ context.Context, _ resource.Schema
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID or slug of the organization that owns the context. Requests for the context then use the organization's `credentials` block, if the provider has one, in place of `key`.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *contextRestrictionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_restriction", "Create")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan contextRestrictionResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -105,6 +115,7 @@ func (r *contextRestrictionResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	ctx = client.WithOrganization(ctx, plan.OrganizationId.ValueString())
 	newCciContextRestriction, err := r.client.CreateRestriction(ctx, plan.ContextId.ValueString(), plan.Value.ValueString(), plan.Type.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -129,6 +140,9 @@ func (r *contextRestrictionResource) Create(ctx context.Context, req resource.Cr
 
 // Read refreshes the Terraform state with the latest data.
 func (r *contextRestrictionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_restriction", "Read")
+	defer end(&resp.Diagnostics)
+
 	var contextRestrictionState contextRestrictionResourceModel
 	diags := req.State.Get(ctx, &contextRestrictionState)
 	if diags != nil {
@@ -144,7 +158,13 @@ func (r *contextRestrictionResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	ctx = client.WithOrganization(ctx, contextRestrictionState.OrganizationId.ValueString())
 	restrictions, err := r.client.GetRestrictions(ctx, contextRestrictionState.ContextId.ValueString())
+	if client.IsNotFound(err) {
+		// The whole context was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read CircleCI context restriction from context with id "+contextRestrictionState.ContextId.ValueString(),
@@ -154,13 +174,20 @@ func (r *contextRestrictionResource) Read(ctx context.Context, req resource.Read
 	}
 
 	var cciContextRestriction ccicontext.ContextRestriction
+	var found bool
 	for _, restriction := range restrictions {
 		if restriction.ID == contextRestrictionState.Id.ValueString() {
 			cciContextRestriction = restriction
+			found = true
 			break
 		}
 	}
 
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	// Map response body to model
 	contextRestrictionState = contextRestrictionResourceModel{
 		Id:        types.StringValue(cciContextRestriction.ID),
@@ -169,6 +196,8 @@ func (r *contextRestrictionResource) Read(ctx context.Context, req resource.Read
 		Name:      types.StringValue(cciContextRestriction.Name),
 		Type:      types.StringValue(cciContextRestriction.RestrictionType),
 		Value:     types.StringValue(cciContextRestriction.RestrictionValue),
+
+		OrganizationId: contextRestrictionState.OrganizationId,
 	}
 
 	// Set state
@@ -185,6 +214,9 @@ func (r *contextRestrictionResource) Update(ctx context.Context, req resource.Up
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *contextRestrictionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_context_restriction", "Delete")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state contextRestrictionResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -194,8 +226,9 @@ func (r *contextRestrictionResource) Delete(ctx context.Context, req resource.De
 	}
 
 	// Delete existing order
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
 	err := r.client.DeleteRestriction(ctx, state.ContextId.ValueString(), state.Id.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCi Context",
 			"Could not delete context, unexpected error: "+err.Error(),
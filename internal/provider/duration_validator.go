@@ -0,0 +1,60 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = durationValidator{}
+
+type durationValidator struct {
+	min, max time.Duration
+}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a duration between %s and %s (e.g. \"1h\")", formatDuration(v.min), formatDuration(v.max))
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d < v.min || d > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Must be a duration between %s and %s, such as \"1h\" or \"90m\", got %q.",
+				formatDuration(v.min), formatDuration(v.max), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// DurationValidator returns a validator that checks for a Go duration string
+// between min and max inclusive.
+func DurationValidator(minDuration, maxDuration time.Duration) validator.String {
+	return durationValidator{min: minDuration, max: maxDuration}
+}
+
+// formatDuration formats d without the zero units time.Duration.String
+// adds, so 24h is "24h" rather than "24h0m0s".
+func formatDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
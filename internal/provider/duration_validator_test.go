@@ -0,0 +1,65 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expectError bool
+	}{
+		{name: "minimum", value: "5m", expectError: false},
+		{name: "maximum", value: "24h", expectError: false},
+		{name: "hours and minutes", value: "1h30m", expectError: false},
+		{name: "seconds", value: "600s", expectError: false},
+
+		{name: "below minimum", value: "4m59s", expectError: true},
+		{name: "above maximum", value: "24h1m", expectError: true},
+		{name: "negative", value: "-1h", expectError: true},
+		{name: "no unit", value: "60", expectError: true},
+		{name: "days", value: "1d", expectError: true},
+		{name: "empty string", value: "", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: types.StringValue(tc.value),
+			}
+			resp := &validator.StringResponse{}
+			DurationValidator(5*time.Minute, 24*time.Hour).ValidateString(context.Background(), req, resp)
+
+			if tc.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected validation error for %q but got none", tc.value)
+			}
+			if !tc.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("unexpected validation error for %q: %s", tc.value, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDurationValidatorDescription(t *testing.T) {
+	t.Parallel()
+
+	got := DurationValidator(5*time.Minute, 24*time.Hour).Description(context.Background())
+	want := `value must be a duration between 5m and 24h (e.g. "1h")`
+	if got != want {
+		t.Errorf("Description() = %q, want %q", got, want)
+	}
+}
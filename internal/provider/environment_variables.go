@@ -0,0 +1,114 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// environmentVariablesConcurrency is how many requests the bulk environment
+// variable resources make at once.
+const environmentVariablesConcurrency = 8
+
+// hashEnvironmentVariable returns the hash of an environment variable's
+// value kept in private state, so that the value itself is not.
+func hashEnvironmentVariable(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// environmentVariablesChange is the writes and deletes that turn one set of
+// environment variables into another.
+type environmentVariablesChange struct {
+	Put    map[string]string
+	Delete []string
+}
+
+// diffEnvironmentVariables returns the change from the variables in from to
+// those in to. Variables named in extra are deleted too, unless they are in
+// to; they are the ones CircleCI has but from does not know about.
+func diffEnvironmentVariables(from, to map[string]string, extra []string) environmentVariablesChange {
+	change := environmentVariablesChange{Put: make(map[string]string)}
+	for name, value := range to {
+		if old, ok := from[name]; !ok || old != value {
+			change.Put[name] = value
+		}
+	}
+	for name := range from {
+		if _, ok := to[name]; !ok {
+			change.Delete = append(change.Delete, name)
+		}
+	}
+	for _, name := range extra {
+		_, inFrom := from[name]
+		if _, ok := to[name]; !ok && !inFrom {
+			change.Delete = append(change.Delete, name)
+		}
+	}
+	slices.Sort(change.Delete)
+	return change
+}
+
+// applyEnvironmentVariables makes change with put and del, at most
+// environmentVariablesConcurrency requests at a time. It returns vars
+// updated with every write and delete that succeeded, so the state can
+// record them even when others failed, along with every failure.
+func applyEnvironmentVariables(
+	ctx context.Context,
+	vars map[string]string,
+	change environmentVariablesChange,
+	put func(ctx context.Context, name, value string) error,
+	del func(ctx context.Context, name string) error,
+) (map[string]string, error) {
+	vars = maps.Clone(vars)
+	if vars == nil {
+		vars = make(map[string]string, len(change.Put))
+	}
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		g    errgroup.Group
+	)
+	g.SetLimit(environmentVariablesConcurrency)
+	done := func(name string, err error, apply func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+		apply()
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(change.Put)) {
+		value := change.Put[name]
+		g.Go(func() error {
+			err := put(ctx, name, value)
+			done(name, err, func() { vars[name] = value })
+			return nil
+		})
+	}
+	for _, name := range change.Delete {
+		g.Go(func() error {
+			err := del(ctx, name)
+			done(name, err, func() { delete(vars, name) })
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return vars, errors.Join(errs...)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffEnvironmentVariables(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from, to map[string]string
+		extra    []string
+		want     environmentVariablesChange
+	}{
+		{
+			name: "create",
+			to:   map[string]string{"A": "1", "B": "2"},
+			want: environmentVariablesChange{Put: map[string]string{"A": "1", "B": "2"}},
+		},
+		{
+			name: "update_and_delete",
+			from: map[string]string{"A": "1", "B": "2", "C": "3"},
+			to:   map[string]string{"A": "1", "B": "two", "D": "4"},
+			want: environmentVariablesChange{
+				Put:    map[string]string{"B": "two", "D": "4"},
+				Delete: []string{"C"},
+			},
+		},
+		{
+			name:  "extra",
+			from:  map[string]string{"A": "1", "B": "2"},
+			to:    map[string]string{"A": "1"},
+			extra: []string{"A", "B", "UNMANAGED"},
+			want: environmentVariablesChange{
+				Put:    map[string]string{},
+				Delete: []string{"B", "UNMANAGED"},
+			},
+		},
+		{
+			name: "delete_all",
+			from: map[string]string{"B": "2", "A": "1"},
+			want: environmentVariablesChange{
+				Put:    map[string]string{},
+				Delete: []string{"A", "B"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := diffEnvironmentVariables(tc.from, tc.to, tc.extra)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("change (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyEnvironmentVariables(t *testing.T) {
+	t.Run("partial_failure", func(t *testing.T) {
+		vars := map[string]string{"KEEP": "1", "GONE": "2", "STUCK": "3"}
+		change := environmentVariablesChange{
+			Put:    map[string]string{"NEW": "4", "BAD": "5"},
+			Delete: []string{"GONE", "STUCK"},
+		}
+		got, err := applyEnvironmentVariables(context.Background(), vars, change,
+			func(_ context.Context, name, _ string) error {
+				if name == "BAD" {
+					return errors.New("rejected")
+				}
+				return nil
+			},
+			func(_ context.Context, name string) error {
+				if name == "STUCK" {
+					return errors.New("locked")
+				}
+				return nil
+			},
+		)
+		if err == nil || err.Error() != "BAD: rejected\nSTUCK: locked" {
+			t.Errorf("err = %v, want both failures", err)
+		}
+		want := map[string]string{"KEEP": "1", "STUCK": "3", "NEW": "4"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("vars (-want +got):\n%s", diff)
+		}
+		if _, ok := vars["NEW"]; ok {
+			t.Error("vars passed in were modified")
+		}
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		change := environmentVariablesChange{Put: make(map[string]string)}
+		for _, name := range []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P"} {
+			change.Put[name] = name
+		}
+
+		var (
+			inFlight, most atomic.Int32
+			mu             sync.Mutex
+		)
+		_, err := applyEnvironmentVariables(context.Background(), nil, change,
+			func(context.Context, string, string) error {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				mu.Lock()
+				most.Store(max(most.Load(), n))
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			},
+			nil,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := most.Load(); got > environmentVariablesConcurrency || got < 2 {
+			t.Errorf("%d requests were made at once, want 2 to %d", got, environmentVariablesConcurrency)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/oidc"
+)
+
+// The bounds CircleCI puts on the ttl claim of OIDC tokens.
+const (
+	oidcMinTTL = 5 * time.Minute
+	oidcMaxTTL = 24 * time.Hour
+)
+
+// newOIDCClaimsUpdate returns the update that sets the configured claims.
+func newOIDCClaimsUpdate(ctx context.Context, audience types.Set, ttl types.String) (oidc.Update, diag.Diagnostics) {
+	var u oidc.Update
+	var diags diag.Diagnostics
+	if !audience.IsNull() {
+		diags.Append(audience.ElementsAs(ctx, &u.Audience, false)...)
+	}
+	u.TTL = ttl.ValueString()
+	return u, diags
+}
+
+// applyOIDCClaims sets the claims in u with update, then unsets every other
+// claim with del, so that the claims are only those configured.
+func applyOIDCClaims(
+	ctx context.Context,
+	u oidc.Update,
+	update func(context.Context, oidc.Update) (*oidc.Claims, error),
+	del func(context.Context, ...string) (*oidc.Claims, error),
+) (*oidc.Claims, error) {
+	claims, err := update(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var unset []string
+	if u.Audience == nil && len(claims.Audience) > 0 {
+		unset = append(unset, oidc.ClaimAudience)
+	}
+	if u.TTL == "" && claims.TTL != "" {
+		unset = append(unset, oidc.ClaimTTL)
+	}
+	if len(unset) == 0 {
+		return claims, nil
+	}
+	return del(ctx, unset...)
+}
+
+// oidcAudienceValue returns the audience claim, or null if it is unset.
+func oidcAudienceValue(ctx context.Context, audience []string) (types.Set, diag.Diagnostics) {
+	if len(audience) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, audience)
+}
+
+// oidcTTLValue returns the ttl claim, or null if it is unset. prior is kept
+// when it is the same duration written another way, such as "60m" for "1h".
+func oidcTTLValue(ttl string, prior types.String) types.String {
+	if ttl == "" {
+		return types.StringNull()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		p, err1 := time.ParseDuration(prior.ValueString())
+		d, err2 := time.ParseDuration(ttl)
+		if err1 == nil && err2 == nil && p == d {
+			return prior
+		}
+	}
+	return types.StringValue(ttl)
+}
+
+// oidcUpdatedAtValue returns when a claim was last set, in UTC, or null if
+// it is unset.
+func oidcUpdatedAtValue(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(formatTimestamp(t))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOIDCUpdatedAtValue(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   time.Time
+		want types.String
+	}{
+		{
+			name: "unset",
+			want: types.StringNull(),
+		},
+		{
+			name: "utc",
+			in:   time.Date(2025, 3, 4, 5, 6, 7, 890000000, time.UTC),
+			want: types.StringValue("2025-03-04T05:06:07.890Z"),
+		},
+		{
+			name: "other_zone",
+			in:   time.Date(2025, 3, 4, 7, 6, 7, 890000000, time.FixedZone("UTC+2", 2*60*60)),
+			want: types.StringValue("2025-03-04T05:06:07.890Z"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := oidcUpdatedAtValue(tc.in); !got.Equal(tc.want) {
+				t.Errorf("oidcUpdatedAtValue(%v) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-circleci/internal/circleci/organization"
+	"terraform-provider-circleci/internal/circleci/user"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -36,6 +37,9 @@ func NewOrganizationDataSource() datasource.DataSource {
 // OrganizationDataSource is the data source implementation.
 type OrganizationDataSource struct {
 	client *organization.OrganizationService
+	// orgs are the orgs found when the provider validated its credentials,
+	// which saves a request for any of them.
+	orgs []user.Collaboration
 }
 
 // Metadata returns the data source type name.
@@ -70,6 +74,9 @@ func (d *OrganizationDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 
 // Read refreshes the Terraform state with the latest data.
 func (d *OrganizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_organization", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state organizationDataSourceModel
 	diags := req.Config.Get(ctx, &state)
 	if diags != nil {
@@ -85,7 +92,7 @@ func (d *OrganizationDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	org, err := d.client.Get(ctx, state.Id.ValueString())
+	org, err := d.get(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read CircleCI organization with id "+state.Id.ValueString(),
@@ -123,4 +130,19 @@ func (d *OrganizationDataSource) Configure(_ context.Context, req datasource.Con
 	}
 
 	d.client = client.OrganizationService
+	d.orgs = client.Organizations
+}
+
+func (d *OrganizationDataSource) get(ctx context.Context, id string) (*organization.Organization, error) {
+	for _, o := range d.orgs {
+		if o.Id == id {
+			return &organization.Organization{
+				Id:      o.Id,
+				Name:    o.Name,
+				VcsType: o.VcsType,
+				Slug:    o.Slug,
+			}, nil
+		}
+	}
+	return d.client.Get(ctx, id)
 }
@@ -0,0 +1,145 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/oidc"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &organizationOIDCClaimsDataSource{}
+	_ datasource.DataSourceWithConfigure = &organizationOIDCClaimsDataSource{}
+)
+
+// organizationOIDCClaimsDataSourceModel maps the data source schema.
+type organizationOIDCClaimsDataSourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	OrganizationId    types.String `tfsdk:"organization_id"`
+	Audience          types.Set    `tfsdk:"audience"`
+	TTL               types.String `tfsdk:"ttl"`
+	AudienceUpdatedAt types.String `tfsdk:"audience_updated_at"`
+	TTLUpdatedAt      types.String `tfsdk:"ttl_updated_at"`
+}
+
+// NewOrganizationOIDCClaimsDataSource is a helper function to simplify the provider implementation.
+func NewOrganizationOIDCClaimsDataSource() datasource.DataSource {
+	return &organizationOIDCClaimsDataSource{}
+}
+
+// organizationOIDCClaimsDataSource is the data source implementation.
+type organizationOIDCClaimsDataSource struct {
+	client   *oidc.Service
+	defaults providerDefaults
+}
+
+// Metadata returns the data source type name.
+func (d *organizationOIDCClaimsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_oidc_claims"
+}
+
+// Schema defines the schema for the data source.
+func (d *organizationOIDCClaimsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the custom claims of the OIDC tokens of a CircleCI organization's jobs. Claims that are not set are null, and tokens use CircleCI's default for them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization.",
+				Computed:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization. Defaults to the provider's `default_organization_id`.",
+				Optional:            true,
+			},
+			"audience": schema.SetAttribute{
+				MarkdownDescription: "The audiences (`aud` claim) of the tokens.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the tokens are valid for, such as `\"1h\"`.",
+				Computed:            true,
+			},
+			"audience_updated_at": schema.StringAttribute{
+				MarkdownDescription: "The time at which the audience was last set.",
+				Computed:            true,
+			},
+			"ttl_updated_at": schema.StringAttribute{
+				MarkdownDescription: "The time at which the ttl was last set.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read fetches the organization's claims.
+func (d *organizationOIDCClaimsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_organization_oidc_claims", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state organizationOIDCClaimsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OrganizationId.IsNull() {
+		if d.defaults.OrganizationID == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("organization_id"),
+				"Missing organization_id",
+				"Set organization_id, or set default_organization_id in the provider configuration.",
+			)
+			return
+		}
+		state.OrganizationId = types.StringValue(d.defaults.OrganizationID)
+	}
+
+	claims, err := d.client.GetOrgClaims(ctx, state.OrganizationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading CircleCI organization OIDC claims",
+			"Could not read OIDC claims of organization "+state.OrganizationId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var diags diag.Diagnostics
+	state.Id = state.OrganizationId
+	state.Audience, diags = oidcAudienceValue(ctx, claims.Audience)
+	resp.Diagnostics.Append(diags...)
+	state.TTL = oidcTTLValue(claims.TTL, types.StringNull())
+	state.AudienceUpdatedAt = oidcUpdatedAtValue(claims.AudienceUpdatedAt)
+	state.TTLUpdatedAt = oidcUpdatedAtValue(claims.TTLUpdatedAt)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *organizationOIDCClaimsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client.OIDCService
+	d.defaults = client.Defaults
+}
@@ -0,0 +1,83 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccOrganizationOIDCClaimsDataSource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "oidc-claims-data")
+	updatedAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	err := fc.SetOrgOIDCClaims(prj.Org.ID, fakecircle.OIDCClaims{
+		Audience:          []string{"sts.amazonaws.com"},
+		AudienceUpdatedAt: updatedAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fc.ProviderConfig() + fmt.Sprintf(`
+data "circleci_organization_oidc_claims" "test" {
+  organization_id = %[1]q
+}
+`, prj.Org.ID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.circleci_organization_oidc_claims.test",
+						tfjsonpath.New("audience"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("sts.amazonaws.com")}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.circleci_organization_oidc_claims.test",
+						tfjsonpath.New("audience_updated_at"),
+						knownvalue.StringExact("2026-03-04T05:06:07.000Z"),
+					),
+					// Unset claims are null.
+					statecheck.ExpectKnownValue(
+						"data.circleci_organization_oidc_claims.test",
+						tfjsonpath.New("ttl"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.circleci_organization_oidc_claims.test",
+						tfjsonpath.New("ttl_updated_at"),
+						knownvalue.Null(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccOrganizationOIDCClaimsDataSource_missingOrganization(t *testing.T) {
+	fc := testAccFakeCircle(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fc.ProviderConfig() + `
+data "circleci_organization_oidc_claims" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Missing organization_id`),
+			},
+		},
+	})
+}
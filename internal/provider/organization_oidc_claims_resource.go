@@ -0,0 +1,290 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/oidc"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &organizationOIDCClaimsResource{}
+	_ resource.ResourceWithConfigure        = &organizationOIDCClaimsResource{}
+	_ resource.ResourceWithConfigValidators = &organizationOIDCClaimsResource{}
+	_ resource.ResourceWithModifyPlan       = &organizationOIDCClaimsResource{}
+	_ resource.ResourceWithImportState      = &organizationOIDCClaimsResource{}
+)
+
+// organizationOIDCClaimsResourceModel maps the resource schema.
+type organizationOIDCClaimsResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	OrganizationId    types.String `tfsdk:"organization_id"`
+	Audience          types.Set    `tfsdk:"audience"`
+	TTL               types.String `tfsdk:"ttl"`
+	AudienceUpdatedAt types.String `tfsdk:"audience_updated_at"`
+	TTLUpdatedAt      types.String `tfsdk:"ttl_updated_at"`
+}
+
+// setClaims maps the organization's claims onto the model.
+func (m *organizationOIDCClaimsResourceModel) setClaims(ctx context.Context, claims *oidc.Claims) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = m.OrganizationId
+	m.Audience, diags = oidcAudienceValue(ctx, claims.Audience)
+	m.TTL = oidcTTLValue(claims.TTL, m.TTL)
+	m.AudienceUpdatedAt = oidcUpdatedAtValue(claims.AudienceUpdatedAt)
+	m.TTLUpdatedAt = oidcUpdatedAtValue(claims.TTLUpdatedAt)
+	return diags
+}
+
+// NewOrganizationOIDCClaimsResource is a helper function to simplify the provider implementation.
+func NewOrganizationOIDCClaimsResource() resource.Resource {
+	return &organizationOIDCClaimsResource{}
+}
+
+// organizationOIDCClaimsResource is the resource implementation.
+type organizationOIDCClaimsResource struct {
+	client   *oidc.Service
+	defaults providerDefaults
+}
+
+// Metadata returns the resource type name.
+func (r *organizationOIDCClaimsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_oidc_claims"
+}
+
+// Schema defines the schema for the resource.
+func (r *organizationOIDCClaimsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the custom claims of the OIDC tokens of a CircleCI organization's jobs. " +
+			"Claims left out of the configuration are unset, so tokens use CircleCI's default for them. " +
+			"Projects can override these claims with `circleci_project_oidc_claims`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization. Defaults to the provider's `default_organization_id`. Changing this value forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"audience": schema.SetAttribute{
+				MarkdownDescription: "The audiences (`aud` claim) of the tokens, such as `sts.amazonaws.com`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the tokens are valid for, as a duration between `5m` and `24h` such as `\"1h\"`.",
+				Optional:            true,
+				Validators: []validator.String{
+					DurationValidator(oidcMinTTL, oidcMaxTTL),
+				},
+			},
+			"audience_updated_at": schema.StringAttribute{
+				MarkdownDescription: "The time at which the audience was last set.",
+				Computed:            true,
+			},
+			"ttl_updated_at": schema.StringAttribute{
+				MarkdownDescription: "The time at which the ttl was last set.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ConfigValidators requires at least one claim to be set.
+func (r *organizationOIDCClaimsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("audience"),
+			path.MatchRoot("ttl"),
+		),
+	}
+}
+
+// Create sets the claims and sets the initial Terraform state.
+func (r *organizationOIDCClaimsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_organization_oidc_claims", "Create")
+	defer end(&resp.Diagnostics)
+
+	var plan organizationOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *organizationOIDCClaimsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_organization_oidc_claims", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state organizationOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	claims, err := r.client.GetOrgClaims(ctx, state.OrganizationId.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading CircleCI organization OIDC claims",
+			"Could not read OIDC claims of organization "+state.OrganizationId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(claims.Audience) == 0 && claims.TTL == "" {
+		// Every claim was unset outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setClaims(ctx, claims)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sets the claims in the plan and unsets the rest.
+func (r *organizationOIDCClaimsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_organization_oidc_claims", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan organizationOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// apply makes the organization's claims those in plan, and maps the result
+// onto it.
+func (r *organizationOIDCClaimsResource) apply(ctx context.Context, plan *organizationOIDCClaimsResourceModel, diags *diag.Diagnostics) {
+	u, d := newOIDCClaimsUpdate(ctx, plan.Audience, plan.TTL)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	orgID := plan.OrganizationId.ValueString()
+	claims, err := applyOIDCClaims(ctx, u,
+		func(ctx context.Context, u oidc.Update) (*oidc.Claims, error) {
+			return r.client.UpdateOrgClaims(ctx, orgID, u)
+		},
+		func(ctx context.Context, names ...string) (*oidc.Claims, error) {
+			return r.client.DeleteOrgClaims(ctx, orgID, names...)
+		},
+	)
+	if err != nil {
+		diags.AddError(
+			"Error setting CircleCI organization OIDC claims",
+			"Could not set OIDC claims of organization "+orgID+": "+err.Error(),
+		)
+		return
+	}
+
+	diags.Append(plan.setClaims(ctx, claims)...)
+}
+
+// Delete unsets the claims, so tokens use CircleCI's defaults again.
+func (r *organizationOIDCClaimsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_organization_oidc_claims", "Delete")
+	defer end(&resp.Diagnostics)
+
+	var state organizationOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DeleteOrgClaims(ctx, state.OrganizationId.ValueString(), oidc.ClaimAudience, oidc.ClaimTTL)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting CircleCI organization OIDC claims",
+			"Could not unset OIDC claims of organization "+state.OrganizationId.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *organizationOIDCClaimsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, true)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *organizationOIDCClaimsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.OIDCService
+	r.defaults = client.Defaults
+}
+
+// ImportState imports the claims of the organization with the given ID.
+func (r *organizationOIDCClaimsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), req.ID)...)
+}
@@ -0,0 +1,236 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccOrganizationOIDCClaimsResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "oidc-claims")
+	orgID := prj.Org.ID.String()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_organization_oidc_claims" "test" {
+  organization_id = %[1]q
+  audience        = ["sts.amazonaws.com", "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/circleci"]
+  ttl             = "60m"
+}
+`, orgID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_organization_oidc_claims.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(orgID),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_organization_oidc_claims.test",
+						tfjsonpath.New("audience"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("sts.amazonaws.com"),
+							knownvalue.StringExact("//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/circleci"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_organization_oidc_claims.test",
+						tfjsonpath.New("ttl"),
+						knownvalue.StringExact("60m"),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_organization_oidc_claims.test",
+						tfjsonpath.New("ttl_updated_at"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			// The same ttl written another way is not a change.
+			{
+				PreConfig: func() {
+					claims, err := fc.OrgOIDCClaims(prj.Org.ID)
+					if err != nil {
+						t.Fatal(err)
+					}
+					claims.TTL = "1h"
+					if err := fc.SetOrgOIDCClaims(prj.Org.ID, claims); err != nil {
+						t.Fatal(err)
+					}
+				},
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("circleci_organization_oidc_claims.test", "ttl", "60m"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_organization_oidc_claims.test",
+				ImportState:       true,
+				ImportStateId:     orgID,
+				ImportStateVerify: true,
+				// The API has the ttl as it was set out-of-band.
+				ImportStateVerifyIgnore: []string{"ttl"},
+			},
+			// Removing a claim unsets it
+			{
+				Config: fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_organization_oidc_claims" "test" {
+  organization_id = %[1]q
+  ttl             = "2h"
+}
+`, orgID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_organization_oidc_claims.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_organization_oidc_claims.test",
+						tfjsonpath.New("audience"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_organization_oidc_claims.test",
+						tfjsonpath.New("audience_updated_at"),
+						knownvalue.Null(),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckOrgOIDCClaims(fc, prj.Org.ID, fakecircle.OIDCClaims{TTL: "2h"}),
+					fc.CheckLastBody(http.MethodPatch, "/org/*/oidc-custom-claims", "ttl", "2h"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: testAccCheckOrgOIDCClaims(fc, prj.Org.ID, fakecircle.OIDCClaims{}),
+	})
+}
+
+func TestAccOrganizationOIDCClaimsResource_defaultOrganization(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "oidc-default")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefaultsConfig(fc, fmt.Sprintf("  default_organization_id = %q", prj.Org.ID), `
+resource "circleci_organization_oidc_claims" "test" {
+  audience = ["vault"]
+}
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_organization_oidc_claims.test",
+						tfjsonpath.New("organization_id"),
+						knownvalue.StringExact(prj.Org.ID.String()),
+					),
+				},
+				Check: testAccCheckOrgOIDCClaims(fc, prj.Org.ID, fakecircle.OIDCClaims{Audience: []string{"vault"}}),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationOIDCClaimsResource_invalid(t *testing.T) {
+	fc := testAccFakeCircle(t)
+
+	for name, tc := range map[string]struct {
+		attrs string
+		err   string
+	}{
+		"no_claims":      {attrs: ``, err: `At least one of these attributes must be configured: \[audience,ttl\]`},
+		"ttl_too_long":   {attrs: `ttl = "25h"`, err: `Must be a duration between 5m and 24h`},
+		"ttl_days":       {attrs: `ttl = "1d"`, err: `Must be a duration between 5m and 24h`},
+		"empty_audience": {attrs: `audience = []`, err: `set must contain at least 1 elements`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_organization_oidc_claims" "test" {
+  organization_id = "5e2a3c1f-0000-4000-8000-000000000000"
+  %s
+}
+`, tc.attrs),
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccOrganizationOIDCClaimsResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "oidc-disappears")
+	config := fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_organization_oidc_claims" "test" {
+  organization_id = %[1]q
+  ttl             = "30m"
+}
+`, prj.Org.ID)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_organization_oidc_claims.test", func(context.Context, map[string]string) error {
+					return fc.SetOrgOIDCClaims(prj.Org.ID, fakecircle.OIDCClaims{})
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_organization_oidc_claims.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckOrgOIDCClaims checks the fake has the org's audience and ttl
+// claims as in want.
+func testAccCheckOrgOIDCClaims(fc *testAccFake, orgID uuid.UUID, want fakecircle.OIDCClaims) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		got, err := fc.OrgOIDCClaims(orgID)
+		if err != nil {
+			return err
+		}
+		return testAccCompareOIDCClaims(got, want)
+	}
+}
+
+// testAccCompareOIDCClaims compares the audience and ttl claims of got and
+// want, ignoring when they were set.
+func testAccCompareOIDCClaims(got, want fakecircle.OIDCClaims) error {
+	if !slices.Equal(got.Audience, want.Audience) || got.TTL != want.TTL {
+		return fmt.Errorf("expected audience %v and ttl %q, got audience %v and ttl %q", want.Audience, want.TTL, got.Audience, got.TTL)
+	}
+	return nil
+}
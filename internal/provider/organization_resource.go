@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/organization"
 )
 
@@ -21,6 +22,7 @@ import (
 var (
 	_ resource.Resource                = &organizationResource{}
 	_ resource.ResourceWithConfigure   = &organizationResource{}
+	_ resource.ResourceWithModifyPlan  = &organizationResource{}
 	_ resource.ResourceWithImportState = &organizationResource{}
 )
 
@@ -39,7 +41,8 @@ func NewOrganizationResource() resource.Resource {
 
 // organizationResource is the resource implementation.
 type organizationResource struct {
-	client *organization.OrganizationService
+	client   *organization.OrganizationService
+	defaults providerDefaults
 }
 
 // Metadata returns the resource type name.
@@ -74,9 +77,11 @@ func (r *organizationResource) Schema(_ context.Context, _ resource.SchemaReques
 				},
 			},
 			"vcs_type": schema.StringAttribute{
-				MarkdownDescription: "The VCS type of the CircleCI organization (e.g., github, bitbucket, circleci). Changing this value forces a new resource to be created.",
-				Required:            true,
+				MarkdownDescription: "The VCS type of the CircleCI organization (e.g., github, bitbucket, circleci). Defaults to the provider's `default_vcs_type`. Changing this value forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -86,6 +91,9 @@ func (r *organizationResource) Schema(_ context.Context, _ resource.SchemaReques
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_organization", "Create")
+	defer end(&resp.Diagnostics)
+
 	var plan organizationResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -113,6 +121,9 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 
 // Read refreshes the Terraform state with the latest data.
 func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_organization", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state organizationResourceModel
 	diags := req.State.Get(ctx, &state)
 	if diags != nil {
@@ -129,28 +140,19 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	org, err := r.client.Get(ctx, state.Id.ValueString())
+	if client.IsNotFound(err) {
+		// The organization was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		orgID := "unknown ID"
-		if !state.Id.IsNull() {
-			orgID = state.Id.ValueString()
-		}
-
 		resp.Diagnostics.AddError(
-			"Unable to Read CircleCI organization with id "+orgID,
+			"Unable to Read CircleCI organization with id "+state.Id.ValueString(),
 			err.Error(),
 		)
 		return
 	}
 
-	if org == nil {
-		resp.Diagnostics.AddWarning(
-			"Organization not found during Read",
-			fmt.Sprintf("Organization ID %s could not be retrieved from CircleCI. Removing from state.", state.Id.ValueString()),
-		)
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
 	// Map response body to model
 	state = organizationResourceModel{
 		Id:      types.StringValue(org.Id),
@@ -171,6 +173,9 @@ func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRe
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_organization", "Delete")
+	defer end(&resp.Diagnostics)
+
 	var state organizationResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -179,7 +184,7 @@ func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 
 	err := r.client.Delete(ctx, state.Id.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCI Organization",
 			"Could not delete organization, unexpected error: "+err.Error(),
@@ -188,6 +193,15 @@ func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
+// ModifyPlan fills in vcs_type from the provider's default_vcs_type.
+func (r *organizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("vcs_type"), "default_vcs_type", r.defaults.VcsType, true)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *organizationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -204,6 +218,7 @@ func (r *organizationResource) Configure(_ context.Context, req resource.Configu
 	}
 
 	r.client = client.OrganizationService
+	r.defaults = client.Defaults
 }
 
 // ImportState imports an existing resource into Terraform state.
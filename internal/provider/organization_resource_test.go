@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"regexp"
@@ -11,8 +12,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/organization"
 )
 
 func TestAccOrganizationCircleCiResource(t *testing.T) {
@@ -71,3 +75,29 @@ resource "circleci_organization" "test_organization" {
 }
 `, name, vcs_type)
 }
+
+func TestAccOrganizationResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	config := fc.ProviderConfig() + testAccOrganizationResourceConfig("disappears", "circleci")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_organization.test_organization", func(ctx context.Context, attrs map[string]string) error {
+					return organization.NewOrganizationService(fc.Client).Delete(ctx, attrs["id"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_organization.test_organization", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
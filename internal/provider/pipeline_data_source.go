@@ -110,6 +110,9 @@ func (d *PipelineDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 
 // Read refreshes the Terraform state with the latest data.
 func (d *PipelineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_pipeline", "Read")
+	defer end(&resp.Diagnostics)
+
 	var pipelineState pipelineDataSourceModel
 	diags := req.Config.Get(ctx, &pipelineState)
 	if diags != nil {
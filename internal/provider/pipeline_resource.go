@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/common"
 	"terraform-provider-circleci/internal/circleci/pipeline"
 )
@@ -128,6 +129,9 @@ func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *pipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_pipeline", "Create")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan pipelineResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -195,6 +199,9 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read refreshes the Terraform state with the latest data.
 func (r *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_pipeline", "Read")
+	defer end(&resp.Diagnostics)
+
 	var pipelineState pipelineResourceModel
 	diags := req.State.Get(ctx, &pipelineState)
 	if diags != nil {
@@ -219,9 +226,14 @@ func (r *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	retrievedPipeline, err := r.client.Get(ctx, pipelineState.ProjectId.ValueString(), pipelineState.Id.ValueString())
+	if client.IsNotFound(err) {
+		// The pipeline definition was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Read CircleCI context with id "+pipelineState.Id.ValueString(),
+			"Unable to Read CircleCI pipeline with id "+pipelineState.Id.ValueString(),
 			err.Error(),
 		)
 		return
@@ -253,6 +265,9 @@ func (r *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_pipeline", "Update")
+	defer end(&resp.Diagnostics)
+
 	var plan pipelineResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -309,6 +324,9 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *pipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_pipeline", "Delete")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state pipelineResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -319,7 +337,7 @@ func (r *pipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	// Delete existing order
 	err := r.client.Delete(ctx, state.ProjectId.ValueString(), state.Id.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCi pipeline",
 			"Could not delete pipeline, unexpected error: "+err.Error(),
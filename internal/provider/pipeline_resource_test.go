@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -12,9 +13,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/pipeline"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
 )
 
 func TestAccPipelineResource(t *testing.T) {
@@ -212,3 +217,44 @@ resource "circleci_pipeline" "test_pipeline" {
 }
 `, project_id, name, description)
 }
+
+func TestAccPipelineResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + testAccPipelineResourceConfig(prj.ID.String(), "disappears", "disappears")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_pipeline.test_pipeline", func(ctx context.Context, attrs map[string]string) error {
+					return pipeline.NewPipelineService(fc.Client).Delete(ctx, attrs["project_id"], attrs["id"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_pipeline.test_pipeline", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
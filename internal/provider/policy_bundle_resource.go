@@ -0,0 +1,270 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/policy"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &policyBundleResource{}
+	_ resource.ResourceWithConfigure   = &policyBundleResource{}
+	_ resource.ResourceWithModifyPlan  = &policyBundleResource{}
+	_ resource.ResourceWithImportState = &policyBundleResource{}
+)
+
+// policyBundleResourceModel maps the resource schema.
+type policyBundleResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	Policies       types.Map    `tfsdk:"policies"`
+}
+
+// NewPolicyBundleResource is a helper function to simplify the provider implementation.
+func NewPolicyBundleResource() resource.Resource {
+	return &policyBundleResource{}
+}
+
+// policyBundleResource is the resource implementation.
+type policyBundleResource struct {
+	client   *policy.Service
+	defaults providerDefaults
+}
+
+// Metadata returns the resource type name.
+func (r *policyBundleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_bundle"
+}
+
+// Schema defines the schema for the resource.
+func (r *policyBundleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the config policy bundle of a CircleCI organization: the rego policies pipelines' config is checked against. " +
+			"The bundle is replaced as a whole, so policies pushed outside of Terraform are removed. " +
+			"Use `circleci_policy_settings` to turn checking on.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization. Defaults to the provider's `default_organization_id`. Changing this value forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policies": schema.MapAttribute{
+				MarkdownDescription: "The rego content of each policy, keyed by file name, such as `{ for f in fileset(\"${path.module}/policies\", \"*.rego\") : f => file(\"${path.module}/policies/${f}\") }`. " +
+					"Each policy must declare a package.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+// Create pushes the bundle and sets the initial Terraform state.
+func (r *policyBundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_bundle", "Create")
+	defer end(&resp.Diagnostics)
+
+	var plan policyBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var policies map[string]string
+	resp.Diagnostics.Append(plan.Policies.ElementsAs(ctx, &policies, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := plan.OrganizationId.ValueString()
+	_, err := r.client.PushBundle(ctx, orgID, policies, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating CircleCI policy bundle",
+			"Could not push policy bundle of organization "+orgID+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = plan.OrganizationId
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *policyBundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_bundle", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state policyBundleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bundle, err := r.client.GetBundle(ctx, state.OrganizationId.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading CircleCI policy bundle",
+			"Could not read policy bundle of organization "+state.OrganizationId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	contents := bundle.Contents()
+	if len(contents) == 0 {
+		// The bundle was emptied outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var prior map[string]string
+	resp.Diagnostics.Append(state.Policies.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured content of policies that only differ in line
+	// endings or surrounding whitespace, which the API may not keep.
+	for name, content := range contents {
+		if p, ok := prior[name]; ok && normalizePolicy(p) == normalizePolicy(content) {
+			contents[name] = p
+		}
+	}
+
+	var diags diag.Diagnostics
+	state.Id = state.OrganizationId
+	state.Policies, diags = types.MapValueFrom(ctx, types.StringType, contents)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update pushes the bundle in the plan.
+func (r *policyBundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_bundle", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan policyBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var policies map[string]string
+	resp.Diagnostics.Append(plan.Policies.ElementsAs(ctx, &policies, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := plan.OrganizationId.ValueString()
+	_, err := r.client.PushBundle(ctx, orgID, policies, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating CircleCI policy bundle",
+			"Could not push policy bundle of organization "+orgID+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete pushes an empty bundle.
+func (r *policyBundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_bundle", "Delete")
+	defer end(&resp.Diagnostics)
+
+	var state policyBundleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.PushBundle(ctx, state.OrganizationId.ValueString(), nil, false)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting CircleCI policy bundle",
+			"Could not empty policy bundle of organization "+state.OrganizationId.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *policyBundleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, true)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *policyBundleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.PolicyService
+	r.defaults = client.Defaults
+}
+
+// ImportState imports the bundle of the organization with the given ID.
+func (r *policyBundleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), req.ID)...)
+}
+
+// normalizePolicy returns a policy's content with Unix line endings and
+// without surrounding whitespace.
+func normalizePolicy(content string) string {
+	return strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+}
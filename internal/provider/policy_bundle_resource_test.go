@@ -0,0 +1,232 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const (
+	testAccBranchPolicy = "package org\n\npolicy_name[\"branches\"]\n"
+	testAccImagePolicy  = "package org\n\npolicy_name[\"images\"]\n"
+)
+
+func TestAccPolicyBundleResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "policy-bundle")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fc.ProviderConfig() + testAccPolicyBundleResourceConfig(prj.Org.ID, map[string]string{
+					"branches.rego": testAccBranchPolicy,
+					"images.rego":   testAccImagePolicy,
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_policy_bundle.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(prj.Org.ID.String()),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_policy_bundle.test",
+						tfjsonpath.New("policies"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"branches.rego": knownvalue.StringExact(testAccBranchPolicy),
+							"images.rego":   knownvalue.StringExact(testAccImagePolicy),
+						}),
+					),
+				},
+				Check: testAccCheckPolicyBundle(fc, prj.Org.ID, map[string]string{
+					"branches.rego": testAccBranchPolicy,
+					"images.rego":   testAccImagePolicy,
+				}),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_policy_bundle.test",
+				ImportState:       true,
+				ImportStateId:     prj.Org.ID.String(),
+				ImportStateVerify: true,
+			},
+			// Changing a policy's content and removing another updates the
+			// bundle in place
+			{
+				Config: fc.ProviderConfig() + testAccPolicyBundleResourceConfig(prj.Org.ID, map[string]string{
+					"branches.rego": testAccBranchPolicy + "\nenable_rule[\"branches\"]\n",
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_policy_bundle.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckPolicyBundle(fc, prj.Org.ID, map[string]string{
+					"branches.rego": testAccBranchPolicy + "\nenable_rule[\"branches\"]\n",
+				}),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: testAccCheckPolicyBundle(fc, prj.Org.ID, map[string]string{}),
+	})
+}
+
+func TestAccPolicyBundleResource_lineEndings(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "policy-bundle-line-endings")
+	config := fc.ProviderConfig() + testAccPolicyBundleResourceConfig(prj.Org.ID, map[string]string{
+		"branches.rego": testAccBranchPolicy,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// The same policy pushed with Windows line endings is not a change.
+			{
+				PreConfig: func() {
+					err := fc.SetPolicyBundle(prj.Org.ID, map[string]string{
+						"branches.rego": strings.ReplaceAll(testAccBranchPolicy, "\n", "\r\n"),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// A policy pushed outside of Terraform is removed.
+			{
+				PreConfig: func() {
+					err := fc.SetPolicyBundle(prj.Org.ID, map[string]string{
+						"branches.rego": testAccBranchPolicy,
+						"images.rego":   testAccImagePolicy,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_policy_bundle.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckPolicyBundle(fc, prj.Org.ID, map[string]string{
+					"branches.rego": testAccBranchPolicy,
+				}),
+			},
+		},
+	})
+}
+
+func TestAccPolicyBundleResource_invalidPolicy(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "policy-bundle-invalid")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fc.ProviderConfig() + testAccPolicyBundleResourceConfig(prj.Org.ID, map[string]string{
+					"bad.rego": "allow = true\n",
+				}),
+				ExpectError: regexp.MustCompile(`policy must declare a package`),
+			},
+			{
+				Config:      fc.ProviderConfig() + testAccPolicyBundleResourceConfig(prj.Org.ID, map[string]string{}),
+				ExpectError: regexp.MustCompile(`Attribute policies map must contain at least 1 elements`),
+			},
+		},
+	})
+}
+
+func TestAccPolicyBundleResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "policy-bundle-disappears")
+	config := testAccDefaultsConfig(fc, fmt.Sprintf("  default_organization_id = %q", prj.Org.ID), fmt.Sprintf(`
+resource "circleci_policy_bundle" "test" {
+  policies = {
+    "branches.rego" = %q
+  }
+}
+`, testAccBranchPolicy))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_policy_bundle.test",
+						tfjsonpath.New("organization_id"),
+						knownvalue.StringExact(prj.Org.ID.String()),
+					),
+				},
+				Check: testAccCheckResourceDisappears("circleci_policy_bundle.test", func(context.Context, map[string]string) error {
+					return fc.SetPolicyBundle(prj.Org.ID, nil)
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_policy_bundle.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckPolicyBundle checks the fake has want as the org's bundle.
+func testAccCheckPolicyBundle(fc *testAccFake, orgID uuid.UUID, want map[string]string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		got, err := fc.PolicyBundle(orgID)
+		if err != nil {
+			return err
+		}
+		if !maps.Equal(got, want) {
+			return fmt.Errorf("expected policy bundle %q, got %q", want, got)
+		}
+		return nil
+	}
+}
+
+func testAccPolicyBundleResourceConfig(orgID uuid.UUID, policies map[string]string) string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(policies)) {
+		fmt.Fprintf(&b, "    %q = %q\n", name, policies[name])
+	}
+	return fmt.Sprintf(`
+resource "circleci_policy_bundle" "test" {
+  organization_id = %[1]q
+  policies = {
+%[2]s  }
+}
+`, orgID, b.String())
+}
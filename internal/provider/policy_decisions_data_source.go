@@ -0,0 +1,315 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/policy"
+)
+
+// defaultPolicyDecisionsLimit is how many decisions are fetched when limit
+// is not set.
+const defaultPolicyDecisionsLimit = 100
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &policyDecisionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &policyDecisionsDataSource{}
+)
+
+// policyDecisionsDataSourceModel maps the data source schema.
+type policyDecisionsDataSourceModel struct {
+	Id             types.String                    `tfsdk:"id"`
+	OrganizationId types.String                    `tfsdk:"organization_id"`
+	Status         types.String                    `tfsdk:"status"`
+	Branch         types.String                    `tfsdk:"branch"`
+	ProjectId      types.String                    `tfsdk:"project_id"`
+	After          types.String                    `tfsdk:"after"`
+	Before         types.String                    `tfsdk:"before"`
+	Limit          types.Int64                     `tfsdk:"limit"`
+	Decisions      []policyDecisionDataSourceModel `tfsdk:"decisions"`
+}
+
+type policyDecisionDataSourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	CreatedAt    types.String                   `tfsdk:"created_at"`
+	Status       types.String                   `tfsdk:"status"`
+	Reason       types.String                   `tfsdk:"reason"`
+	ProjectId    types.String                   `tfsdk:"project_id"`
+	Branch       types.String                   `tfsdk:"branch"`
+	BuildNumber  types.Int64                    `tfsdk:"build_number"`
+	EnabledRules []types.String                 `tfsdk:"enabled_rules"`
+	HardFailures []policyFailureDataSourceModel `tfsdk:"hard_failures"`
+	SoftFailures []policyFailureDataSourceModel `tfsdk:"soft_failures"`
+	TimeTakenMS  types.Int64                    `tfsdk:"time_taken_ms"`
+}
+
+type policyFailureDataSourceModel struct {
+	Rule   types.String `tfsdk:"rule"`
+	Reason types.String `tfsdk:"reason"`
+}
+
+// NewPolicyDecisionsDataSource is a helper function to simplify the provider implementation.
+func NewPolicyDecisionsDataSource() datasource.DataSource {
+	return &policyDecisionsDataSource{}
+}
+
+// policyDecisionsDataSource is the data source implementation.
+type policyDecisionsDataSource struct {
+	client   *policy.Service
+	defaults providerDefaults
+}
+
+// Metadata returns the data source type name.
+func (d *policyDecisionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_decisions"
+}
+
+// Schema defines the schema for the data source.
+func (d *policyDecisionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	failures := schema.ListNestedAttribute{
+		Computed: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"rule": schema.StringAttribute{
+					MarkdownDescription: "The name of the rule.",
+					Computed:            true,
+				},
+				"reason": schema.StringAttribute{
+					MarkdownDescription: "Why the config failed the rule.",
+					Computed:            true,
+				},
+			},
+		},
+	}
+	hardFailures, softFailures := failures, failures
+	hardFailures.MarkdownDescription = "The rules the config failed that block the pipeline."
+	softFailures.MarkdownDescription = "The rules the config failed that do not block the pipeline."
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists entries of a CircleCI organization's config policy decision audit log, newest first.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization.",
+				Computed:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization. Defaults to the provider's `default_organization_id`.",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only list decisions with this status, one of `PASS`, `SOFT_FAIL`, `HARD_FAIL` or `ERROR`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(policy.StatusPass, policy.StatusSoftFail, policy.StatusHardFail, policy.StatusError),
+				},
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "Only list decisions for pipelines on this branch.",
+				Optional:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Only list decisions for pipelines of this project.",
+				Optional:            true,
+			},
+			"after": schema.StringAttribute{
+				MarkdownDescription: "Only list decisions made after this RFC 3339 timestamp.",
+				Optional:            true,
+				Validators: []validator.String{
+					TimestampValidator(),
+				},
+			},
+			"before": schema.StringAttribute{
+				MarkdownDescription: "Only list decisions made before this RFC 3339 timestamp.",
+				Optional:            true,
+				Validators: []validator.String{
+					TimestampValidator(),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The most decisions to list. Defaults to `%d`.", defaultPolicyDecisionsLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"decisions": schema.ListNestedAttribute{
+				MarkdownDescription: "The decisions, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the decision.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The time at which the decision was made.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The outcome of the decision, one of `PASS`, `SOFT_FAIL`, `HARD_FAIL` or `ERROR`.",
+							Computed:            true,
+						},
+						"reason": schema.StringAttribute{
+							MarkdownDescription: "Why the decision errored, if it did.",
+							Computed:            true,
+						},
+						"project_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the pipeline's project.",
+							Computed:            true,
+						},
+						"branch": schema.StringAttribute{
+							MarkdownDescription: "The branch the pipeline ran on.",
+							Computed:            true,
+						},
+						"build_number": schema.Int64Attribute{
+							MarkdownDescription: "The number of the pipeline.",
+							Computed:            true,
+						},
+						"enabled_rules": schema.ListAttribute{
+							MarkdownDescription: "The rules the config was checked against.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"hard_failures": hardFailures,
+						"soft_failures": softFailures,
+						"time_taken_ms": schema.Int64Attribute{
+							MarkdownDescription: "How long the decision took, in milliseconds.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read fetches the decisions, a page at a time, until limit are found.
+func (d *policyDecisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_policy_decisions", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state policyDecisionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OrganizationId.IsNull() {
+		if d.defaults.OrganizationID == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("organization_id"),
+				"Missing organization_id",
+				"Set organization_id, or set default_organization_id in the provider configuration.",
+			)
+			return
+		}
+		state.OrganizationId = types.StringValue(d.defaults.OrganizationID)
+	}
+
+	filter := policy.DecisionFilter{
+		Status:    state.Status.ValueString(),
+		Branch:    state.Branch.ValueString(),
+		ProjectID: state.ProjectId.ValueString(),
+	}
+	// The validators have checked the timestamps parse.
+	if !state.After.IsNull() {
+		filter.After, _ = time.Parse(time.RFC3339, state.After.ValueString())
+	}
+	if !state.Before.IsNull() {
+		filter.Before, _ = time.Parse(time.RFC3339, state.Before.ValueString())
+	}
+	limit := defaultPolicyDecisionsLimit
+	if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	orgID := state.OrganizationId.ValueString()
+	var decisions []policy.DecisionLog
+	for len(decisions) < limit {
+		filter.Offset = len(decisions)
+		page, err := d.client.ListDecisions(ctx, orgID, filter)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading CircleCI policy decisions",
+				"Could not list policy decisions of organization "+orgID+": "+err.Error(),
+			)
+			return
+		}
+		if len(page) == 0 {
+			break
+		}
+		decisions = append(decisions, page...)
+	}
+	decisions = decisions[:min(len(decisions), limit)]
+
+	state.Id = state.OrganizationId
+	state.Decisions = make([]policyDecisionDataSourceModel, 0, len(decisions))
+	for _, dl := range decisions {
+		state.Decisions = append(state.Decisions, newPolicyDecisionDataSourceModel(dl))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func newPolicyDecisionDataSourceModel(dl policy.DecisionLog) policyDecisionDataSourceModel {
+	failures := func(fs []policy.Failure) []policyFailureDataSourceModel {
+		res := make([]policyFailureDataSourceModel, 0, len(fs))
+		for _, f := range fs {
+			res = append(res, policyFailureDataSourceModel{
+				Rule:   types.StringValue(f.Rule),
+				Reason: types.StringValue(f.Reason),
+			})
+		}
+		return res
+	}
+
+	m := policyDecisionDataSourceModel{
+		Id:           types.StringValue(dl.ID),
+		CreatedAt:    types.StringValue(formatTimestamp(dl.CreatedAt)),
+		Status:       types.StringValue(dl.Decision.Status),
+		Reason:       types.StringValue(dl.Decision.Reason),
+		ProjectId:    types.StringValue(dl.Metadata.ProjectID),
+		Branch:       types.StringValue(dl.Metadata.VCS.Branch),
+		BuildNumber:  types.Int64Value(dl.Metadata.BuildNumber),
+		EnabledRules: make([]types.String, 0, len(dl.Decision.EnabledRules)),
+		HardFailures: failures(dl.Decision.HardFailures),
+		SoftFailures: failures(dl.Decision.SoftFailures),
+		TimeTakenMS:  types.Int64Value(dl.TimeTakenMS),
+	}
+	for _, rule := range dl.Decision.EnabledRules {
+		m.EnabledRules = append(m.EnabledRules, types.StringValue(rule))
+	}
+	return m
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *policyDecisionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client.PolicyService
+	d.defaults = client.Defaults
+}
@@ -0,0 +1,144 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccPolicyDecisionsDataSource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "policy-decisions")
+	fc.SetPageSize(2)
+
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i, status := range []string{
+		fakecircle.PolicyStatusPass,
+		fakecircle.PolicyStatusHardFail,
+		fakecircle.PolicyStatusPass,
+		fakecircle.PolicyStatusPass,
+		fakecircle.PolicyStatusSoftFail,
+	} {
+		d := fakecircle.PolicyDecision{
+			CreatedAt:    start.Add(time.Duration(i) * time.Hour),
+			Status:       status,
+			EnabledRules: []string{"use_official_docker_image"},
+			ProjectID:    prj.ID,
+			Branch:       "main",
+			BuildNumber:  int64(i + 1),
+			TimeTaken:    15 * time.Millisecond,
+		}
+		if status == fakecircle.PolicyStatusHardFail {
+			d.HardFailures = []fakecircle.PolicyFailure{{Rule: "use_official_docker_image", Reason: "cimg/base is required"}}
+		}
+		if _, err := fc.AddPolicyDecision(prj.Org.ID, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Every decision, across pages
+			{
+				Config: testAccDefaultsConfig(fc, fmt.Sprintf("  default_organization_id = %q", prj.Org.ID), `
+data "circleci_policy_decisions" "test" {}
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.circleci_policy_decisions.test",
+						tfjsonpath.New("decisions"),
+						knownvalue.ListSizeExact(5),
+					),
+					statecheck.ExpectKnownValue(
+						"data.circleci_policy_decisions.test",
+						tfjsonpath.New("decisions").AtSliceIndex(0),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"status":        knownvalue.StringExact("SOFT_FAIL"),
+							"build_number":  knownvalue.Int64Exact(5),
+							"project_id":    knownvalue.StringExact(prj.ID.String()),
+							"branch":        knownvalue.StringExact("main"),
+							"created_at":    knownvalue.StringExact("2026-05-01T04:00:00.000Z"),
+							"time_taken_ms": knownvalue.Int64Exact(15),
+							"enabled_rules": knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("use_official_docker_image")}),
+						}),
+					),
+				},
+			},
+			// Filters and limit
+			{
+				Config: fc.ProviderConfig() + fmt.Sprintf(`
+data "circleci_policy_decisions" "test" {
+  organization_id = %[1]q
+  status          = "HARD_FAIL"
+  branch          = "main"
+}
+
+data "circleci_policy_decisions" "limited" {
+  organization_id = %[1]q
+  status          = "PASS"
+  after           = "2026-05-01T00:00:00Z"
+  limit           = 1
+}
+`, prj.Org.ID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.circleci_policy_decisions.test",
+						tfjsonpath.New("decisions"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"build_number": knownvalue.Int64Exact(2),
+								"hard_failures": knownvalue.ListExact([]knownvalue.Check{
+									knownvalue.ObjectExact(map[string]knownvalue.Check{
+										"rule":   knownvalue.StringExact("use_official_docker_image"),
+										"reason": knownvalue.StringExact("cimg/base is required"),
+									}),
+								}),
+								"soft_failures": knownvalue.ListSizeExact(0),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.circleci_policy_decisions.limited",
+						tfjsonpath.New("decisions"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"build_number": knownvalue.Int64Exact(4),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccPolicyDecisionsDataSource_invalidTimestamp(t *testing.T) {
+	fc := testAccFakeCircle(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fc.ProviderConfig() + `
+data "circleci_policy_decisions" "test" {
+  organization_id = "4a5b6c7d-0000-4000-8000-000000000000"
+  after           = "2026-05-01"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Timestamp`),
+			},
+		},
+	})
+}
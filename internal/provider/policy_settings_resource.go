@@ -0,0 +1,219 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/policy"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &policySettingsResource{}
+	_ resource.ResourceWithConfigure   = &policySettingsResource{}
+	_ resource.ResourceWithModifyPlan  = &policySettingsResource{}
+	_ resource.ResourceWithImportState = &policySettingsResource{}
+)
+
+// policySettingsResourceModel maps the resource schema.
+type policySettingsResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+}
+
+// NewPolicySettingsResource is a helper function to simplify the provider implementation.
+func NewPolicySettingsResource() resource.Resource {
+	return &policySettingsResource{}
+}
+
+// policySettingsResource is the resource implementation.
+type policySettingsResource struct {
+	client   *policy.Service
+	defaults providerDefaults
+}
+
+// Metadata returns the resource type name.
+func (r *policySettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_settings"
+}
+
+// Schema defines the schema for the resource.
+func (r *policySettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the config policy decision settings of a CircleCI organization. " +
+			"Destroying the resource turns checking off.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization. Defaults to the provider's `default_organization_id`. Changing this value forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether pipelines' config is checked against the organization's policy bundle.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+// Create sets the settings and sets the initial Terraform state.
+func (r *policySettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_settings", "Create")
+	defer end(&resp.Diagnostics)
+
+	var plan policySettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *policySettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_settings", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state policySettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.GetSettings(ctx, state.OrganizationId.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading CircleCI policy settings",
+			"Could not read policy settings of organization "+state.OrganizationId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Id = state.OrganizationId
+	state.Enabled = types.BoolValue(settings.Enabled)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sets the settings in the plan.
+func (r *policySettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_settings", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan policySettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// apply makes the organization's settings those in plan, and maps the result
+// onto it.
+func (r *policySettingsResource) apply(ctx context.Context, plan *policySettingsResourceModel, diags *diag.Diagnostics) {
+	orgID := plan.OrganizationId.ValueString()
+	settings, err := r.client.UpdateSettings(ctx, orgID, policy.Settings{Enabled: plan.Enabled.ValueBool()})
+	if err != nil {
+		diags.AddError(
+			"Error setting CircleCI policy settings",
+			"Could not set policy settings of organization "+orgID+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = plan.OrganizationId
+	plan.Enabled = types.BoolValue(settings.Enabled)
+}
+
+// Delete turns checking off.
+func (r *policySettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_policy_settings", "Delete")
+	defer end(&resp.Diagnostics)
+
+	var state policySettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.UpdateSettings(ctx, state.OrganizationId.ValueString(), policy.Settings{Enabled: false})
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting CircleCI policy settings",
+			"Could not turn off policy checking of organization "+state.OrganizationId.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *policySettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, true)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *policySettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.PolicyService
+	r.defaults = client.Defaults
+}
+
+// ImportState imports the settings of the organization with the given ID.
+func (r *policySettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), req.ID)...)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPolicySettingsResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "policy-settings")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fc.ProviderConfig() + testAccPolicySettingsResourceConfig(prj.Org.ID, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_policy_settings.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(prj.Org.ID.String()),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_policy_settings.test",
+						tfjsonpath.New("enabled"),
+						knownvalue.Bool(true),
+					),
+				},
+				Check: testAccCheckPolicyDecisionsEnabled(fc, prj.Org.ID, true),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_policy_settings.test",
+				ImportState:       true,
+				ImportStateId:     prj.Org.ID.String(),
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: fc.ProviderConfig() + testAccPolicySettingsResourceConfig(prj.Org.ID, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_policy_settings.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckPolicyDecisionsEnabled(fc, prj.Org.ID, false),
+			},
+			// Changes outside of Terraform are reverted
+			{
+				PreConfig: func() {
+					if err := fc.SetPolicyDecisionsEnabled(prj.Org.ID, true); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fc.ProviderConfig() + testAccPolicySettingsResourceConfig(prj.Org.ID, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_policy_settings.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckPolicyDecisionsEnabled(fc, prj.Org.ID, false),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPolicySettingsResource_deleteDisables(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "policy-settings-delete")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefaultsConfig(fc, fmt.Sprintf("  default_organization_id = %q", prj.Org.ID), `
+resource "circleci_policy_settings" "test" {
+  enabled = true
+}
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_policy_settings.test",
+						tfjsonpath.New("organization_id"),
+						knownvalue.StringExact(prj.Org.ID.String()),
+					),
+				},
+				Check: testAccCheckPolicyDecisionsEnabled(fc, prj.Org.ID, true),
+			},
+		},
+		CheckDestroy: testAccCheckPolicyDecisionsEnabled(fc, prj.Org.ID, false),
+	})
+}
+
+// testAccCheckPolicyDecisionsEnabled checks whether the fake has the org's
+// decisions enabled.
+func testAccCheckPolicyDecisionsEnabled(fc *testAccFake, orgID uuid.UUID, want bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		got, err := fc.PolicyDecisionsEnabled(orgID)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return fmt.Errorf("expected policy decisions enabled to be %t, got %t", want, got)
+		}
+		return nil
+	}
+}
+
+func testAccPolicySettingsResourceConfig(orgID uuid.UUID, enabled bool) string {
+	return fmt.Sprintf(`
+resource "circleci_policy_settings" "test" {
+  organization_id = %[1]q
+  enabled         = %[2]t
+}
+`, orgID, enabled)
+}
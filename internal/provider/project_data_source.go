@@ -113,6 +113,9 @@ func (d *ProjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_project", "Read")
+	defer end(&resp.Diagnostics)
+
 	if d.client == nil {
 		resp.Diagnostics.AddError(
 			"Unconfigured HTTP Client",
@@ -71,6 +71,9 @@ func (d *ProjectEnvironmentVariableDataSource) Schema(_ context.Context, _ datas
 
 // Read refreshes the Terraform state with the latest data.
 func (d *ProjectEnvironmentVariableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_project_environment_variable", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state projectEnvironmentVariableDataSourceModel
 	diags := req.Config.Get(ctx, &state)
 	if diags != nil {
@@ -8,29 +8,37 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/envproject"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &projectEnvironmentVariableResource{}
-	_ resource.ResourceWithConfigure   = &projectEnvironmentVariableResource{}
-	_ resource.ResourceWithImportState = &projectEnvironmentVariableResource{}
+	_ resource.Resource                     = &projectEnvironmentVariableResource{}
+	_ resource.ResourceWithConfigure        = &projectEnvironmentVariableResource{}
+	_ resource.ResourceWithConfigValidators = &projectEnvironmentVariableResource{}
+	_ resource.ResourceWithImportState      = &projectEnvironmentVariableResource{}
 )
 
 // projectEnvironmentVariableResourceModel maps the resource schema.
 type projectEnvironmentVariableResourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Value       types.String `tfsdk:"value"`
-	ProjectSlug types.String `tfsdk:"project_slug"`
-	CreatedAt   types.String `tfsdk:"created_at"`
+	Name           types.String `tfsdk:"name"`
+	Value          types.String `tfsdk:"value"`
+	ValueWo        types.String `tfsdk:"value_wo"`
+	ValueWoVersion types.Int64  `tfsdk:"value_wo_version"`
+	ProjectSlug    types.String `tfsdk:"project_slug"`
+	CreatedAt      types.String `tfsdk:"created_at"`
 }
 
 // NewProjectEnvironmentVariableResource is a helper function to simplify the provider implementation.
@@ -61,11 +69,27 @@ func (r *projectEnvironmentVariableResource) Schema(_ context.Context, _ resourc
 				},
 			},
 			"value": schema.StringAttribute{
-				MarkdownDescription: "The value of the environment variable. Changing this value forces a new resource to be created.",
-				Required:            true,
+				MarkdownDescription: "The value of the environment variable. It is stored in state; use `value_wo` to keep it out. Exactly one of `value` or `value_wo` must be set.",
+				Optional:            true,
 				Sensitive:           true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				MarkdownDescription: "The value of the environment variable, which is never stored in state or plan files. Requires Terraform 1.11 or later. Terraform cannot tell when it changes, so change `value_wo_version` to write a new value.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("value")),
+				},
+			},
+			"value_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version for `value_wo`. Changing it writes the current `value_wo` to CircleCI.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("value_wo")),
 				},
 			},
 			"project_slug": schema.StringAttribute{
@@ -83,8 +107,18 @@ func (r *projectEnvironmentVariableResource) Schema(_ context.Context, _ resourc
 	}
 }
 
+// ConfigValidators returns the validators that check the resource's configuration as a whole.
+func (r *projectEnvironmentVariableResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(path.MatchRoot("value"), path.MatchRoot("value_wo")),
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *projectEnvironmentVariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variable", "Create")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan projectEnvironmentVariableResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -93,8 +127,14 @@ func (r *projectEnvironmentVariableResource) Create(ctx context.Context, req res
 		return
 	}
 
+	value, diags := writeOnlyValue(ctx, req.Config, plan.Value, path.Root("value_wo"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create new project environment variable
-	newEnvVar, err := r.client.Create(ctx, plan.ProjectSlug.ValueString(), plan.Value.ValueString(), plan.Name.ValueString())
+	newEnvVar, err := r.client.Create(ctx, plan.ProjectSlug.ValueString(), value, plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating CircleCI project environment variable",
@@ -120,6 +160,9 @@ func (r *projectEnvironmentVariableResource) Create(ctx context.Context, req res
 
 // Read refreshes the Terraform state with the latest data.
 func (r *projectEnvironmentVariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variable", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state projectEnvironmentVariableResourceModel
 	diags := req.State.Get(ctx, &state)
 	if diags != nil {
@@ -128,11 +171,11 @@ func (r *projectEnvironmentVariableResource) Read(ctx context.Context, req resou
 	}
 
 	envVar, err := r.client.Get(ctx, state.ProjectSlug.ValueString(), state.Name.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
-			resp.State.RemoveResource(ctx)
-			return
-		}
 		resp.Diagnostics.AddError(
 			"Error Reading CircleCI Project Environment Variable",
 			"Could not read project environment variable "+state.Name.ValueString()+": "+err.Error(),
@@ -156,12 +199,49 @@ func (r *projectEnvironmentVariableResource) Read(ctx context.Context, req resou
 	}
 }
 
-// Update updates the resource and sets the updated Terraform state on success.
+// Update writes the value again, which the create endpoint overwrites in
+// place, so the variable is never missing.
 func (r *projectEnvironmentVariableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variable", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan projectEnvironmentVariableResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value, diags := writeOnlyValue(ctx, req.Config, plan.Value, path.Root("value_wo"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.Create(ctx, plan.ProjectSlug.ValueString(), value, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating CircleCI project environment variable",
+			"Could not update CircleCI project environment variable, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !updated.CreatedAt.IsZero() {
+		plan.CreatedAt = types.StringValue(updated.CreatedAt.Format("2006-01-02T15:04:05.000Z"))
+	} else {
+		plan.CreatedAt = types.StringValue("")
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *projectEnvironmentVariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variable", "Delete")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state projectEnvironmentVariableResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -172,7 +252,7 @@ func (r *projectEnvironmentVariableResource) Delete(ctx context.Context, req res
 
 	// Delete existing project environment variable
 	err := r.client.Delete(ctx, state.ProjectSlug.ValueString(), state.Name.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCi Project Environment Variable",
 			"Could not delete project environment variable, unexpected error: "+err.Error(),
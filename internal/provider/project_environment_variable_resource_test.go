@@ -4,16 +4,23 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-circleci/internal/circleci/envproject"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
 )
 
 func TestAccProjectEnvironmentVariableResource(t *testing.T) {
@@ -92,7 +99,7 @@ func TestAccProjectEnvironmentVariableResourceUpdate(t *testing.T) {
 					),
 				},
 			},
-			// Update (triggers replace) and Read testing
+			// Update in place and Read testing
 			{
 				Config: testAccProjectEnvironmentVariableResourceConfig(name, updatedValue, "circleci/8e4z1Akd74woxagxnvLT5q/CzMcAU8dvQo4FJhyj87QsA"),
 				ConfigStateChecks: []statecheck.StateCheck{
@@ -122,3 +129,170 @@ resource "circleci_project_environment_variable" "test_env" {
 }
 `, name, value, projectSlug)
 }
+
+func TestAccProjectEnvironmentVariableResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + testAccProjectEnvironmentVariableResourceConfig("DISAPPEARS", "value", prj.Slug)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_project_environment_variable.test_env", func(ctx context.Context, attrs map[string]string) error {
+					return envproject.NewEnvService(fc.Client).Delete(ctx, attrs["project_slug"], attrs["name"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_environment_variable.test_env", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccProjectEnvironmentVariableResource_writeOnly(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "write-only",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "write-only",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(value string, version int) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_project_environment_variable" "test_env" {
+  project_slug     = %[1]q
+  name             = "WRITE_ONLY"
+  value_wo         = %[2]q
+  value_wo_version = %[3]d
+}
+`, prj.Slug, value, version)
+	}
+	const postPattern = "/project/*/*/*/envvar"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("first", 1),
+				Check:  fc.CheckLastBody(http.MethodPost, postPattern, "value", "first"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_project_environment_variable.test_env",
+						tfjsonpath.New("value_wo"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				Config: config("second", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config("second", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_environment_variable.test_env", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					fc.CheckLastBody(http.MethodPost, postPattern, "value", "second"),
+					// The value is overwritten in place, never deleted first.
+					fc.CheckCallCount(http.MethodDelete, postPattern+"/*", 0),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectEnvironmentVariableResource_updateValue(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "update-value",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "update-value",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(value string) string {
+		return fc.ProviderConfig() + testAccProjectEnvironmentVariableResourceConfig("UPDATE_VALUE", value, prj.Slug)
+	}
+	const postPattern = "/project/*/*/*/envvar"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("first"),
+				Check:  fc.CheckLastBody(http.MethodPost, postPattern, "value", "first"),
+			},
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config("second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_environment_variable.test_env", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					fc.CheckLastBody(http.MethodPost, postPattern, "value", "second"),
+					// The value is overwritten in place, never deleted first.
+					fc.CheckCallCount(http.MethodDelete, postPattern+"/*", 0),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_project_environment_variable.test_env",
+						tfjsonpath.New("value"),
+						knownvalue.StringExact("second"),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,393 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/envproject"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &projectEnvironmentVariablesResource{}
+	_ resource.ResourceWithConfigure   = &projectEnvironmentVariablesResource{}
+	_ resource.ResourceWithImportState = &projectEnvironmentVariablesResource{}
+)
+
+// projectEnvironmentVariablesPrivateKey is the private state key under which
+// Create and Update record the hash of the value CircleCI reported for each
+// variable they wrote. The API masks values, so this is the hash of the
+// masked value, which changes when someone else writes a new value.
+const projectEnvironmentVariablesPrivateKey = "written"
+
+// projectEnvironmentVariablesResourceModel maps the resource schema.
+type projectEnvironmentVariablesResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	ProjectSlug types.String `tfsdk:"project_slug"`
+	Variables   types.Map    `tfsdk:"variables"`
+	Exclusive   types.Bool   `tfsdk:"exclusive"`
+}
+
+// NewProjectEnvironmentVariablesResource is a helper function to simplify the provider implementation.
+func NewProjectEnvironmentVariablesResource() resource.Resource {
+	return &projectEnvironmentVariablesResource{}
+}
+
+// projectEnvironmentVariablesResource is the resource implementation.
+type projectEnvironmentVariablesResource struct {
+	client *envproject.EnvService
+}
+
+// Metadata returns the resource type name.
+func (r *projectEnvironmentVariablesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_environment_variables"
+}
+
+// Schema defines the schema for the resource.
+func (r *projectEnvironmentVariablesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of environment variables stored in a CircleCI project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The project slug.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "The project slug in the format `vcs-type/org-name/repo-name`. Changing this value forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "The environment variables, keyed by name.",
+				Required:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"exclusive": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the project's other environment variables, those not in `variables`. Defaults to `false`, which leaves them alone.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *projectEnvironmentVariablesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variables", "Create")
+	defer end(&resp.Diagnostics)
+
+	var plan projectEnvironmentVariablesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vars, hashes, diags := r.apply(ctx, nil, nil, plan)
+	resp.Diagnostics.Append(diags...)
+	if vars == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, vars)...)
+	resp.Diagnostics.Append(setProjectEnvironmentVariablesHashes(ctx, resp.Private, hashes)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectEnvironmentVariablesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variables", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state projectEnvironmentVariablesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	envVars, err := r.client.List(ctx, state.ProjectSlug.ValueString())
+	if client.IsNotFound(err) {
+		// The project was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading CircleCI Project Environment Variables",
+			"Could not read the environment variables of project "+state.ProjectSlug.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var vars map[string]string
+	resp.Diagnostics.Append(state.Variables.ElementsAs(ctx, &vars, false)...)
+	hashes, diags := readProjectEnvironmentVariablesHashes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API masks values, so the values in state are kept unless the
+	// masked value is no longer the one reported when Terraform wrote it.
+	// Such a value, and any variable only CircleCI knows about, is given an
+	// empty value so that the plan writes or deletes it. Unknown variables
+	// are only kept when exclusive. Otherwise they are not Terraform's, even
+	// right after an import, which is never exclusive: the first apply then
+	// writes just the configured variables.
+	adopt := state.Exclusive.ValueBool()
+	refreshed := make(map[string]string, len(envVars))
+	for _, ev := range envVars {
+		value, ok := vars[ev.Name]
+		switch {
+		case !ok && !adopt:
+			continue
+		case !ok:
+			value = ""
+		case hashes[ev.Name] != "" && hashes[ev.Name] != hashEnvironmentVariable(ev.Value):
+			// Written again outside of Terraform.
+			value = ""
+		}
+		refreshed[ev.Name] = value
+	}
+
+	state.Id = state.ProjectSlug
+	state.Variables, diags = types.MapValueFrom(ctx, types.StringType, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *projectEnvironmentVariablesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variables", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan, state projectEnvironmentVariablesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var current map[string]string
+	resp.Diagnostics.Append(state.Variables.ElementsAs(ctx, &current, false)...)
+	hashes, diags := readProjectEnvironmentVariablesHashes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vars, hashes, diags := r.apply(ctx, current, hashes, plan)
+	resp.Diagnostics.Append(diags...)
+	if vars == nil {
+		// Nothing was written, so the state is unchanged.
+		resp.State.Raw = req.State.Raw
+		return
+	}
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, vars)...)
+	resp.Diagnostics.Append(setProjectEnvironmentVariablesHashes(ctx, resp.Private, hashes)...)
+}
+
+// apply writes the variables in plan, deletes those in current that are not,
+// and when exclusive deletes any others the project has. It returns the
+// variables as they now are, even if some of the requests failed, or nil if
+// none were made, along with hashes updated for the variables written.
+func (r *projectEnvironmentVariablesResource) apply(ctx context.Context, current, hashes map[string]string, plan projectEnvironmentVariablesResourceModel) (map[string]string, map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	projectSlug := plan.ProjectSlug.ValueString()
+
+	var vars map[string]string
+	diags.Append(plan.Variables.ElementsAs(ctx, &vars, false)...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	var extra []string
+	if plan.Exclusive.ValueBool() {
+		envVars, err := r.client.List(ctx, projectSlug)
+		if err != nil {
+			diags.AddError(
+				"Error Reading CircleCI Project Environment Variables",
+				"Could not read the environment variables of project "+projectSlug+": "+err.Error(),
+			)
+			return nil, nil, diags
+		}
+		for _, ev := range envVars {
+			extra = append(extra, ev.Name)
+		}
+	}
+
+	var mu sync.Mutex
+	written := make(map[string]string)
+	applied, err := applyEnvironmentVariables(ctx, current, diffEnvironmentVariables(current, vars, extra),
+		func(ctx context.Context, name, value string) error {
+			ev, err := r.client.Create(ctx, projectSlug, value, name)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			written[name] = hashEnvironmentVariable(ev.Value)
+			return nil
+		},
+		func(ctx context.Context, name string) error {
+			err := r.client.Delete(ctx, projectSlug, name)
+			if client.IsNotFound(err) {
+				return nil
+			}
+			return err
+		},
+	)
+	if err != nil {
+		diags.AddError(
+			"Error Writing CircleCI Project Environment Variables",
+			"Could not write every environment variable of project "+projectSlug+": "+err.Error(),
+		)
+	}
+
+	newHashes := make(map[string]string, len(applied))
+	for name := range applied {
+		if h, ok := written[name]; ok {
+			newHashes[name] = h
+		} else if h, ok := hashes[name]; ok {
+			newHashes[name] = h
+		}
+	}
+	return applied, newHashes, diags
+}
+
+// setState sets state to plan with the variables in vars.
+func (r *projectEnvironmentVariablesResource) setState(ctx context.Context, state *tfsdk.State, plan projectEnvironmentVariablesResourceModel, vars map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	plan.Id = plan.ProjectSlug
+	plan.Variables, diags = types.MapValueFrom(ctx, types.StringType, vars)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(state.Set(ctx, &plan)...)
+	return diags
+}
+
+// readProjectEnvironmentVariablesHashes returns the hashes recorded in
+// private, keyed by variable name.
+func readProjectEnvironmentVariablesHashes(ctx context.Context, private privateState) (map[string]string, diag.Diagnostics) {
+	b, diags := private.GetKey(ctx, projectEnvironmentVariablesPrivateKey)
+	if diags.HasError() || len(b) == 0 {
+		return nil, diags
+	}
+
+	var hashes map[string]string
+	if err := json.Unmarshal(b, &hashes); err != nil {
+		diags.AddError("Error Reading CircleCI Project Environment Variables Private State", err.Error())
+		return nil, diags
+	}
+	return hashes, diags
+}
+
+// setProjectEnvironmentVariablesHashes records hashes in private.
+func setProjectEnvironmentVariablesHashes(ctx context.Context, private interface {
+	SetKey(context.Context, string, []byte) diag.Diagnostics
+}, hashes map[string]string) diag.Diagnostics {
+	// Marshalling a map of strings cannot fail.
+	b, _ := json.Marshal(hashes)
+	return private.SetKey(ctx, projectEnvironmentVariablesPrivateKey, b)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectEnvironmentVariablesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_environment_variables", "Delete")
+	defer end(&resp.Diagnostics)
+
+	var state projectEnvironmentVariablesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var vars map[string]string
+	resp.Diagnostics.Append(state.Variables.ElementsAs(ctx, &vars, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := state.ProjectSlug.ValueString()
+	_, err := applyEnvironmentVariables(ctx, vars, diffEnvironmentVariables(vars, nil, nil), nil,
+		func(ctx context.Context, name string) error {
+			err := r.client.Delete(ctx, projectSlug, name)
+			if client.IsNotFound(err) {
+				return nil
+			}
+			return err
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting CircleCI Project Environment Variables",
+			"Could not delete every environment variable of project "+projectSlug+": "+err.Error(),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectEnvironmentVariablesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.ProjectEnvironmentVariableService
+}
+
+// ImportState imports a project's environment variables, by its slug.
+func (r *projectEnvironmentVariablesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			"Expected import ID format: 'project_slug'. Got an empty ID.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exclusive"), false)...)
+	resp.Diagnostics.AddWarning(
+		"Project environment variable values cannot be read from API",
+		"CircleCI masks project environment variable values, so no variables are imported, and the first apply writes every value in your configuration. The project's other variables are left alone, unless exclusive is set, when the first apply deletes them.",
+	)
+}
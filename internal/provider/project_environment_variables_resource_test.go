@@ -0,0 +1,203 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-circleci/internal/circleci/envproject"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccProjectEnvironmentVariablesResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "bulk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "bulk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fc.AddProjectEnv(prj.ID, fakecircle.NewEnvVarProject{Name: "UNMANAGED", Value: "x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(exclusive bool, vars string) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_project_environment_variables" "test" {
+  project_slug = %[1]q
+  exclusive    = %[2]t
+  variables    = {
+%[3]s
+  }
+}
+`, prj.Slug, exclusive, vars)
+	}
+	// checkNames checks the project has exactly the named variables.
+	checkNames := func(want ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			vars, err := envproject.NewEnvService(fc.Client).List(t.Context(), prj.Slug)
+			if err != nil {
+				return err
+			}
+			var got []string
+			for _, v := range vars {
+				got = append(got, v.Name)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, want) {
+				return fmt.Errorf("project has the variables %v, want %v", got, want)
+			}
+			return nil
+		}
+	}
+	const postPattern = "/project/*/*/*/envvar"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: fc.ResetCalls,
+				Config: config(false, `
+    A = "1"
+    B = "2"`),
+				Check: resource.ComposeTestCheckFunc(
+					checkNames("A", "B", "UNMANAGED"),
+					fc.CheckCallCount(http.MethodPost, postPattern, 2),
+				),
+			},
+			// A value written outside of Terraform is written again.
+			{
+				PreConfig: func() {
+					if _, err := fc.PutProjectEnv(prj.ID, fakecircle.NewEnvVarProject{Name: "B", Value: "rotated"}); err != nil {
+						t.Fatal(err)
+					}
+					fc.ResetCalls()
+				},
+				Config: config(false, `
+    A = "1"
+    B = "2"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_environment_variables.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					fc.CheckCallCount(http.MethodPost, postPattern, 1),
+					fc.CheckLastBody(http.MethodPost, postPattern, "value", "2"),
+				),
+			},
+			{
+				Config: config(true, `
+    A = "1"
+    C = "3"`),
+				Check: checkNames("A", "C"),
+			},
+			{
+				ResourceName:            "circleci_project_environment_variables.test",
+				ImportState:             true,
+				ImportStateId:           prj.Slug,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclusive", "variables"},
+			},
+		},
+		CheckDestroy: checkNames(),
+	})
+}
+
+func TestAccProjectEnvironmentVariablesResource_importNonExclusive(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "bulk-import",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "bulk-import",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"A", "UNMANAGED", "ALSO_UNMANAGED"} {
+		if _, err := fc.AddProjectEnv(prj.ID, fakecircle.NewEnvVarProject{Name: name, Value: "x"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_project_environment_variables" "test" {
+  project_slug = %[1]q
+  exclusive    = false
+  variables    = {
+    A = "1"
+    B = "2"
+  }
+}
+`, prj.Slug)
+	const postPattern = "/project/*/*/*/envvar"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "circleci_project_environment_variables.test",
+				ImportState:        true,
+				ImportStateId:      prj.Slug,
+				ImportStatePersist: true,
+			},
+			// The first apply writes the configured variables and leaves the
+			// others alone.
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					fc.CheckCallCount(http.MethodPost, postPattern, 2),
+					fc.CheckCallCount(http.MethodDelete, postPattern+"/*", 0),
+					func(*terraform.State) error {
+						vars, err := envproject.NewEnvService(fc.Client).List(t.Context(), prj.Slug)
+						if err != nil {
+							return err
+						}
+						var got []string
+						for _, v := range vars {
+							got = append(got, v.Name)
+						}
+						slices.Sort(got)
+						if want := []string{"A", "ALSO_UNMANAGED", "B", "UNMANAGED"}; !slices.Equal(got, want) {
+							return fmt.Errorf("project has the variables %v, want %v", got, want)
+						}
+						return nil
+					},
+				),
+			},
+			// Later plans leave them alone too.
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
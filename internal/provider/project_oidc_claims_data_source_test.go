@@ -0,0 +1,58 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccProjectOIDCClaimsDataSource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "project-oidc-claims-data")
+	if err := fc.SetOrgOIDCClaims(prj.Org.ID, fakecircle.OIDCClaims{Audience: []string{"org"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fc.SetProjectOIDCClaims(prj.ID, fakecircle.OIDCClaims{TTL: "45m"}); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefaultsConfig(fc, fmt.Sprintf("  default_organization_id = %q", prj.Org.ID), fmt.Sprintf(`
+data "circleci_project_oidc_claims" "test" {
+  project_id = %[1]q
+}
+`, prj.ID)),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.circleci_project_oidc_claims.test",
+						tfjsonpath.New("organization_id"),
+						knownvalue.StringExact(prj.Org.ID.String()),
+					),
+					statecheck.ExpectKnownValue(
+						"data.circleci_project_oidc_claims.test",
+						tfjsonpath.New("ttl"),
+						knownvalue.StringExact("45m"),
+					),
+					// The org's audience is not the project's.
+					statecheck.ExpectKnownValue(
+						"data.circleci_project_oidc_claims.test",
+						tfjsonpath.New("audience"),
+						knownvalue.Null(),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,309 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/oidc"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &projectOIDCClaimsResource{}
+	_ resource.ResourceWithConfigure        = &projectOIDCClaimsResource{}
+	_ resource.ResourceWithConfigValidators = &projectOIDCClaimsResource{}
+	_ resource.ResourceWithModifyPlan       = &projectOIDCClaimsResource{}
+	_ resource.ResourceWithImportState      = &projectOIDCClaimsResource{}
+)
+
+// projectOIDCClaimsResourceModel maps the resource schema.
+type projectOIDCClaimsResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	OrganizationId    types.String `tfsdk:"organization_id"`
+	ProjectId         types.String `tfsdk:"project_id"`
+	Audience          types.Set    `tfsdk:"audience"`
+	TTL               types.String `tfsdk:"ttl"`
+	AudienceUpdatedAt types.String `tfsdk:"audience_updated_at"`
+	TTLUpdatedAt      types.String `tfsdk:"ttl_updated_at"`
+}
+
+// setClaims maps the project's claims onto the model.
+func (m *projectOIDCClaimsResourceModel) setClaims(ctx context.Context, claims *oidc.Claims) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = m.ProjectId
+	m.Audience, diags = oidcAudienceValue(ctx, claims.Audience)
+	m.TTL = oidcTTLValue(claims.TTL, m.TTL)
+	m.AudienceUpdatedAt = oidcUpdatedAtValue(claims.AudienceUpdatedAt)
+	m.TTLUpdatedAt = oidcUpdatedAtValue(claims.TTLUpdatedAt)
+	return diags
+}
+
+// NewProjectOIDCClaimsResource is a helper function to simplify the provider implementation.
+func NewProjectOIDCClaimsResource() resource.Resource {
+	return &projectOIDCClaimsResource{}
+}
+
+// projectOIDCClaimsResource is the resource implementation.
+type projectOIDCClaimsResource struct {
+	client   *oidc.Service
+	defaults providerDefaults
+}
+
+// Metadata returns the resource type name.
+func (r *projectOIDCClaimsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_oidc_claims"
+}
+
+// Schema defines the schema for the resource.
+func (r *projectOIDCClaimsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the custom claims of the OIDC tokens of a CircleCI project's jobs, overriding the organization's. " +
+			"Claims left out of the configuration are unset, so tokens use the organization's claims, or CircleCI's default, for them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization that owns the project. Defaults to the provider's `default_organization_id`. Changing this value forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project. Changing this value forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"audience": schema.SetAttribute{
+				MarkdownDescription: "The audiences (`aud` claim) of the tokens, such as `sts.amazonaws.com`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the tokens are valid for, as a duration between `5m` and `24h` such as `\"1h\"`.",
+				Optional:            true,
+				Validators: []validator.String{
+					DurationValidator(oidcMinTTL, oidcMaxTTL),
+				},
+			},
+			"audience_updated_at": schema.StringAttribute{
+				MarkdownDescription: "The time at which the audience was last set.",
+				Computed:            true,
+			},
+			"ttl_updated_at": schema.StringAttribute{
+				MarkdownDescription: "The time at which the ttl was last set.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ConfigValidators requires at least one claim to be set.
+func (r *projectOIDCClaimsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("audience"),
+			path.MatchRoot("ttl"),
+		),
+	}
+}
+
+// Create sets the claims and sets the initial Terraform state.
+func (r *projectOIDCClaimsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_oidc_claims", "Create")
+	defer end(&resp.Diagnostics)
+
+	var plan projectOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectOIDCClaimsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_oidc_claims", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state projectOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	claims, err := r.client.GetProjectClaims(ctx, state.OrganizationId.ValueString(), state.ProjectId.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading CircleCI project OIDC claims",
+			"Could not read OIDC claims of project "+state.ProjectId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(claims.Audience) == 0 && claims.TTL == "" {
+		// Every claim was unset outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setClaims(ctx, claims)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sets the claims in the plan and unsets the rest.
+func (r *projectOIDCClaimsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_oidc_claims", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan projectOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// apply makes the project's claims those in plan, and maps the result
+// onto it.
+func (r *projectOIDCClaimsResource) apply(ctx context.Context, plan *projectOIDCClaimsResourceModel, diags *diag.Diagnostics) {
+	u, d := newOIDCClaimsUpdate(ctx, plan.Audience, plan.TTL)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	orgID, projectID := plan.OrganizationId.ValueString(), plan.ProjectId.ValueString()
+	claims, err := applyOIDCClaims(ctx, u,
+		func(ctx context.Context, u oidc.Update) (*oidc.Claims, error) {
+			return r.client.UpdateProjectClaims(ctx, orgID, projectID, u)
+		},
+		func(ctx context.Context, names ...string) (*oidc.Claims, error) {
+			return r.client.DeleteProjectClaims(ctx, orgID, projectID, names...)
+		},
+	)
+	if err != nil {
+		diags.AddError(
+			"Error setting CircleCI project OIDC claims",
+			"Could not set OIDC claims of project "+projectID+": "+err.Error(),
+		)
+		return
+	}
+
+	diags.Append(plan.setClaims(ctx, claims)...)
+}
+
+// Delete unsets the claims, so tokens use the organization's again.
+func (r *projectOIDCClaimsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_oidc_claims", "Delete")
+	defer end(&resp.Diagnostics)
+
+	var state projectOIDCClaimsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DeleteProjectClaims(ctx, state.OrganizationId.ValueString(), state.ProjectId.ValueString(), oidc.ClaimAudience, oidc.ClaimTTL)
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting CircleCI project OIDC claims",
+			"Could not unset OIDC claims of project "+state.ProjectId.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *projectOIDCClaimsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, true)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectOIDCClaimsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.OIDCService
+	r.defaults = client.Defaults
+}
+
+// ImportState imports the claims of a project. The import ID format is
+// "organization_id/project_id".
+func (r *projectOIDCClaimsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, projectID, ok := strings.Cut(req.ID, "/")
+	if !ok || orgID == "" || projectID == "" || strings.Contains(projectID, "/") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			fmt.Sprintf("Expected import ID format: 'organization_id/project_id'. Got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), orgID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+}
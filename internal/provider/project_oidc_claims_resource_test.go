@@ -0,0 +1,162 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccProjectOIDCClaimsResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "project-oidc-claims")
+	other, err := fc.AddProject(fakecircle.NewProject{OrgID: prj.Org.ID, Name: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fc.ProviderConfig() + testAccProjectOIDCClaimsResourceConfig(prj.Org.ID, prj.ID, `audience = ["sts.amazonaws.com"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_project_oidc_claims.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(prj.ID.String()),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_project_oidc_claims.test",
+						tfjsonpath.New("audience"),
+						knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("sts.amazonaws.com")}),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_project_oidc_claims.test",
+						tfjsonpath.New("ttl"),
+						knownvalue.Null(),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckProjectOIDCClaims(fc, prj.ID, fakecircle.OIDCClaims{Audience: []string{"sts.amazonaws.com"}}),
+					// The org's claims are left alone.
+					testAccCheckOrgOIDCClaims(fc, prj.Org.ID, fakecircle.OIDCClaims{}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_project_oidc_claims.test",
+				ImportState:       true,
+				ImportStateId:     prj.Org.ID.String() + "/" + prj.ID.String(),
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: fc.ProviderConfig() + testAccProjectOIDCClaimsResourceConfig(prj.Org.ID, prj.ID, `
+  audience = ["sts.amazonaws.com"]
+  ttl      = "15m"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_oidc_claims.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckProjectOIDCClaims(fc, prj.ID, fakecircle.OIDCClaims{Audience: []string{"sts.amazonaws.com"}, TTL: "15m"}),
+			},
+			// Changing the project replaces the claims
+			{
+				Config: fc.ProviderConfig() + testAccProjectOIDCClaimsResourceConfig(prj.Org.ID, other.ID, `ttl = "15m"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_oidc_claims.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckProjectOIDCClaims(fc, prj.ID, fakecircle.OIDCClaims{}),
+					testAccCheckProjectOIDCClaims(fc, other.ID, fakecircle.OIDCClaims{TTL: "15m"}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: testAccCheckProjectOIDCClaims(fc, other.ID, fakecircle.OIDCClaims{}),
+	})
+}
+
+func TestAccProjectOIDCClaimsResource_invalidImportID(t *testing.T) {
+	fc := testAccFakeCircle(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        fc.ProviderConfig() + testAccProjectOIDCClaimsResourceConfig(uuid.New(), uuid.New(), `ttl = "1h"`),
+				ResourceName:  "circleci_project_oidc_claims.test",
+				ImportState:   true,
+				ImportStateId: "not-an-import-id",
+				ExpectError:   regexp.MustCompile(`Expected import ID format: 'organization_id/project_id'`),
+			},
+		},
+	})
+}
+
+func TestAccProjectOIDCClaimsResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "project-oidc-disappears")
+	config := fc.ProviderConfig() + testAccProjectOIDCClaimsResourceConfig(prj.Org.ID, prj.ID, `ttl = "1h"`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_project_oidc_claims.test", func(context.Context, map[string]string) error {
+					return fc.SetProjectOIDCClaims(prj.ID, fakecircle.OIDCClaims{})
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_oidc_claims.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckProjectOIDCClaims checks the fake has the project's audience
+// and ttl claims as in want.
+func testAccCheckProjectOIDCClaims(fc *testAccFake, projectID uuid.UUID, want fakecircle.OIDCClaims) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		got, err := fc.ProjectOIDCClaims(projectID)
+		if err != nil {
+			return err
+		}
+		return testAccCompareOIDCClaims(got, want)
+	}
+}
+
+func testAccProjectOIDCClaimsResourceConfig(orgID, projectID uuid.UUID, claims string) string {
+	return fmt.Sprintf(`
+resource "circleci_project_oidc_claims" "test" {
+  organization_id = %[1]q
+  project_id      = %[2]q
+  %[3]s
+}
+`, orgID, projectID, claims)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/common"
 	"terraform-provider-circleci/internal/circleci/project"
 )
@@ -24,6 +25,7 @@ import (
 var (
 	_ resource.Resource                = &projectResource{}
 	_ resource.ResourceWithConfigure   = &projectResource{}
+	_ resource.ResourceWithModifyPlan  = &projectResource{}
 	_ resource.ResourceWithImportState = &projectResource{}
 )
 
@@ -56,7 +58,8 @@ func NewProjectResource() resource.Resource {
 
 // projectResource is the resource implementation.
 type projectResource struct {
-	client *project.ProjectService
+	client   *project.ProjectService
+	defaults providerDefaults
 }
 
 // Metadata returns the resource type name.
@@ -93,9 +96,11 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:            true,
 			},
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the organization that owns this project. Changing this value forces a new resource to be created.",
-				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns this project. Defaults to the provider's `default_organization_id`. Changing this value forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -162,6 +167,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project", "Create")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan projectResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -299,6 +307,9 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project", "Read")
+	defer end(&resp.Diagnostics)
+
 	var projectState projectResourceModel
 	diags := req.State.Get(ctx, &projectState)
 
@@ -316,6 +327,11 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	apiProject, err := r.client.Get(ctx, projectState.Slug.ValueString())
+	if client.IsNotFound(err) {
+		// The project was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read CircleCI project with Slug "+projectState.Slug.ValueString(),
@@ -342,7 +358,10 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		slug[1],
 		slug[2],
 	)
-
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read CircleCI project settings",
@@ -376,6 +395,9 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project", "Update")
+	defer end(&resp.Diagnostics)
+
 	var plan projectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -441,6 +463,9 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project", "Delete")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state projectResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -451,7 +476,7 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	// Delete existing project
 	err := r.client.Delete(ctx, state.Slug.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCi Project",
 			"Could not delete project, unexpected error: "+err.Error(),
@@ -460,6 +485,15 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, true)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *projectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -479,6 +513,7 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 	}
 
 	r.client = client.ProjectService
+	r.defaults = client.Defaults
 }
 
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -13,9 +14,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/project"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
 )
 
 func TestAccCircleCiProjectResource(t *testing.T) {
@@ -390,6 +395,40 @@ func TestAccProjectResourcePROnlyBranchOverrides(t *testing.T) {
 	})
 }
 
+func TestAccProjectResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + testAccProjectResourceConfig("disappears", org.ID.String(), false, false)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_project.test_project", func(ctx context.Context, attrs map[string]string) error {
+					return project.NewProjectService(fc.Client).Delete(ctx, attrs["slug"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project.test_project", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
 func testAccProjectResourceConfigWithBranchOverrides(name, organization_id string, overrides []string) string {
 	quoted := make([]string, len(overrides))
 	for index, override := range overrides {
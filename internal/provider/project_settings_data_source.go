@@ -105,6 +105,9 @@ func (d *ProjectSettingsDataSource) Schema(_ context.Context, _ datasource.Schem
 }
 
 func (d *ProjectSettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_project_settings", "Read")
+	defer end(&resp.Diagnostics)
+
 	if d.client == nil {
 		resp.Diagnostics.AddError(
 			"Unconfigured HTTP Client",
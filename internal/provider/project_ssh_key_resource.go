@@ -0,0 +1,340 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/sshkey"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &projectSSHKeyResource{}
+	_ resource.ResourceWithConfigure        = &projectSSHKeyResource{}
+	_ resource.ResourceWithConfigValidators = &projectSSHKeyResource{}
+	_ resource.ResourceWithImportState      = &projectSSHKeyResource{}
+)
+
+// projectSSHKeyResourceModel maps the resource schema.
+type projectSSHKeyResourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	ProjectSlug         types.String `tfsdk:"project_slug"`
+	Hostname            types.String `tfsdk:"hostname"`
+	PrivateKey          types.String `tfsdk:"private_key"`
+	PrivateKeyWo        types.String `tfsdk:"private_key_wo"`
+	PrivateKeyWoVersion types.Int64  `tfsdk:"private_key_wo_version"`
+	Fingerprint         types.String `tfsdk:"fingerprint"`
+	PublicKey           types.String `tfsdk:"public_key"`
+}
+
+// projectSSHKeyImportedKey is the private state key ImportState sets. An
+// imported key has neither private_key nor private_key_wo_version in state,
+// and setting either records it rather than replacing the key.
+const projectSSHKeyImportedKey = "imported"
+
+// projectSSHKeyRequiresReplace reports whether a change to a private key
+// attribute replaces the key: always, unless the attribute is being set for
+// the first time on an imported key. A key created with private_key_wo and
+// no version has the same null state, so the import is told apart by private.
+func projectSSHKeyRequiresReplace(ctx context.Context, private privateState, stateNull bool) (bool, diag.Diagnostics) {
+	if !stateNull {
+		return true, nil
+	}
+	imported, diags := private.GetKey(ctx, projectSSHKeyImportedKey)
+	return len(imported) == 0, diags
+}
+
+// NewProjectSSHKeyResource is a helper function to simplify the provider implementation.
+func NewProjectSSHKeyResource() resource.Resource {
+	return &projectSSHKeyResource{}
+}
+
+// projectSSHKeyResource is the resource implementation.
+type projectSSHKeyResource struct {
+	client *sshkey.Service
+}
+
+// Metadata returns the resource type name.
+func (r *projectSSHKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_ssh_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *projectSSHKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an additional SSH key of a CircleCI project, which jobs use to SSH to a host such as a deploy target. CircleCI keeps the private key and only returns its fingerprint and public key.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The fingerprint of the SSH key.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "The project slug in the format `vcs-type/org-name/repo-name`. Changing this value forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "The host jobs use the key for. Changing this value forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^/\s]+$`), "must be a hostname, without a scheme or path"),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "The PEM-encoded private key, without a passphrase. It is stored in state; use `private_key_wo` to keep it out. Exactly one of `private_key` or `private_key_wo` must be set. Changing this value forces a new resource to be created, except when setting it on an imported key.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace, resp.Diagnostics = projectSSHKeyRequiresReplace(ctx, req.Private, req.StateValue.IsNull())
+						},
+						"Changing the private key forces a new resource to be created, unless the key was imported.",
+						"Changing the private key forces a new resource to be created, unless the key was imported.",
+					),
+				},
+			},
+			"private_key_wo": schema.StringAttribute{
+				MarkdownDescription: "The PEM-encoded private key, which is never stored in state or plan files. Requires Terraform 1.11 or later. Terraform cannot tell when it changes, so change `private_key_wo_version` to add a new key.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"private_key_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version for `private_key_wo`. Changing it adds the current `private_key_wo` to CircleCI. Changing this value forces a new resource to be created, except when setting it on an imported key.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace, resp.Diagnostics = projectSSHKeyRequiresReplace(ctx, req.Private, req.StateValue.IsNull())
+						},
+						"Changing the private key version forces a new resource to be created, unless the key was imported.",
+						"Changing the private key version forces a new resource to be created, unless the key was imported.",
+					),
+				},
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("private_key_wo")),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The MD5 fingerprint of the key.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The public key, in OpenSSH format, e.g. to add to the host's `authorized_keys`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators that check the resource's configuration as a whole.
+func (r *projectSSHKeyResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("private_key"), path.MatchRoot("private_key_wo")),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *projectSSHKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_ssh_key", "Create")
+	defer end(&resp.Diagnostics)
+
+	// Retrieve values from plan
+	var plan projectSSHKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateKey, diags := writeOnlyValue(ctx, req.Config, plan.PrivateKey, path.Root("private_key_wo"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.Create(ctx, plan.ProjectSlug.ValueString(), plan.Hostname.ValueString(), privateKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating CircleCI project SSH key",
+			"Could not create CircleCI project SSH key, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(key.Fingerprint)
+	plan.Fingerprint = types.StringValue(key.Fingerprint)
+	plan.PublicKey = types.StringValue(key.PublicKey)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectSSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_ssh_key", "Read")
+	defer end(&resp.Diagnostics)
+
+	var state projectSSHKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := r.client.List(ctx, state.ProjectSlug.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading CircleCI Project SSH Key",
+			"Could not read SSH keys of project "+state.ProjectSlug.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var found *sshkey.Key
+	for i, k := range keys {
+		if k.Hostname == state.Hostname.ValueString() && k.Fingerprint == state.Id.ValueString() {
+			found = &keys[i]
+			break
+		}
+	}
+	if found == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Fingerprint = types.StringValue(found.Fingerprint)
+	state.PublicKey = types.StringValue(found.PublicKey)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only records a private_key or private_key_wo_version set on an
+// imported key, as changing anything else replaces the key.
+func (r *projectSSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_ssh_key", "Update")
+	defer end(&resp.Diagnostics)
+
+	var plan projectSSHKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	// The private key is now in state, so later changes replace the key.
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, projectSSHKeyImportedKey, nil)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectSSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_project_ssh_key", "Delete")
+	defer end(&resp.Diagnostics)
+
+	// Retrieve values from state
+	var state projectSSHKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Delete(ctx, state.ProjectSlug.ValueString(), state.Hostname.ValueString(), state.Id.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting CircleCI Project SSH Key",
+			"Could not delete project SSH key, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectSSHKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.SSHKeyService
+}
+
+// ImportState imports an existing resource into Terraform state.
+// Expected import ID format: "project_slug/hostname/fingerprint".
+// e.g. "circleci/org_id/project_id/deploy.example.com/a1:b2:...".
+func (r *projectSSHKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The project slug contains slashes (e.g. "circleci/org/project"),
+	// so split from the right to extract the hostname and fingerprint.
+	parts := strings.Split(req.ID, "/")
+	if len(parts) < 3 || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID Format",
+			fmt.Sprintf("Expected import ID format: 'project_slug/hostname/fingerprint' (e.g. 'circleci/org_id/project_id/deploy.example.com/a1:b2:c3:...'). Got: %s", req.ID),
+		)
+		return
+	}
+
+	projectSlug := strings.Join(parts[:len(parts)-2], "/")
+	hostname := parts[len(parts)-2]
+	fingerprint := parts[len(parts)-1]
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), projectSlug)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hostname"), hostname)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fingerprint)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, projectSSHKeyImportedKey, []byte("true"))...)
+}
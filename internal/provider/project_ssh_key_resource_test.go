@@ -0,0 +1,285 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-circleci/internal/circleci/sshkey"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccProjectSSHKeyResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "ssh-key")
+	privateKey, err := fakecircle.NewSSHPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fc.ProviderConfig() + testAccProjectSSHKeyResourceConfig(prj.Slug, "deploy.example.com", privateKey),
+				Check:  fc.CheckLastBody(http.MethodPost, "/project/*/*/*/ssh-key", "hostname", "deploy.example.com"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_project_ssh_key.test",
+						tfjsonpath.New("fingerprint"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^([0-9a-f]{2}:){15}[0-9a-f]{2}$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_project_ssh_key.test",
+						tfjsonpath.New("public_key"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^ssh-ed25519 `)),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "circleci_project_ssh_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["circleci_project_ssh_key.test"]
+					return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["project_slug"], rs.Primary.Attributes["hostname"], rs.Primary.Attributes["fingerprint"]), nil
+				},
+			},
+			// Changing the host replaces the key
+			{
+				Config: fc.ProviderConfig() + testAccProjectSSHKeyResourceConfig(prj.Slug, "staging.example.com", privateKey),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_ssh_key.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccProjectSSHKeyResource_noPrivateKey(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "no-private-key")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_project_ssh_key" "test" {
+  project_slug = %q
+  hostname     = "deploy.example.com"
+}
+`, prj.Slug),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccProjectSSHKeyResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "disappears")
+	privateKey, err := fakecircle.NewSSHPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := fc.ProviderConfig() + testAccProjectSSHKeyResourceConfig(prj.Slug, "deploy.example.com", privateKey)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_project_ssh_key.test", func(ctx context.Context, attrs map[string]string) error {
+					return sshkey.NewServiceWithBaseURL(fc.Client, fc.URL).Delete(ctx, attrs["project_slug"], attrs["hostname"], attrs["fingerprint"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_ssh_key.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccProjectSSHKeyResource_writeOnly(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "write-only")
+	first, err := fakecircle.NewSSHPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := fakecircle.NewSSHPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(privateKey string, version int) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_project_ssh_key" "test" {
+  project_slug           = %[1]q
+  hostname               = "deploy.example.com"
+  private_key_wo         = %[2]q
+  private_key_wo_version = %[3]d
+}
+`, prj.Slug, privateKey, version)
+	}
+	const postPattern = "/project/*/*/*/ssh-key"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(first, 1),
+				Check:  fc.CheckLastBody(http.MethodPost, postPattern, "private_key", first),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_project_ssh_key.test",
+						tfjsonpath.New("private_key_wo"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				Config: config(second, 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: config(second, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_ssh_key.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: fc.CheckLastBody(http.MethodPost, postPattern, "private_key", second),
+			},
+		},
+	})
+}
+
+func TestAccProjectSSHKeyResource_importThenConfigure(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	prj := fc.AddOrgProject(t, "import-then-configure")
+	var privateKeys, importIDs []string
+	for _, hostname := range []string{"deploy.example.com", "staging.example.com"} {
+		privateKey, err := fakecircle.NewSSHPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := fc.AddSSHKey(prj.ID, hostname, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privateKeys = append(privateKeys, privateKey)
+		importIDs = append(importIDs, prj.Slug+"/"+hostname+"/"+key.Fingerprint)
+	}
+
+	config := func(version int) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_project_ssh_key" "state" {
+  project_slug = %[1]q
+  hostname     = "deploy.example.com"
+  private_key  = %[2]q
+}
+
+resource "circleci_project_ssh_key" "write_only" {
+  project_slug           = %[1]q
+  hostname               = "staging.example.com"
+  private_key_wo         = %[3]q
+  private_key_wo_version = %[4]d
+}
+`, prj.Slug, privateKeys[0], privateKeys[1], version)
+	}
+	const keyPattern = "/project/*/*/*/ssh-key"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:             config(1),
+				ResourceName:       "circleci_project_ssh_key.state",
+				ImportState:        true,
+				ImportStateId:      importIDs[0],
+				ImportStatePersist: true,
+			},
+			{
+				Config:             config(1),
+				ResourceName:       "circleci_project_ssh_key.write_only",
+				ImportState:        true,
+				ImportStateId:      importIDs[1],
+				ImportStatePersist: true,
+			},
+			// Configuring the private key of an imported key records it
+			// without replacing the key.
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config(1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_ssh_key.state", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("circleci_project_ssh_key.write_only", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					fc.CheckCallCount(http.MethodPost, keyPattern, 0),
+					fc.CheckCallCount(http.MethodDelete, keyPattern, 0),
+				),
+			},
+			// Once recorded, changing the version replaces the key as usual.
+			{
+				Config: config(2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_project_ssh_key.state", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("circleci_project_ssh_key.write_only", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: fc.CheckLastBody(http.MethodPost, keyPattern, "private_key", privateKeys[1]),
+			},
+		},
+	})
+}
+
+func testAccProjectSSHKeyResourceConfig(projectSlug, hostname, privateKey string) string {
+	return fmt.Sprintf(`
+resource "circleci_project_ssh_key" "test" {
+  project_slug = %[1]q
+  hostname     = %[2]q
+  private_key  = %[3]q
+}
+`, projectSlug, hostname, privateKey)
+}
@@ -5,8 +5,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -14,17 +22,24 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.opentelemetry.io/otel"
 
+	"terraform-provider-circleci/internal/circleci/checkout"
 	"terraform-provider-circleci/internal/circleci/client"
 	ccicontext "terraform-provider-circleci/internal/circleci/context"
 	"terraform-provider-circleci/internal/circleci/envcontext"
 	"terraform-provider-circleci/internal/circleci/envproject"
+	"terraform-provider-circleci/internal/circleci/oidc"
 	"terraform-provider-circleci/internal/circleci/organization"
 	"terraform-provider-circleci/internal/circleci/pipeline"
+	"terraform-provider-circleci/internal/circleci/policy"
 	"terraform-provider-circleci/internal/circleci/project"
 	"terraform-provider-circleci/internal/circleci/runner"
+	"terraform-provider-circleci/internal/circleci/sshkey"
 	"terraform-provider-circleci/internal/circleci/trigger"
+	"terraform-provider-circleci/internal/circleci/user"
 	"terraform-provider-circleci/internal/circleci/webhook"
 )
 
@@ -44,6 +59,20 @@ type CircleCiClientWrapper struct {
 	WebhookService                    *webhook.WebhookService
 	ProjectEnvironmentVariableService *envproject.EnvService
 	RunnerService                     *runner.Service
+	UserService                       *user.UserService
+	CheckoutKeyService                *checkout.Service
+	SSHKeyService                     *sshkey.Service
+	OIDCService                       *oidc.Service
+	PolicyService                     *policy.Service
+
+	// CurrentUser and Organizations are the token's user and the orgs they
+	// are a member of, as found when the credentials were validated. They
+	// are nil when validate_credentials is false.
+	CurrentUser   *user.User
+	Organizations []user.Collaboration
+
+	// Defaults are used for attributes that configurations leave out.
+	Defaults providerDefaults
 }
 
 // circleciProviderModel maps provider schema data to a Go type.
@@ -51,8 +80,42 @@ type circleciProviderModel struct {
 	Host       types.String `tfsdk:"host"`
 	Key        types.String `tfsdk:"key"`
 	RunnerHost types.String `tfsdk:"runner_host"`
+
+	KeyFile       types.String `tfsdk:"key_file"`
+	KeyCommand    types.List   `tfsdk:"key_command"`
+	KeyCommandTTL types.String `tfsdk:"key_command_ttl"`
+
+	MaxRetries        types.Int64   `tfsdk:"max_retries"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	RetryMaxWait      types.String  `tfsdk:"retry_max_wait"`
+	ReadCacheTTL      types.String  `tfsdk:"read_cache_ttl"`
+
+	ValidateCredentials types.Bool `tfsdk:"validate_credentials"`
+
+	DefaultOrganizationId   types.String `tfsdk:"default_organization_id"`
+	DefaultOrganizationSlug types.String `tfsdk:"default_organization_slug"`
+	DefaultVcsType          types.String `tfsdk:"default_vcs_type"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+
+	Credentials []organizationCredentialsModel `tfsdk:"credentials"`
 }
 
+// defaultRequestsPerSecond is the request rate the provider keeps to unless
+// requests_per_second is set.
+const defaultRequestsPerSecond = 10
+
+// defaultReadCacheTTL is how long the provider reuses the response to a GET
+// unless read_cache_ttl is set. Terraform reads the same lists once per
+// resource, e.g. a context's env vars for each
+// circleci_context_environment_variable, and this makes that one request.
+const defaultReadCacheTTL = time.Minute
+
 // CircleCiProvider defines the provider implementation.
 type CircleCiProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -76,9 +139,125 @@ func (p *CircleCiProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:  true,
 				Sensitive: true,
 			},
+			"key_file": schema.StringAttribute{
+				MarkdownDescription: "The path to a file holding the API token. The file is read again for each request, so a token rotated on disk is picked up. Conflicts with `key` and `key_command`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("key"), path.MatchRoot("key_command")),
+				},
+			},
+			"key_command": schema.ListAttribute{
+				MarkdownDescription: "A command, and its arguments, that prints the API token, e.g. `[\"vault\", \"read\", \"-field=token\", \"secret/circleci\"]`. It is run without a shell, and again once the token is older than `key_command_ttl`. Conflicts with `key` and `key_file`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("key"), path.MatchRoot("key_file")),
+				},
+			},
+			"key_command_ttl": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long the token printed by `key_command` is used for before the command is run again, as a duration such as `\"5m\"`. `\"0s\"` runs the command for every request. Defaults to `\"%s\"`.", defaultKeyCommandTTL),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("key_command")),
+				},
+			},
 			"runner_host": schema.StringAttribute{
 				Optional: true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("How many times a request that was rate limited, failed with a server error or could not connect is retried. Defaults to `%d`; `0` disables retries.", client.DefaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: fmt.Sprintf("The average number of requests per second the provider makes to the CircleCI APIs, across all resources and data sources. Defaults to `%d`; `0` removes the limit. Whatever the limit, requests are held back when the API reports its rate limit is used up.", defaultRequestsPerSecond),
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The longest the provider waits before retrying a request, as a duration such as `\"30s\"`. This also caps a wait asked for by the API's `Retry-After` and `X-RateLimit-Reset` headers. Defaults to `\"%s\"`.", client.DefaultRetryMaxWait),
+				Optional:            true,
+			},
+			"read_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long the response to an API read is reused, as a duration such as `\"30s\"`. Identical reads made at the same time share one request, and any write drops the cached reads it could affect. `\"0s\"` turns the cache off. Defaults to `\"%s\"`.", formatDuration(defaultReadCacheTTL)),
+				Optional:            true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Whether to check the `key` against the `host`, and the `runner_host` if one is set, when the provider is configured. A bad token or host then fails straight away with an explanation, rather than on the first resource. Defaults to `true`.",
+				Optional:            true,
+			},
+			"default_organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization used by resources and data sources whose `organization_id` is not set. Conflicts with `default_organization_slug`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("default_organization_slug")),
+				},
+			},
+			"default_organization_slug": schema.StringAttribute{
+				MarkdownDescription: "The slug of the organization used by resources and data sources whose `organization_id` is not set, e.g. `gh/my-org`. It must be an organization the token's user is a member of. Conflicts with `default_organization_id`.",
+				Optional:            true,
+			},
+			"default_vcs_type": schema.StringAttribute{
+				MarkdownDescription: "The VCS type used by `circleci_organization` resources whose `vcs_type` is not set, e.g. `circleci`.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "The path to a PEM file of CA certificates to trust, as well as the system's, for the `host` and `runner_host`. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates to trust, as well as the system's, for the `host` and `runner_host`. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "The client certificate presented to the `host` and `runner_host` for mutual TLS, either PEM-encoded or the path to a PEM file. Requires `client_key`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "The private key of the `client_cert`, either PEM-encoded or the path to a PEM file. Requires `client_cert`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip verifying the TLS certificates of the `host` and `runner_host`. This is insecure and only meant for testing. Defaults to `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the HTTP proxy to send requests to the `host` and `runner_host` through, e.g. `http://proxy.example.com:3128`. Defaults to the proxy set by the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"credentials": schema.ListNestedBlock{
+				MarkdownDescription: "The API token to use for one organization, in place of `key`. Resources and data sources for that organization use it where the organization is known from their arguments: contexts, their env vars and restrictions where `organization_id` is set, projects and their env vars and settings, organizations, and runner resource classes and tokens. Anything else uses `key`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"organization": schema.StringAttribute{
+							MarkdownDescription: "The slug of the organization, e.g. `gh/my-org`, or its ID. An ID needs `validate_credentials`, which looks up the organization's slug, so that the key is used for both. When `validate_credentials` is false this must be the slug, and the key is only used for requests that give the organization by slug, such as those for projects.",
+							Required:            true,
+						},
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The API token for the organization.",
+							Required:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -107,19 +286,26 @@ func (p *CircleCiProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	token, diags := resolveToken(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	key := token.Key
+
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
 	host := os.Getenv("CIRCLE_HOST")
-	key := os.Getenv("CIRCLE_TOKEN")
 	runner_host := os.Getenv("CIRCLE_RUNNER_HOST")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
 	}
 
-	if !config.Key.IsNull() {
-		key = config.Key.ValueString()
+	// A token from the CircleCI CLI's config goes to the host it is for.
+	if host == "" {
+		host = token.Host
 	}
 
 	if !config.RunnerHost.IsNull() {
@@ -132,6 +318,55 @@ func (p *CircleCiProvider) Configure(ctx context.Context, req provider.Configure
 		host = "https://circleci.com/api/v2"
 	}
 
+	opts := client.Options{
+		MaxRetries:   client.DefaultMaxRetries,
+		RetryMaxWait: client.DefaultRetryMaxWait,
+	}
+	requestsPerSecond := float64(defaultRequestsPerSecond)
+
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		opts.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+
+	if !config.RetryMaxWait.IsNull() && !config.RetryMaxWait.IsUnknown() {
+		d, err := time.ParseDuration(config.RetryMaxWait.ValueString())
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Retry Max Wait",
+				fmt.Sprintf("retry_max_wait must be a positive duration such as \"30s\", got %q.", config.RetryMaxWait.ValueString()),
+			)
+		}
+		opts.RetryMaxWait = d
+	}
+
+	opts.CacheTTL = defaultReadCacheTTL
+	if !config.ReadCacheTTL.IsNull() && !config.ReadCacheTTL.IsUnknown() {
+		d, err := time.ParseDuration(config.ReadCacheTTL.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_cache_ttl"),
+				"Invalid Read Cache TTL",
+				fmt.Sprintf("read_cache_ttl must be a non-negative duration such as \"1m\", got %q.", config.ReadCacheTTL.ValueString()),
+			)
+		}
+		opts.CacheTTL = d
+	}
+
+	opts.TokenSource = token.Source
+	opts.TLSConfig, opts.ProxyURL, diags = transportSettings(config)
+	resp.Diagnostics.Append(diags...)
+
+	// Bursts are allowed up to one second's worth of requests.
+	opts.Limiter = client.NewLimiter(requestsPerSecond, int(math.Ceil(requestsPerSecond)))
+	// These are no-ops unless telemetry was set up from OTEL_* env vars.
+	opts.TracerProvider = otel.GetTracerProvider()
+	opts.MeterProvider = otel.GetMeterProvider()
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 	if key == "" {
@@ -139,8 +374,8 @@ func (p *CircleCiProvider) Configure(ctx context.Context, req provider.Configure
 			path.Root("key"),
 			"Missing CircleCI API Password",
 			"The provider cannot create the CircleCI API client as there is a missing or empty value for the CircleCI API password. "+
-				"Set the password value in the configuration or use the CIRCLE_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the password value in the configuration, set key_file or key_command, use the CIRCLE_TOKEN environment variable, or log in with the CircleCI CLI. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -148,8 +383,35 @@ func (p *CircleCiProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	validate := config.ValidateCredentials.IsNull() || config.ValidateCredentials.IsUnknown() || config.ValidateCredentials.ValueBool()
+	validateOpts := opts
+	validateOpts.MaxRetries = min(opts.MaxRetries, validateMaxRetries)
+
+	var creds *credentials
+	if validate {
+		validateClient := client.NewClientWithOptions(host, key, "terraform-provider-circleci/"+p.version, validateOpts)
+
+		creds, diags = validateCredentials(ctx, validateClient, host, runner_host)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Each org's key is checked on its own, without the provider's token
+	// source.
+	orgOpts := validateOpts
+	orgOpts.TokenSource = nil
+	opts.Credentials, diags = organizationTokens(ctx, config.Credentials, validate, func(key string) *client.Client {
+		return client.NewClientWithOptions(host, key, "terraform-provider-circleci/"+p.version, orgOpts)
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create a new CircleCi client using the configuration values
-	circleciClient := client.NewClient(host, key, "terraform-provider-circleci/"+p.version)
+	circleciClient := client.NewClientWithOptions(host, key, "terraform-provider-circleci/"+p.version, opts)
 	contextService := ccicontext.NewContextService(circleciClient)
 	organizationService := organization.NewOrganizationService(circleciClient)
 	projectService := project.NewProjectService(circleciClient)
@@ -164,7 +426,29 @@ func (p *CircleCiProvider) Configure(ctx context.Context, req provider.Configure
 	} else {
 		runnerService = runner.NewServiceWithBaseURL(circleciClient, runner_host)
 	}
-	// TODO: would it be possible to verify that the client is correctly configured?
+	userService := user.NewUserService(circleciClient)
+	checkoutKeyService := checkout.NewService(circleciClient)
+	oidcService := oidc.NewService(circleciClient)
+	hostBaseURL := strings.TrimSuffix(strings.TrimSuffix(host, "/"), "/api/v2")
+	policyService := policy.NewServiceWithBaseURL(circleciClient, policyBaseURL(hostBaseURL))
+	// Additional SSH keys are only in the v1.1 API, on the same host.
+	sshKeyService := sshkey.NewServiceWithBaseURL(circleciClient, hostBaseURL)
+
+	defaults := providerDefaults{
+		OrganizationID: config.DefaultOrganizationId.ValueString(),
+		VcsType:        config.DefaultVcsType.ValueString(),
+	}
+	if slug := config.DefaultOrganizationSlug.ValueString(); slug != "" {
+		var orgs []user.Collaboration
+		if creds != nil {
+			orgs = creds.Organizations
+		}
+		defaults.OrganizationID, diags = resolveOrganizationSlug(ctx, userService, orgs, slug)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Make the CircleCI client available during DataSource and Resource type Configure methods.
 	cccw := CircleCiClientWrapper{
@@ -177,9 +461,30 @@ func (p *CircleCiProvider) Configure(ctx context.Context, req provider.Configure
 		WebhookService:                    webhookService,
 		ProjectEnvironmentVariableService: projectEnvVarService,
 		RunnerService:                     runnerService,
+		UserService:                       userService,
+		CheckoutKeyService:                checkoutKeyService,
+		SSHKeyService:                     sshKeyService,
+		OIDCService:                       oidcService,
+		PolicyService:                     policyService,
+		Defaults:                          defaults,
+	}
+	if creds != nil {
+		cccw.CurrentUser = creds.User
+		cccw.Organizations = creds.Organizations
 	}
 	resp.DataSourceData = &cccw
 	resp.ResourceData = &cccw
+	resp.EphemeralResourceData = &cccw
+}
+
+// policyBaseURL returns where the config policy API is for the host at
+// baseURL. circleci.com serves it from a host of its own, and CircleCI
+// server from the same host.
+func policyBaseURL(baseURL string) string {
+	if baseURL == "https://circleci.com" {
+		return policy.DefaultBaseURL
+	}
+	return baseURL
 }
 
 func (p *CircleCiProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -190,16 +495,26 @@ func (p *CircleCiProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewContextResource,
 		NewContextRestrictionResource,
 		NewContextEnvironmentVariableResource,
+		NewContextEnvironmentVariablesResource,
 		NewWebhookResource,
 		NewOrganizationResource,
 		NewProjectEnvironmentVariableResource,
+		NewProjectEnvironmentVariablesResource,
 		NewRunnerResourceClassResource,
 		NewRunnerTokenResource,
+		NewCheckoutKeyResource,
+		NewProjectSSHKeyResource,
+		NewOrganizationOIDCClaimsResource,
+		NewProjectOIDCClaimsResource,
+		NewPolicyBundleResource,
+		NewPolicySettingsResource,
 	}
 }
 
 func (p *CircleCiProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewRunnerTokenEphemeralResource,
+	}
 }
 
 func (p *CircleCiProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -214,6 +529,10 @@ func (p *CircleCiProvider) DataSources(ctx context.Context) []func() datasource.
 		NewOrganizationDataSource,
 		NewProjectEnvironmentVariableDataSource,
 		NewRunnerResourceClassDataSource,
+		NewCheckoutKeysDataSource,
+		NewOrganizationOIDCClaimsDataSource,
+		NewProjectOIDCClaimsDataSource,
+		NewPolicyDecisionsDataSource,
 	}
 }
 
@@ -0,0 +1,94 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestAccProvider_ReadCacheTTL(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "read-cache",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgCtx, err := fc.AddContext(fakecircle.NewContext{
+		OrgID: org.ID,
+		Name:  "read-cache",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"FOO", "BAR"} {
+		if _, err := fc.AddContextEnv(orgCtx.ID, fakecircle.NewEnvVarContext{Variable: name, Value: "value"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := func(settings string) string {
+		return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+  key         = %[2]q
+%[3]s
+}
+
+data "circleci_context_environment_variable" "foo" {
+  name       = "FOO"
+  context_id = %[4]q
+}
+
+data "circleci_context_environment_variable" "bar" {
+  name       = "BAR"
+  context_id = %[4]q
+}
+`, fc.URL, testAccFakeToken, settings, orgCtx.ID)
+	}
+
+	// The same steps are run with and without the cache, so without it the
+	// two data sources make twice the requests.
+	var cached int
+	countCached := func(*terraform.State) error {
+		cached = fc.CallCount(http.MethodGet, "/context/*/environment-variable")
+		return nil
+	}
+	checkUncached := func(*terraform.State) error {
+		if n := fc.CallCount(http.MethodGet, "/context/*/environment-variable"); n != 2*cached {
+			return fmt.Errorf("got %d env var list requests without the cache, want %d", n, 2*cached)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config(""),
+				Check:     countCached,
+			},
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config(`  read_cache_ttl = "0s"`),
+				Check:     checkUncached,
+			},
+			{
+				Config:      config(`  read_cache_ttl = "soon"`),
+				ExpectError: regexp.MustCompile(`Invalid Read Cache TTL`),
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/user"
+)
+
+// organizationCredentialsModel maps a credentials block.
+type organizationCredentialsModel struct {
+	Organization types.String `tfsdk:"organization"`
+	Key          types.String `tfsdk:"key"`
+}
+
+// organizationTokens returns the tokens from the credentials blocks, keyed
+// by org, for client.Options.Credentials.
+//
+// When lookup is set each token is checked by listing its user's orgs, and
+// is keyed by both the ID and the slug of its org, whichever the block
+// named. Otherwise it is only keyed by what the block named, which must be a
+// slug: client.WithProject finds a project's token by the org slug in the
+// project slug, so a token keyed by ID would never be used for projects.
+func organizationTokens(ctx context.Context, blocks []organizationCredentialsModel, lookup bool, newClient func(key string) *client.Client) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(blocks) == 0 {
+		return nil, diags
+	}
+
+	tokens := make(map[string]string, 2*len(blocks))
+	seen := make(map[string]bool, len(blocks))
+	for i, b := range blocks {
+		block := path.Root("credentials").AtListIndex(i)
+		if b.Organization.IsUnknown() || b.Key.IsUnknown() {
+			diags.AddAttributeError(
+				block,
+				"Unknown CircleCI Organization Credentials",
+				"The provider cannot use these credentials as their organization or key is unknown. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			continue
+		}
+
+		org, key := b.Organization.ValueString(), b.Key.ValueString()
+		if seen[strings.ToLower(org)] {
+			diags.AddAttributeError(
+				block.AtName("organization"),
+				"Duplicate CircleCI Organization Credentials",
+				fmt.Sprintf("There is more than one credentials block for the organization %q.", org),
+			)
+			continue
+		}
+		seen[strings.ToLower(org)] = true
+
+		if !lookup {
+			if !strings.Contains(org, "/") {
+				diags.AddAttributeError(
+					block.AtName("organization"),
+					"Invalid CircleCI Organization Slug",
+					fmt.Sprintf("When validate_credentials is false, the organization must be given by its slug, e.g. gh/my-org, not %q. "+
+						"Requests for projects find their credentials by the organization slug in the project slug, "+
+						"and without validate_credentials the provider does not look up an ID's slug. "+
+						"Use the slug, or set validate_credentials to true.", org),
+				)
+				continue
+			}
+			tokens[org] = key
+			continue
+		}
+
+		orgs, err := user.NewUserService(newClient(key)).Collaborations(ctx)
+		if err != nil {
+			diags.AddAttributeError(
+				block.AtName("key"),
+				"Invalid CircleCI Organization Credentials",
+				fmt.Sprintf("The CircleCI API rejected the key for the organization %q: %s. "+
+					"To skip this check, set validate_credentials to false.", org, err),
+			)
+			continue
+		}
+		match := slices.IndexFunc(orgs, func(o user.Collaboration) bool {
+			return o.Id == org || strings.EqualFold(o.Slug, org)
+		})
+		if match == -1 {
+			diags.AddAttributeError(
+				block.AtName("organization"),
+				"Unknown CircleCI Organization",
+				fmt.Sprintf("The key's user is not a member of an organization with the ID or slug %q. "+
+					"Check the organization, or that the key is the one for it.", org),
+			)
+			continue
+		}
+		tokens[orgs[match].Id] = key
+		tokens[orgs[match].Slug] = key
+	}
+
+	return tokens, diags
+}
@@ -0,0 +1,287 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+const testAccFakeOrgToken = "CCIPAT_7c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+
+func TestOrganizationTokens(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeGitHub,
+		Name: "tokens",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AddOrgToken(testAccFakeOrgToken, org.ID)
+
+	newClient := func(key string) *client.Client {
+		return client.NewClient(fc.URL+"/api/v2", key, "terraform-provider-circleci/test")
+	}
+	block := func(org, key string) organizationCredentialsModel {
+		return organizationCredentialsModel{Organization: types.StringValue(org), Key: types.StringValue(key)}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		blocks []organizationCredentialsModel
+		lookup bool
+		want   map[string]string
+	}{
+		{
+			name:   "none",
+			lookup: true,
+		},
+		{
+			name:   "by_id",
+			blocks: []organizationCredentialsModel{block(org.ID.String(), testAccFakeOrgToken)},
+			lookup: true,
+			want:   map[string]string{org.ID.String(): testAccFakeOrgToken, org.Slug: testAccFakeOrgToken},
+		},
+		{
+			name:   "by_slug",
+			blocks: []organizationCredentialsModel{block(org.Slug, testAccFakeOrgToken)},
+			lookup: true,
+			want:   map[string]string{org.ID.String(): testAccFakeOrgToken, org.Slug: testAccFakeOrgToken},
+		},
+		{
+			name:   "no_lookup",
+			blocks: []organizationCredentialsModel{block("gh/elsewhere", "other-token")},
+			want:   map[string]string{"gh/elsewhere": "other-token"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diags := organizationTokens(context.Background(), tc.blocks, tc.lookup, newClient)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("tokens (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name        string
+		blocks      []organizationCredentialsModel
+		lookup      bool
+		wantSummary string
+	}{
+		{
+			name:        "bad_key",
+			blocks:      []organizationCredentialsModel{block(org.Slug, "not-valid")},
+			lookup:      true,
+			wantSummary: "Invalid CircleCI Organization Credentials",
+		},
+		{
+			name:        "not_a_member",
+			blocks:      []organizationCredentialsModel{block("gh/elsewhere", testAccFakeOrgToken)},
+			lookup:      true,
+			wantSummary: "Unknown CircleCI Organization",
+		},
+		{
+			name: "duplicate",
+			blocks: []organizationCredentialsModel{
+				block(org.Slug, testAccFakeOrgToken),
+				block(org.Slug, testAccFakeToken),
+			},
+			lookup:      true,
+			wantSummary: "Duplicate CircleCI Organization Credentials",
+		},
+		{
+			name:        "no_lookup_by_id",
+			blocks:      []organizationCredentialsModel{block(org.ID.String(), testAccFakeOrgToken)},
+			wantSummary: "Invalid CircleCI Organization Slug",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, diags := organizationTokens(context.Background(), tc.blocks, tc.lookup, newClient)
+			if !diags.HasError() || diags.Errors()[0].Summary() != tc.wantSummary {
+				t.Errorf("diagnostics = %v, want %q", diags, tc.wantSummary)
+			}
+		})
+	}
+}
+
+func TestAccProvider_Credentials(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "credentials",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AddOrgToken(testAccFakeOrgToken, org.ID)
+
+	config := func(credentials string) string {
+		return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+  key         = %[2]q
+%[3]s
+}
+
+resource "circleci_context" "test_context" {
+  name            = "credentials"
+  organization_id = %[4]q
+}
+`, fc.URL, testAccFakeToken, credentials, org.ID)
+	}
+
+	// checkContextToken checks the context's requests used the org's token.
+	checkContextToken := func(*terraform.State) error {
+		calls := append(fc.CallsMatching("", "/context"), fc.CallsMatching("", "/context/*")...)
+		if len(calls) == 0 {
+			return fmt.Errorf("no context requests were made")
+		}
+		for _, c := range calls {
+			if c.TokenOrg != org.ID {
+				return fmt.Errorf("%s %s used a token for the org %q, not the org's own", c.Method, c.Path, c.TokenOrg)
+			}
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: fc.ResetCalls,
+				Config: config(fmt.Sprintf(`
+  credentials {
+    organization = %q
+    key          = %q
+  }`, org.Slug, testAccFakeOrgToken)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context.test_context", "name", "credentials"),
+					checkContextToken,
+					fc.CheckCallCount(http.MethodPost, "/context", 1),
+				),
+			},
+			{
+				Config: config(`
+  credentials {
+    organization = "gh/elsewhere"
+    key          = "` + testAccFakeOrgToken + `"
+  }`),
+				ExpectError: regexp.MustCompile(`Unknown CircleCI Organization`),
+			},
+		},
+	})
+}
+
+// TestAccProvider_CredentialsContextEnvironmentVariables checks that a
+// context's env vars are managed with its org's credentials when the
+// provider's key cannot see the org.
+func TestAccProvider_CredentialsContextEnvironmentVariables(t *testing.T) {
+	const otherOrgToken = "CCIPAT_0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "credentials-env",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "credentials-other",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AddOrgToken(testAccFakeOrgToken, org.ID)
+	fc.AddOrgToken(otherOrgToken, other.ID)
+
+	config := func(value string) string {
+		return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+  key         = %[2]q
+
+  credentials {
+    organization = %[3]q
+    key          = %[4]q
+  }
+}
+
+resource "circleci_context" "test_context" {
+  name            = "credentials-env"
+  organization_id = %[3]q
+}
+
+resource "circleci_context_environment_variable" "test_env" {
+  name            = "FOO"
+  value           = %[5]q
+  context_id      = circleci_context.test_context.id
+  organization_id = circleci_context.test_context.organization_id
+}
+
+resource "circleci_context_environment_variables" "test_envs" {
+  context_id      = circleci_context.test_context.id
+  organization_id = circleci_context.test_context.organization_id
+  variables = {
+    BAR = %[5]q
+  }
+}
+`, fc.URL, otherOrgToken, org.ID, testAccFakeOrgToken, value)
+	}
+
+	// checkEnvToken checks the env var requests used the org's token.
+	checkEnvToken := func(*terraform.State) error {
+		calls := append(fc.CallsMatching("", "/context/*/environment-variable"), fc.CallsMatching("", "/context/*/environment-variable/*")...)
+		if len(calls) == 0 {
+			return fmt.Errorf("no context env var requests were made")
+		}
+		for _, c := range calls {
+			if c.TokenOrg != org.ID {
+				return fmt.Errorf("%s %s used a token for the org %q, not the org's own", c.Method, c.Path, c.TokenOrg)
+			}
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config("one"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("circleci_context_environment_variable.test_env", "updated_at"),
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.test_envs", "variables.BAR", "one"),
+					checkEnvToken,
+				),
+			},
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config("two"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.test_envs", "variables.BAR", "two"),
+					checkEnvToken,
+				),
+			},
+		},
+	})
+}
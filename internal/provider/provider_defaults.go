@@ -0,0 +1,99 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/user"
+)
+
+// providerDefaults are provider settings that resources and data sources use
+// for attributes left out of their configuration.
+type providerDefaults struct {
+	// OrganizationID is from default_organization_id, or the org that
+	// default_organization_slug names.
+	OrganizationID string
+	VcsType        string
+}
+
+// resolveOrganizationSlug returns the ID of the org with the given slug, from
+// orgs if they are known, and otherwise from the token's collaborations.
+func resolveOrganizationSlug(ctx context.Context, users *user.UserService, orgs []user.Collaboration, slug string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if orgs == nil {
+		var err error
+		orgs, err = users.Collaborations(ctx)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("default_organization_slug"),
+				"Unable to Resolve Default Organization",
+				fmt.Sprintf("Could not list the organizations the token's user is a member of: %s", err),
+			)
+			return "", diags
+		}
+	}
+
+	for _, o := range orgs {
+		if o.Slug == slug {
+			return o.Id, diags
+		}
+	}
+
+	diags.AddAttributeError(
+		path.Root("default_organization_slug"),
+		"Unknown Default Organization",
+		fmt.Sprintf("The token's user is not a member of an organization with the slug %q. "+
+			"Check the slug, or set default_organization_id instead.", slug),
+	)
+	return "", diags
+}
+
+// planProviderDefault fills in the string attribute at attr from the
+// provider default def when the configuration leaves it out, so the plan
+// shows the value that will be used. providerAttr names the provider setting
+// def comes from, for the error when neither is set.
+//
+// When the filled in value differs from state and requiresReplace is set,
+// the resource is replaced, as a RequiresReplace plan modifier would do for
+// a configured value.
+func planProviderDefault(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attr path.Path, providerAttr, def string, requiresReplace bool) {
+	// Nothing to do when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attr, &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	if def == "" {
+		resp.Diagnostics.AddAttributeError(
+			attr,
+			"Missing "+attr.String(),
+			fmt.Sprintf("Set %s, or set %s in the provider configuration.", attr, providerAttr),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, types.StringValue(def))...)
+
+	if !requiresReplace || req.State.Raw.IsNull() {
+		return
+	}
+	var prior types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, attr, &prior)...)
+	if !prior.IsNull() && prior.ValueString() != def {
+		resp.RequiresReplace = append(resp.RequiresReplace, attr)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+	"terraform-provider-circleci/internal/circleci/user"
+)
+
+func TestResolveOrganizationSlug(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "defaults",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := user.NewUserService(client.NewClient(fc.URL+"/api/v2", testAccFakeToken, "terraform-provider-circleci/test"))
+
+	t.Run("listed", func(t *testing.T) {
+		id, diags := resolveOrganizationSlug(context.Background(), users, nil, org.Slug)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if id != org.ID.String() {
+			t.Errorf("ID = %q, want %q", id, org.ID)
+		}
+	})
+
+	t.Run("known", func(t *testing.T) {
+		orgs := []user.Collaboration{{Id: "known-id", Slug: "circleci/known"}}
+		id, diags := resolveOrganizationSlug(context.Background(), users, orgs, "circleci/known")
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if id != "known-id" {
+			t.Errorf("ID = %q, want %q", id, "known-id")
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, diags := resolveOrganizationSlug(context.Background(), users, nil, "circleci/unknown")
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Unknown Default Organization" {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+}
+
+func testAccDefaultsConfig(fc *testAccFake, settings, resources string) string {
+	return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+  key         = %[2]q
+%[3]s
+}
+%[4]s`, fc.URL, testAccFakeToken, settings, resources)
+}
+
+func TestAccProvider_DefaultOrganization(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "defaults",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const contextConfig = `
+resource "circleci_context" "test_context" {
+  name = "defaults"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDefaultsConfig(fc, "", contextConfig),
+				ExpectError: regexp.MustCompile(`Missing organization_id`),
+			},
+			{
+				Config: testAccDefaultsConfig(fc, fmt.Sprintf("  default_organization_id = %q", org.ID), contextConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context.test_context", "organization_id", org.ID.String()),
+				),
+			},
+			// The same org named by slug plans no changes.
+			{
+				Config:   testAccDefaultsConfig(fc, fmt.Sprintf("  default_organization_slug = %q", org.Slug), contextConfig),
+				PlanOnly: true,
+			},
+			{
+				Config:      testAccDefaultsConfig(fc, `  default_organization_slug = "circleci/unknown"`, contextConfig),
+				ExpectError: regexp.MustCompile(`Unknown Default Organization`),
+			},
+		},
+	})
+}
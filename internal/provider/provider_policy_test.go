@@ -0,0 +1,24 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"terraform-provider-circleci/internal/circleci/policy"
+)
+
+func TestPolicyBaseURL(t *testing.T) {
+	for _, tc := range []struct {
+		baseURL string
+		want    string
+	}{
+		{baseURL: "https://circleci.com", want: policy.DefaultBaseURL},
+		{baseURL: "https://circleci.example.com", want: "https://circleci.example.com"},
+	} {
+		if got := policyBaseURL(tc.baseURL); got != tc.want {
+			t.Errorf("policyBaseURL(%q) = %q, want %q", tc.baseURL, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func testAccRetryConfig(fc *testAccFake, settings, orgID string) string {
+	return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+  key         = %[2]q
+%[3]s
+}
+
+resource "circleci_context" "test_context" {
+  name            = "retries"
+  organization_id = %[4]q
+}
+`, fc.URL, testAccFakeToken, settings, orgID)
+}
+
+func TestAccProvider_RetrySettings(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "retries",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The create is rate limited twice and succeeds on the last retry.
+			{
+				PreConfig: func() {
+					fc.InjectFault(fakecircle.Fault{
+						Method: http.MethodPost,
+						Path:   "/context",
+						Times:  2,
+						Status: http.StatusTooManyRequests,
+						Header: http.Header{"Retry-After": {"30"}},
+					})
+				},
+				Config: testAccRetryConfig(fc, `
+  max_retries         = 2
+  requests_per_second = 50
+  retry_max_wait      = "10ms"
+`, org.ID.String()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("circleci_context.test_context", "id"),
+					func(*terraform.State) error {
+						if n := fc.PendingFaults(); n != 0 {
+							return fmt.Errorf("expected every fault to be used, %d left", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_RetrySettingsExhausted(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "no retries",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					fc.InjectFault(fakecircle.Fault{
+						Method: http.MethodPost,
+						Path:   "/context",
+						Times:  1,
+						Status: http.StatusTooManyRequests,
+						Header: http.Header{"Retry-After": {"0"}},
+					})
+				},
+				Config:      testAccRetryConfig(fc, "  max_retries = 0", org.ID.String()),
+				ExpectError: regexp.MustCompile(`429 Too\s+Many\s+Requests`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_InvalidRetryMaxWait(t *testing.T) {
+	fc := testAccFakeCircle(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRetryConfig(fc, `  retry_max_wait = "soon"`, "00000000-0000-0000-0000-000000000000"),
+				ExpectError: regexp.MustCompile(`Invalid Retry Max Wait`),
+			},
+			{
+				Config:      testAccRetryConfig(fc, `  retry_max_wait = "0s"`, "00000000-0000-0000-0000-000000000000"),
+				ExpectError: regexp.MustCompile(`Invalid Retry Max Wait`),
+			},
+			{
+				Config:      testAccRetryConfig(fc, "  requests_per_second = -1", "00000000-0000-0000-0000-000000000000"),
+				ExpectError: regexp.MustCompile(`must be at least 0`),
+			},
+		},
+	})
+}
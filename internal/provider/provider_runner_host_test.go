@@ -23,6 +23,8 @@ provider "circleci" {
   host        = "http://127.0.0.1:1"
   runner_host = %q
   key         = "fake"
+
+  validate_credentials = false
 }
 data "circleci_runner_resource_class" "t" {
   organization_id = "00000000-1111-2222-3333-444444444444"
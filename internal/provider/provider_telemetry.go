@@ -0,0 +1,38 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName names the tracer for Terraform operations.
+const tracerName = "terraform-provider-circleci/internal/provider"
+
+// traceOperation starts a span for a Terraform operation, such as a Read of
+// circleci_context, that is the parent of the spans for the API requests it
+// makes. Data sources are named with a "data." prefix, as in Terraform
+// addresses. Defer the returned function with the operation's diagnostics to
+// end the span.
+func traceOperation(ctx context.Context, typeName, op string) (context.Context, func(*diag.Diagnostics)) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, typeName+" "+op,
+		trace.WithAttributes(
+			attribute.String("terraform.type", typeName),
+			attribute.String("terraform.operation", op),
+		),
+	)
+
+	return ctx, func(diags *diag.Diagnostics) {
+		if errs := diags.Errors(); len(errs) > 0 {
+			span.SetStatus(codes.Error, errs[0].Summary())
+		}
+		span.End()
+	}
+}
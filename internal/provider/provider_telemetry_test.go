@@ -0,0 +1,46 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceOperation(t *testing.T) {
+	spans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var diags diag.Diagnostics
+	ctx, end := traceOperation(context.Background(), "circleci_context", "Read")
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		t.Error("the returned context does not carry the span")
+	}
+	end(&diags)
+
+	diags.AddError("Unable to Read", "boom")
+	_, end = traceOperation(context.Background(), "data.circleci_context", "Read")
+	end(&diags)
+
+	got := spans.GetSpans()
+	if len(got) != 2 {
+		t.Fatalf("got %d spans, want 2", len(got))
+	}
+	if got[0].Name != "circleci_context Read" || got[0].Status.Code != codes.Unset {
+		t.Errorf("first span = %q with status %v", got[0].Name, got[0].Status)
+	}
+	if got[1].Name != "data.circleci_context Read" || got[1].Status.Code != codes.Error || got[1].Status.Description != "Unable to Read" {
+		t.Errorf("second span = %q with status %v", got[1].Name, got[1].Status)
+	}
+}
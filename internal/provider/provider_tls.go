@@ -0,0 +1,106 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// transportSettings returns the TLS config and proxy the provider's clients
+// use for both the host and runner_host. The TLS config is nil when no TLS
+// setting is set, so the system defaults apply, and so is the proxy when
+// proxy_url is not set.
+func transportSettings(config circleciProviderModel) (*tls.Config, *url.URL, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var proxy *url.URL
+	if v := config.ProxyURL.ValueString(); v != "" {
+		u, err := url.Parse(v)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("proxy_url must be an http, https or socks5 URL with a host, such as \"http://proxy.example.com:3128\", got %q.", v),
+			)
+		}
+		proxy = u
+	}
+
+	caFile := config.CACertFile.ValueString()
+	caPEM := config.CACertPEM.ValueString()
+	certValue := config.ClientCert.ValueString()
+	keyValue := config.ClientKey.ValueString()
+	insecure := config.InsecureSkipVerify.ValueBool()
+	if caFile == "" && caPEM == "" && certValue == "" && !insecure {
+		return nil, proxy, diags
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Only for testing, as the attribute's description says.
+		InsecureSkipVerify: insecure,
+	}
+
+	if caFile != "" || caPEM != "" {
+		attr := path.Root("ca_cert_pem")
+		pem := []byte(caPEM)
+		if caFile != "" {
+			attr = path.Root("ca_cert_file")
+			var err error
+			pem, err = os.ReadFile(caFile)
+			if err != nil {
+				diags.AddAttributeError(attr, "Unable to Read CA Certificates", err.Error())
+				return nil, nil, diags
+			}
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			diags.AddAttributeError(attr, "Invalid CA Certificates", "No PEM-encoded certificates were found.")
+			return nil, nil, diags
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if certValue != "" {
+		certPEM, err := pemOrFile(certValue)
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_cert"), "Unable to Read Client Certificate", err.Error())
+			return nil, nil, diags
+		}
+		keyPEM, err := pemOrFile(keyValue)
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_key"), "Unable to Read Client Key", err.Error())
+			return nil, nil, diags
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_cert"), "Invalid Client Certificate", err.Error())
+			return nil, nil, diags
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, proxy, diags
+}
+
+// pemOrFile returns v when it is PEM-encoded, and otherwise the contents of
+// the file it names.
+func pemOrFile(v string) ([]byte, error) {
+	if strings.Contains(v, "-----BEGIN") {
+		return []byte(v), nil
+	}
+	return os.ReadFile(v)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+// testCertPEM returns a self-signed certificate and its key, PEM-encoded.
+func testCertPEM(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-circleci"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestTransportSettings(t *testing.T) {
+	certPEM, keyPEM := testCertPEM(t)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, []byte(certPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, []byte(keyPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		tlsConfig, proxy, diags := transportSettings(circleciProviderModel{})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tlsConfig != nil || proxy != nil {
+			t.Errorf("got TLS config %v and proxy %v, want neither", tlsConfig, proxy)
+		}
+	})
+
+	t.Run("ca_cert_pem", func(t *testing.T) {
+		tlsConfig, _, diags := transportSettings(circleciProviderModel{CACertPEM: types.StringValue(certPEM)})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tlsConfig == nil || tlsConfig.RootCAs == nil {
+			t.Fatal("CA certificates not set")
+		}
+	})
+
+	t.Run("ca_cert_file", func(t *testing.T) {
+		tlsConfig, _, diags := transportSettings(circleciProviderModel{CACertFile: types.StringValue(certFile)})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tlsConfig == nil || tlsConfig.RootCAs == nil {
+			t.Fatal("CA certificates not set")
+		}
+	})
+
+	t.Run("client_cert_pem", func(t *testing.T) {
+		tlsConfig, _, diags := transportSettings(circleciProviderModel{
+			ClientCert: types.StringValue(certPEM),
+			ClientKey:  types.StringValue(keyPEM),
+		})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tlsConfig == nil || len(tlsConfig.Certificates) != 1 {
+			t.Fatal("client certificate not set")
+		}
+	})
+
+	t.Run("client_cert_file", func(t *testing.T) {
+		tlsConfig, _, diags := transportSettings(circleciProviderModel{
+			ClientCert: types.StringValue(certFile),
+			ClientKey:  types.StringValue(keyFile),
+		})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tlsConfig == nil || len(tlsConfig.Certificates) != 1 {
+			t.Fatal("client certificate not set")
+		}
+	})
+
+	t.Run("proxy_url", func(t *testing.T) {
+		_, proxy, diags := transportSettings(circleciProviderModel{ProxyURL: types.StringValue("http://proxy.example.com:3128")})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if proxy == nil || proxy.Host != "proxy.example.com:3128" {
+			t.Errorf("proxy = %v, want http://proxy.example.com:3128", proxy)
+		}
+	})
+
+	for _, tc := range []struct {
+		name        string
+		config      circleciProviderModel
+		wantSummary string
+	}{
+		{
+			name:        "bad_proxy_url",
+			config:      circleciProviderModel{ProxyURL: types.StringValue("proxy.example.com:3128")},
+			wantSummary: "Invalid Proxy URL",
+		},
+		{
+			name:        "bad_ca_cert_pem",
+			config:      circleciProviderModel{CACertPEM: types.StringValue("not a certificate")},
+			wantSummary: "Invalid CA Certificates",
+		},
+		{
+			name:        "missing_ca_cert_file",
+			config:      circleciProviderModel{CACertFile: types.StringValue(filepath.Join(dir, "missing.pem"))},
+			wantSummary: "Unable to Read CA Certificates",
+		},
+		{
+			name: "mismatched_client_key",
+			config: circleciProviderModel{
+				ClientCert: types.StringValue(certPEM),
+				ClientKey:  types.StringValue(certPEM),
+			},
+			wantSummary: "Invalid Client Certificate",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, diags := transportSettings(tc.config)
+			if !diags.HasError() || diags.Errors()[0].Summary() != tc.wantSummary {
+				t.Errorf("diagnostics = %v, want %q", diags, tc.wantSummary)
+			}
+		})
+	}
+}
+
+func TestAccProvider_TLS(t *testing.T) {
+	fc := fakecircle.New(testAccFakeToken)
+	srv := httptest.NewTLSServer(fc)
+	t.Cleanup(srv.Close)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "tls",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	config := func(settings string) string {
+		return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+  key         = %[2]q
+%[3]s
+}
+
+data "circleci_organization" "test" {
+  id = %[4]q
+}
+`, srv.URL, testAccFakeToken, settings, org.ID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`certificate`),
+			},
+			{
+				Config: config(fmt.Sprintf("  ca_cert_pem = %q", caPEM)),
+				Check:  resource.TestCheckResourceAttr("data.circleci_organization.test", "name", "tls"),
+			},
+			{
+				Config: config("  insecure_skip_verify = true"),
+				Check:  resource.TestCheckResourceAttr("data.circleci_organization.test", "name", "tls"),
+			},
+		},
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"gopkg.in/yaml.v3"
+
+	"terraform-provider-circleci/internal/circleci/client"
+)
+
+const (
+	// defaultKeyCommandTTL is how long the token printed by key_command is
+	// used for unless key_command_ttl is set.
+	defaultKeyCommandTTL = 5 * time.Minute
+	// keyCommandTimeout caps how long key_command may run.
+	keyCommandTimeout = time.Minute
+)
+
+// apiToken is where the provider gets its API token from.
+type apiToken struct {
+	// Key is the token as it was when the provider was configured.
+	Key string
+	// Source, if set, supplies the token for each request, for a token that
+	// can change during the run.
+	Source client.TokenSource
+	// Host is the v2 API host from the CircleCI CLI's config, when the token
+	// was read from there too.
+	Host string
+}
+
+// resolveToken finds the API token. In order, it comes from key, key_file or
+// key_command, the CIRCLE_TOKEN environment variable, or the CircleCI CLI's
+// config file. Key is empty when none of these are set.
+func resolveToken(ctx context.Context, config circleciProviderModel) (apiToken, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !config.Key.IsNull():
+		return apiToken{Key: config.Key.ValueString()}, diags
+
+	case !config.KeyFile.IsNull():
+		src := fileTokenSource(config.KeyFile.ValueString())
+		key, err := src.Token(ctx)
+		if err != nil {
+			diags.AddAttributeError(path.Root("key_file"), "Unable to Read CircleCI API Key File", err.Error())
+			return apiToken{}, diags
+		}
+		return apiToken{Key: key, Source: src}, diags
+
+	case !config.KeyCommand.IsNull():
+		var args []string
+		diags.Append(config.KeyCommand.ElementsAs(ctx, &args, false)...)
+		if diags.HasError() {
+			return apiToken{}, diags
+		}
+
+		ttl := defaultKeyCommandTTL
+		if !config.KeyCommandTTL.IsNull() {
+			d, err := time.ParseDuration(config.KeyCommandTTL.ValueString())
+			if err != nil || d < 0 {
+				diags.AddAttributeError(
+					path.Root("key_command_ttl"),
+					"Invalid Key Command TTL",
+					fmt.Sprintf("key_command_ttl must be a non-negative duration such as \"5m\", got %q.", config.KeyCommandTTL.ValueString()),
+				)
+				return apiToken{}, diags
+			}
+			ttl = d
+		}
+
+		src := &commandTokenSource{args: args, ttl: ttl}
+		key, err := src.Token(ctx)
+		if err != nil {
+			diags.AddAttributeError(path.Root("key_command"), "CircleCI API Key Command Failed", err.Error())
+			return apiToken{}, diags
+		}
+		return apiToken{Key: key, Source: src}, diags
+	}
+
+	if key := os.Getenv("CIRCLE_TOKEN"); key != "" {
+		return apiToken{Key: key}, diags
+	}
+
+	cli, err := readCLIConfig()
+	if err != nil {
+		diags.AddError(
+			"Unable to Read CircleCI CLI Config",
+			fmt.Sprintf("The provider looked for an API token in the CircleCI CLI's config, as key, key_file, key_command and CIRCLE_TOKEN are not set, but could not read it: %s", err),
+		)
+		return apiToken{}, diags
+	}
+	if cli == nil || cli.Token == "" {
+		return apiToken{}, diags
+	}
+	return apiToken{Key: cli.Token, Host: cli.apiHost()}, diags
+}
+
+// fileTokenSource reads the token from a file for each request, so a token
+// that is rotated on disk is picked up.
+type fileTokenSource string
+
+func (f fileTokenSource) Token(context.Context) (string, error) {
+	b, err := os.ReadFile(string(f))
+	if err != nil {
+		return "", err
+	}
+	key := strings.TrimSpace(string(b))
+	if key == "" {
+		return "", fmt.Errorf("%s is empty", string(f))
+	}
+	return key, nil
+}
+
+// commandTokenSource runs a command and uses what it prints as the token,
+// running it again once the token is older than ttl. A ttl of zero runs the
+// command for every request.
+type commandTokenSource struct {
+	args []string
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	key     string
+	expires time.Time
+}
+
+func (c *commandTokenSource) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key != "" && time.Now().Before(c.expires) {
+		return c.key, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, keyCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.args[0], c.args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", c.args[0], err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", c.args[0], err)
+	}
+
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		return "", fmt.Errorf("%s printed no token", c.args[0])
+	}
+	c.key = key
+	c.expires = time.Now().Add(c.ttl)
+	return key, nil
+}
+
+// cliConfig is the part of the CircleCI CLI's config file the provider uses.
+type cliConfig struct {
+	Host         string `yaml:"host"`
+	RestEndpoint string `yaml:"rest_endpoint"`
+	Token        string `yaml:"token"`
+}
+
+// apiHost returns the v2 API host the CLI uses, or "" for the default.
+func (c *cliConfig) apiHost() string {
+	if c.Host == "" {
+		return ""
+	}
+	endpoint := c.RestEndpoint
+	if endpoint == "" {
+		endpoint = "api/v2"
+	}
+	return strings.TrimSuffix(c.Host, "/") + "/" + strings.TrimPrefix(endpoint, "/")
+}
+
+// readCLIConfig reads ~/.circleci/cli.yml. It returns nil when there is no
+// such file, or no home directory to find it in.
+func readCLIConfig() (*cliConfig, error) {
+	home, _ := os.UserHomeDir()
+	if home == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(filepath.Join(home, ".circleci", "cli.yml"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var c cliConfig
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,217 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestResolveToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("CIRCLE_TOKEN", "")
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "token")
+	writeFile := func(t *testing.T, name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commandConfig := func(ttl string, args ...string) circleciProviderModel {
+		command, _ := types.ListValueFrom(context.Background(), types.StringType, args)
+		config := circleciProviderModel{KeyCommand: command}
+		if ttl != "" {
+			config.KeyCommandTTL = types.StringValue(ttl)
+		}
+		return config
+	}
+	// counter prints how many times it has been run.
+	counter := func(t *testing.T) []string {
+		return []string{"sh", "-c", `echo run >> "$0" && wc -l < "$0"`, filepath.Join(t.TempDir(), "runs")}
+	}
+
+	t.Run("none", func(t *testing.T) {
+		tok, diags := resolveToken(context.Background(), circleciProviderModel{})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tok.Key != "" || tok.Source != nil {
+			t.Errorf("token = %+v, want none", tok)
+		}
+	})
+
+	t.Run("key_file", func(t *testing.T) {
+		writeFile(t, keyFile, "first\n")
+		tok, diags := resolveToken(context.Background(), circleciProviderModel{KeyFile: types.StringValue(keyFile)})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tok.Key != "first" {
+			t.Errorf("key = %q, want %q", tok.Key, "first")
+		}
+
+		writeFile(t, keyFile, "second\n")
+		key, err := tok.Source.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != "second" {
+			t.Errorf("key after rotation = %q, want %q", key, "second")
+		}
+	})
+
+	t.Run("key_file_missing", func(t *testing.T) {
+		_, diags := resolveToken(context.Background(), circleciProviderModel{KeyFile: types.StringValue(filepath.Join(dir, "missing"))})
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Unable to Read CircleCI API Key File" {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("key_file_empty", func(t *testing.T) {
+		empty := filepath.Join(dir, "empty")
+		writeFile(t, empty, "\n")
+		_, diags := resolveToken(context.Background(), circleciProviderModel{KeyFile: types.StringValue(empty)})
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Unable to Read CircleCI API Key File" {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("key_command_cached", func(t *testing.T) {
+		tok, diags := resolveToken(context.Background(), commandConfig("", counter(t)...))
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		key, err := tok.Source.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok.Key != "1" || key != "1" {
+			t.Errorf("keys = %q and %q, want the command run once", tok.Key, key)
+		}
+	})
+
+	t.Run("key_command_uncached", func(t *testing.T) {
+		tok, diags := resolveToken(context.Background(), commandConfig("0s", counter(t)...))
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		key, err := tok.Source.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok.Key != "1" || key != "2" {
+			t.Errorf("keys = %q and %q, want the command run for each", tok.Key, key)
+		}
+	})
+
+	t.Run("key_command_fails", func(t *testing.T) {
+		_, diags := resolveToken(context.Background(), commandConfig("", "sh", "-c", "echo locked >&2; exit 1"))
+		if !diags.HasError() || diags.Errors()[0].Summary() != "CircleCI API Key Command Failed" {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if !regexp.MustCompile(`locked`).MatchString(diags.Errors()[0].Detail()) {
+			t.Errorf("detail %q does not include the command's stderr", diags.Errors()[0].Detail())
+		}
+	})
+
+	t.Run("key_command_ttl_invalid", func(t *testing.T) {
+		_, diags := resolveToken(context.Background(), commandConfig("soon", "echo", "token"))
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Key Command TTL" {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("cli_config", func(t *testing.T) {
+		writeFile(t, filepath.Join(home, ".circleci", "cli.yml"), "host: https://circleci.example.com/\ntoken: from-cli\n")
+		tok, diags := resolveToken(context.Background(), circleciProviderModel{})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tok.Key != "from-cli" || tok.Host != "https://circleci.example.com/api/v2" {
+			t.Errorf("token = %+v, want the CLI's token and host", tok)
+		}
+
+		// CIRCLE_TOKEN comes first, and the CLI's host is only used with
+		// its token.
+		t.Setenv("CIRCLE_TOKEN", "from-env")
+		tok, diags = resolveToken(context.Background(), circleciProviderModel{})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if tok.Key != "from-env" || tok.Host != "" {
+			t.Errorf("token = %+v, want CIRCLE_TOKEN and no host", tok)
+		}
+	})
+
+	t.Run("cli_config_invalid", func(t *testing.T) {
+		writeFile(t, filepath.Join(home, ".circleci", "cli.yml"), "token: [\n")
+		_, diags := resolveToken(context.Background(), circleciProviderModel{})
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Unable to Read CircleCI CLI Config" {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+}
+
+func TestAccProvider_KeyFile(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "key-file",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(keyFile, []byte(testAccFakeToken+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(settings string) string {
+		return fmt.Sprintf(`
+provider "circleci" {
+  host        = "%[1]s/api/v2"
+  runner_host = %[1]q
+%[2]s
+}
+
+resource "circleci_context" "test_context" {
+  name            = "key-file"
+  organization_id = %[3]q
+}
+`, fc.URL, settings, org.ID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf("  key_file = %q", keyFile)),
+				Check:  resource.TestCheckResourceAttr("circleci_context.test_context", "name", "key-file"),
+			},
+			{
+				Config: config(fmt.Sprintf("  key_command = [\"cat\", %q]", keyFile)),
+				Check:  resource.TestCheckResourceAttr("circleci_context.test_context", "name", "key-file"),
+			},
+			{
+				Config:      config(fmt.Sprintf("  key_file = %q\n  key = \"other\"", keyFile)),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/runner"
+	"terraform-provider-circleci/internal/circleci/user"
+)
+
+// validateMaxRetries caps the retries made while validating credentials, so
+// a wrong host fails the plan quickly rather than after a full backoff.
+const validateMaxRetries = 2
+
+// credentials is what validateCredentials learns about the token.
+type credentials struct {
+	User          *user.User
+	Organizations []user.Collaboration
+}
+
+// validateCredentials checks that host serves the CircleCI v2 API and accepts
+// the token. When runnerHost is set it also checks the runner API there.
+func validateCredentials(ctx context.Context, c *client.Client, host, runnerHost string) (*credentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	users := user.NewUserService(c)
+
+	me, err := users.Me(ctx)
+	if err != nil {
+		diags.Append(credentialsError(path.Root("host"), "CircleCI API", host, err))
+		return nil, diags
+	}
+	if me.Id == "" {
+		diags.AddAttributeError(
+			path.Root("host"),
+			"Unexpected CircleCI API Response",
+			fmt.Sprintf("%s/me did not return a user ID, so the host does not appear to serve version 2 of the CircleCI API. "+
+				"The host must include the API version, e.g. \"https://circleci.com/api/v2\".", host),
+		)
+		return nil, diags
+	}
+
+	orgs, err := users.Collaborations(ctx)
+	if err != nil {
+		diags.Append(credentialsError(path.Root("host"), "CircleCI API", host, err))
+		return nil, diags
+	}
+
+	if runnerHost != "" {
+		// The runner API has no endpoint without a filter, so list the
+		// resource classes of one of the user's orgs.
+		var orgID string
+		if len(orgs) > 0 {
+			orgID = orgs[0].Id
+		}
+		_, err := runner.NewServiceWithBaseURL(c, runnerHost).ListResourceClasses(ctx, "", orgID)
+		// A 400 about the filter still shows the API is there and took the
+		// token.
+		if err != nil && !client.HasStatus(err, http.StatusBadRequest) {
+			diags.Append(credentialsError(path.Root("runner_host"), "CircleCI runner API", runnerHost, err))
+			return nil, diags
+		}
+	}
+
+	return &credentials{User: me, Organizations: orgs}, diags
+}
+
+// credentialsError explains why a request to check the credentials failed.
+// hostAttr is the setting that points at the API that was called.
+func credentialsError(hostAttr path.Path, api, host string, err error) diag.Diagnostic {
+	const disable = " To skip this check, set validate_credentials to false."
+
+	var apiErr *client.APIError
+	switch {
+	case client.IsUnauthorized(err), client.HasStatus(err, http.StatusForbidden):
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("key"),
+			"Invalid CircleCI API Token",
+			fmt.Sprintf("The %s at %s rejected the API token: %s. "+
+				"Check the key setting or the CIRCLE_TOKEN environment variable.", api, host, err)+disable,
+		)
+	case client.IsNotFound(err):
+		return diag.NewAttributeErrorDiagnostic(
+			hostAttr,
+			"CircleCI API Not Found",
+			fmt.Sprintf("%s does not serve the %s: %s. "+
+				"Check the host is correct and, for the v2 API, that it includes the API version, e.g. \"https://circleci.com/api/v2\".", host, api, err)+disable,
+		)
+	case errors.As(err, &apiErr):
+		return diag.NewAttributeErrorDiagnostic(
+			hostAttr,
+			"Unable to Validate CircleCI Credentials",
+			fmt.Sprintf("The %s at %s returned an error: %s.", api, host, err)+disable,
+		)
+	default:
+		return diag.NewAttributeErrorDiagnostic(
+			hostAttr,
+			"Unable to Reach CircleCI API",
+			fmt.Sprintf("The provider could not get a valid response from the %s at %s: %s. "+
+				"Check the host is correct and reachable.", api, host, err)+disable,
+		)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "validate",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newClient := func(host, tok string) *client.Client {
+		return client.NewClientWithOptions(host, tok, "terraform-provider-circleci/test", client.Options{
+			MaxRetries:   validateMaxRetries,
+			RetryMaxWait: 10 * time.Millisecond,
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		host := fc.URL + "/api/v2"
+		creds, diags := validateCredentials(context.Background(), newClient(host, testAccFakeToken), host, fc.URL)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if creds.User.Id != fakecircle.DefaultUser.ID.String() {
+			t.Errorf("user ID = %q, want %q", creds.User.Id, fakecircle.DefaultUser.ID)
+		}
+		if len(creds.Organizations) != 1 || creds.Organizations[0].Id != org.ID.String() {
+			t.Errorf("organizations = %+v, want just %s", creds.Organizations, org.ID)
+		}
+	})
+
+	for _, tc := range []struct {
+		name        string
+		host        string
+		tok         string
+		runnerHost  string
+		wantAttr    string
+		wantSummary string
+	}{
+		{
+			name:        "bad_token",
+			host:        fc.URL + "/api/v2",
+			tok:         "not-valid",
+			wantAttr:    "key",
+			wantSummary: "Invalid CircleCI API Token",
+		},
+		{
+			name:        "missing_api_version",
+			host:        fc.URL,
+			tok:         testAccFakeToken,
+			wantAttr:    "host",
+			wantSummary: "CircleCI API Not Found",
+		},
+		{
+			name:        "wrong_runner_host",
+			host:        fc.URL + "/api/v2",
+			tok:         testAccFakeToken,
+			runnerHost:  fc.URL + "/api/v2",
+			wantAttr:    "runner_host",
+			wantSummary: "CircleCI API Not Found",
+		},
+		{
+			name:        "unreachable",
+			host:        "http://127.0.0.1:1/api/v2",
+			tok:         testAccFakeToken,
+			wantAttr:    "host",
+			wantSummary: "Unable to Reach CircleCI API",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, diags := validateCredentials(context.Background(), newClient(tc.host, tc.tok), tc.host, tc.runnerHost)
+			if !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			d := diags.Errors()[0]
+			if d.Summary() != tc.wantSummary {
+				t.Errorf("summary = %q, want %q", d.Summary(), tc.wantSummary)
+			}
+			if !strings.Contains(d.Detail(), "validate_credentials") {
+				t.Errorf("detail %q does not say how to skip the check", d.Detail())
+			}
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if !ok || withPath.Path().String() != tc.wantAttr {
+				t.Errorf("diagnostic is not on %s: %v", tc.wantAttr, d)
+			}
+		})
+	}
+
+	t.Run("not_a_v2_api", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"login":"someone"}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		_, diags := validateCredentials(context.Background(), newClient(srv.URL, testAccFakeToken), srv.URL, "")
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Unexpected CircleCI API Response" {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+}
+
+func TestAccProvider_ValidateCredentials(t *testing.T) {
+	fc := testAccFakeCircle(t)
+
+	config := func(tok, settings string) string {
+		return `
+provider "circleci" {
+  host        = "` + fc.URL + `/api/v2"
+  runner_host = "` + fc.URL + `"
+  key         = "` + tok + `"
+` + settings + `
+}
+
+data "circleci_organization" "test" {
+  id = "00000000-0000-0000-0000-000000000000"
+}
+`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("not-valid", ""),
+				ExpectError: regexp.MustCompile(`Invalid CircleCI API Token`),
+			},
+			// Without the check the bad token only fails the data source.
+			{
+				Config:      config("not-valid", "  validate_credentials = false"),
+				ExpectError: regexp.MustCompile(`401 Unauthorized`),
+			},
+		},
+	})
+}
+
+// TestAccOrganizationDataSource_cached checks that an org found while
+// validating the credentials is not fetched again.
+func TestAccOrganizationDataSource_cached(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeGitHub,
+		Name: "cached",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: fc.ResetCalls,
+				Config: fc.ProviderConfig() + `
+data "circleci_organization" "test" {
+  id = "` + org.ID.String() + `"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_organization.test", "name", "cached"),
+					resource.TestCheckResourceAttr("data.circleci_organization.test", "slug", org.Slug),
+					fc.CheckCallCount(http.MethodGet, "/organization/*", 0),
+				),
+			},
+		},
+	})
+}
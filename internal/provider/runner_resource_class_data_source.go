@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-circleci/internal/circleci/runner"
@@ -37,7 +38,8 @@ func NewRunnerResourceClassDataSource() datasource.DataSource {
 
 // runnerResourceClassDataSource is the data source implementation.
 type runnerResourceClassDataSource struct {
-	client *runner.Service
+	client   *runner.Service
+	defaults providerDefaults
 }
 
 // Metadata returns the data source type name.
@@ -51,8 +53,9 @@ func (d *runnerResourceClassDataSource) Schema(_ context.Context, _ datasource.S
 		MarkdownDescription: "Reads a CircleCI runner resource class.",
 		Attributes: map[string]schema.Attribute{
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "The organization id.",
-				Required:            true,
+				MarkdownDescription: "The organization id. Defaults to the provider's `default_organization_id`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"resource_class": schema.StringAttribute{
 				MarkdownDescription: "The resource class name in `namespace/name` format (e.g. `myorg/myrunner`).",
@@ -72,6 +75,9 @@ func (d *runnerResourceClassDataSource) Schema(_ context.Context, _ datasource.S
 
 // Read fetches the resource class from the API.
 func (d *runnerResourceClassDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_runner_resource_class", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state runnerResourceClassDataSourceModel
 	diags := req.Config.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -79,6 +85,18 @@ func (d *runnerResourceClassDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
+	if state.OrganizationId.IsNull() {
+		if d.defaults.OrganizationID == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("organization_id"),
+				"Missing organization_id",
+				"Set organization_id, or set default_organization_id in the provider configuration.",
+			)
+			return
+		}
+		state.OrganizationId = types.StringValue(d.defaults.OrganizationID)
+	}
+
 	organizationId := state.OrganizationId.ValueString()
 	uuidOrgRegex := regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
 	if !uuidOrgRegex.MatchString(organizationId) {
@@ -149,4 +167,5 @@ func (d *runnerResourceClassDataSource) Configure(_ context.Context, req datasou
 	}
 
 	d.client = client.RunnerService
+	d.defaults = client.Defaults
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/runner"
 )
 
@@ -23,6 +24,7 @@ import (
 var (
 	_ resource.Resource                = &runnerResourceClassResource{}
 	_ resource.ResourceWithConfigure   = &runnerResourceClassResource{}
+	_ resource.ResourceWithModifyPlan  = &runnerResourceClassResource{}
 	_ resource.ResourceWithImportState = &runnerResourceClassResource{}
 )
 
@@ -42,7 +44,8 @@ func NewRunnerResourceClassResource() resource.Resource {
 
 // runnerResourceClassResource is the resource implementation.
 type runnerResourceClassResource struct {
-	client *runner.Service
+	client   *runner.Service
+	defaults providerDefaults
 }
 
 // Metadata returns the resource type name.
@@ -63,8 +66,12 @@ func (r *runnerResourceClassResource) Schema(_ context.Context, _ resource.Schem
 				},
 			},
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "The organization id.",
-				Required:            true,
+				MarkdownDescription: "The organization id. Defaults to the provider's `default_organization_id`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"resource_class": schema.StringAttribute{
 				MarkdownDescription: "The resource class name in `namespace/name` format (e.g. `myorg/myrunner`). Changing this value forces a new resource to be created.",
@@ -95,6 +102,9 @@ func (r *runnerResourceClassResource) Schema(_ context.Context, _ resource.Schem
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *runnerResourceClassResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_runner_resource_class", "Create")
+	defer end(&resp.Diagnostics)
+
 	var plan runnerResourceClassResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -126,6 +136,9 @@ func (r *runnerResourceClassResource) Create(ctx context.Context, req resource.C
 
 // Read refreshes the Terraform state with the latest data.
 func (r *runnerResourceClassResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_runner_resource_class", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state runnerResourceClassResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -144,7 +157,13 @@ func (r *runnerResourceClassResource) Read(ctx context.Context, req resource.Rea
 	}
 	namespace := rcName[:slashIdx]
 
+	// Use the token for the resource class's org, if the provider has one.
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
 	classes, err := r.client.ListResourceClasses(ctx, namespace, "")
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading CircleCI runner resource classes",
@@ -178,6 +197,9 @@ func (r *runnerResourceClassResource) Read(ctx context.Context, req resource.Rea
 // Update persists plan values (such as organization_id and force_delete) into
 // state. The runner API has no update endpoint, so no API call is made.
 func (r *runnerResourceClassResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_runner_resource_class", "Update")
+	defer end(&resp.Diagnostics)
+
 	var plan runnerResourceClassResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -191,6 +213,9 @@ func (r *runnerResourceClassResource) Update(ctx context.Context, req resource.U
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *runnerResourceClassResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_runner_resource_class", "Delete")
+	defer end(&resp.Diagnostics)
+
 	var state runnerResourceClassResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -198,8 +223,9 @@ func (r *runnerResourceClassResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
 	err := r.client.DeleteResourceClass(ctx, state.Id.ValueString(), state.ForceDelete.ValueBool())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting CircleCI runner resource class",
 			"Could not delete runner resource class "+state.Id.ValueString()+": "+err.Error(),
@@ -207,6 +233,15 @@ func (r *runnerResourceClassResource) Delete(ctx context.Context, req resource.D
 	}
 }
 
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *runnerResourceClassResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, false)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *runnerResourceClassResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -223,6 +258,7 @@ func (r *runnerResourceClassResource) Configure(_ context.Context, req resource.
 	}
 
 	r.client = client.RunnerService
+	r.defaults = client.Defaults
 }
 
 // ImportState imports an existing resource class into Terraform state.
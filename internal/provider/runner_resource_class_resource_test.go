@@ -4,14 +4,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/runner"
 )
 
 func TestAccRunnerResourceClassResource(t *testing.T) {
@@ -120,3 +124,29 @@ resource "circleci_runner_resource_class" "test" {
 }
 `, organizationId, resourceClass, description, forceDelete)
 }
+
+func TestAccRunnerResourceClassResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	config := fc.ProviderConfig() + testAccRunnerResourceClassConfig(testAccRunnerOrgID, "disappears/rc", "disappears", false)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_runner_resource_class.test", func(ctx context.Context, attrs map[string]string) error {
+					return runner.NewServiceWithBaseURL(fc.Client, fc.URL).DeleteResourceClass(ctx, attrs["id"], true)
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_runner_resource_class.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
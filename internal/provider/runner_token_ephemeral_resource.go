@@ -0,0 +1,235 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-circleci/internal/circleci/client"
+	"terraform-provider-circleci/internal/circleci/runner"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &runnerTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &runnerTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithRenew     = &runnerTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &runnerTokenEphemeralResource{}
+)
+
+// runnerTokenPrivateKey is the private data key under which Open records the
+// token Renew and Close delete.
+const runnerTokenPrivateKey = "runner_token"
+
+// runnerTokenEphemeralResourceModel maps the ephemeral resource schema.
+type runnerTokenEphemeralResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	ResourceClass  types.String `tfsdk:"resource_class"`
+	Nickname       types.String `tfsdk:"nickname"`
+	Lifetime       types.String `tfsdk:"lifetime"`
+	Token          types.String `tfsdk:"token"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+}
+
+// runnerTokenPrivate is what Open records for Renew and Close.
+type runnerTokenPrivate struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organization_id"`
+}
+
+// NewRunnerTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewRunnerTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &runnerTokenEphemeralResource{}
+}
+
+// runnerTokenEphemeralResource is the ephemeral resource implementation.
+type runnerTokenEphemeralResource struct {
+	client   *runner.Service
+	defaults providerDefaults
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *runnerTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runner_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *runnerTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a CircleCI runner authentication token for the length of a Terraform run, without storing it in state or plan files. The token is deleted when Terraform is done with it, or once its `lifetime` has passed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier (UUID) of the runner token.",
+				Computed:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization that owns the resource class. Defaults to the provider's `default_organization_id`.",
+				Optional:            true,
+			},
+			"resource_class": schema.StringAttribute{
+				MarkdownDescription: "The resource class this token grants access to, in `namespace/name` format (e.g. `myorg/myrunner`).",
+				Required:            true,
+			},
+			"nickname": schema.StringAttribute{
+				MarkdownDescription: "A human-readable label for the token.",
+				Required:            true,
+			},
+			"lifetime": schema.StringAttribute{
+				MarkdownDescription: "How long the token lasts, as a duration such as `\"30m\"`. Once it has passed the token is deleted, even if the Terraform run is still going. By default the token lasts until Terraform is done with it.",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The token value used to authenticate a runner agent.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The time at which the token was created.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Open creates the token.
+func (r *runnerTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, end := traceOperation(ctx, "ephemeral.circleci_runner_token", "Open")
+	defer end(&resp.Diagnostics)
+
+	var config runnerTokenEphemeralResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.OrganizationId.IsNull() {
+		if r.defaults.OrganizationID == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("organization_id"),
+				"Missing organization_id",
+				"Set organization_id, or set default_organization_id in the provider configuration.",
+			)
+			return
+		}
+		config.OrganizationId = types.StringValue(r.defaults.OrganizationID)
+	}
+
+	var lifetime time.Duration
+	if !config.Lifetime.IsNull() {
+		d, err := time.ParseDuration(config.Lifetime.ValueString())
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lifetime"),
+				"Invalid Lifetime",
+				fmt.Sprintf("lifetime must be a positive duration such as \"30m\", got %q.", config.Lifetime.ValueString()),
+			)
+			return
+		}
+		lifetime = d
+	}
+
+	t, err := r.client.CreateToken(ctx, runner.CreateTokenRequest{
+		OrganizationID: config.OrganizationId.ValueString(),
+		ResourceClass:  config.ResourceClass.ValueString(),
+		Nickname:       config.Nickname.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating CircleCI runner token",
+			"Could not create runner token, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	private, err := json.Marshal(runnerTokenPrivate{Id: t.Id, OrganizationId: config.OrganizationId.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Error recording CircleCI runner token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, runnerTokenPrivateKey, private)...)
+
+	if lifetime > 0 {
+		resp.RenewAt = time.Now().Add(lifetime)
+	}
+
+	config.Id = types.StringValue(t.Id)
+	config.Token = types.StringValue(t.Token)
+	config.CreatedAt = types.StringValue(t.CreatedAt)
+
+	diags = resp.Result.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Renew is called once the token's lifetime has passed, and deletes it. The
+// token is not replaced, as Terraform has already used its value.
+func (r *runnerTokenEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	ctx, end := traceOperation(ctx, "ephemeral.circleci_runner_token", "Renew")
+	defer end(&resp.Diagnostics)
+
+	r.deleteToken(ctx, req.Private.GetKey, &resp.Diagnostics)
+	// Close has nothing left to delete.
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, runnerTokenPrivateKey, nil)...)
+}
+
+// Close deletes the token, if its lifetime has not already.
+func (r *runnerTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx, end := traceOperation(ctx, "ephemeral.circleci_runner_token", "Close")
+	defer end(&resp.Diagnostics)
+
+	r.deleteToken(ctx, req.Private.GetKey, &resp.Diagnostics)
+}
+
+// deleteToken deletes the token recorded in the private data read by getKey.
+func (r *runnerTokenEphemeralResource) deleteToken(ctx context.Context, getKey func(context.Context, string) ([]byte, diag.Diagnostics), diags *diag.Diagnostics) {
+	b, d := getKey(ctx, runnerTokenPrivateKey)
+	diags.Append(d...)
+	if diags.HasError() || len(b) == 0 {
+		return
+	}
+
+	var private runnerTokenPrivate
+	if err := json.Unmarshal(b, &private); err != nil {
+		diags.AddError("Error reading CircleCI runner token", err.Error())
+		return
+	}
+
+	ctx = client.WithOrganization(ctx, private.OrganizationId)
+	err := r.client.DeleteToken(ctx, private.Id)
+	if err != nil && !client.IsNotFound(err) {
+		diags.AddError(
+			"Error deleting CircleCI runner token",
+			"Could not delete runner token "+private.Id+": "+err.Error(),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *runnerTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleCiClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *CircleCiClientWrapper, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client.RunnerService
+	r.defaults = client.Defaults
+}
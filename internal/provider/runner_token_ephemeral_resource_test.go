@@ -0,0 +1,200 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+)
+
+// testProtoServer returns the provider's protocol server, configured to use
+// the fake.
+func testProtoServer(t *testing.T, fc *testAccFake) tfprotov6.ProviderServer {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := testDynamicValue(t, schemas.Provider.ValueType(), map[string]tftypes.Value{
+		"host":        tftypes.NewValue(tftypes.String, fc.URL+"/api/v2"),
+		"runner_host": tftypes.NewValue(tftypes.String, fc.URL),
+		"key":         tftypes.NewValue(tftypes.String, testAccFakeToken),
+	})
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCheckProtoDiagnostics(t, resp.Diagnostics)
+
+	return server
+}
+
+// testDynamicValue encodes an object of type typ with the given attributes,
+// and every other attribute null, or empty for a block list.
+func testDynamicValue(t *testing.T, typ tftypes.Type, attrs map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	obj := typ.(tftypes.Object)
+	vals := make(map[string]tftypes.Value, len(obj.AttributeTypes))
+	for name, attrType := range obj.AttributeTypes {
+		switch v, ok := attrs[name]; {
+		case ok:
+			vals[name] = v
+		case attrType.Is(tftypes.List{}) && attrType.(tftypes.List).ElementType.Is(tftypes.Object{}):
+			vals[name] = tftypes.NewValue(attrType, []tftypes.Value{})
+		default:
+			vals[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, vals))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+func testCheckProtoDiagnostics(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func TestRunnerTokenEphemeralResource(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "ephemeral",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fc.AddResourceClass("8f2d6c1e-3b4a-4d5e-9f6a-7b8c9d0e1f2a", "ephemeral/rc", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	server := testProtoServer(t, fc)
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemas.EphemeralResourceSchemas["circleci_runner_token"].ValueType()
+
+	open := func(t *testing.T, lifetime string) *tfprotov6.OpenEphemeralResourceResponse {
+		t.Helper()
+
+		attrs := map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, org.ID.String()),
+			"resource_class":  tftypes.NewValue(tftypes.String, "ephemeral/rc"),
+			"nickname":        tftypes.NewValue(tftypes.String, "ephemeral"),
+		}
+		if lifetime != "" {
+			attrs["lifetime"] = tftypes.NewValue(tftypes.String, lifetime)
+		}
+		resp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+			TypeName: "circleci_runner_token",
+			Config:   testDynamicValue(t, typ, attrs),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	closeToken := func(t *testing.T, private []byte) {
+		t.Helper()
+
+		resp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+			TypeName: "circleci_runner_token",
+			Private:  private,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testCheckProtoDiagnostics(t, resp.Diagnostics)
+	}
+
+	t.Run("close", func(t *testing.T) {
+		fc.ResetCalls()
+
+		resp := open(t, "")
+		testCheckProtoDiagnostics(t, resp.Diagnostics)
+		if !resp.RenewAt.IsZero() {
+			t.Errorf("RenewAt = %v, want none without a lifetime", resp.RenewAt)
+		}
+
+		result, err := resp.Result.Unmarshal(typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var vals map[string]tftypes.Value
+		if err := result.As(&vals); err != nil {
+			t.Fatal(err)
+		}
+		var token string
+		if err := vals["token"].As(&token); err != nil || token == "" {
+			t.Fatalf("token = %q, want a token", token)
+		}
+
+		fc.AssertCallCount(t, http.MethodPost, "/runner/token", 1)
+		fc.AssertCallCount(t, http.MethodDelete, "/runner/token/*", 0)
+
+		closeToken(t, resp.Private)
+		fc.AssertCallCount(t, http.MethodDelete, "/runner/token/*", 1)
+	})
+
+	t.Run("lifetime", func(t *testing.T) {
+		fc.ResetCalls()
+
+		resp := open(t, "30m")
+		testCheckProtoDiagnostics(t, resp.Diagnostics)
+		if d := time.Until(resp.RenewAt); d < 29*time.Minute || d > 30*time.Minute {
+			t.Errorf("RenewAt is %v away, want 30m", d)
+		}
+
+		renewed, err := server.RenewEphemeralResource(ctx, &tfprotov6.RenewEphemeralResourceRequest{
+			TypeName: "circleci_runner_token",
+			Private:  resp.Private,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testCheckProtoDiagnostics(t, renewed.Diagnostics)
+		fc.AssertCallCount(t, http.MethodDelete, "/runner/token/*", 1)
+
+		// The token is already gone, so Close has nothing to do.
+		closeToken(t, renewed.Private)
+		fc.AssertCallCount(t, http.MethodDelete, "/runner/token/*", 1)
+	})
+
+	t.Run("invalid_lifetime", func(t *testing.T) {
+		fc.ResetCalls()
+
+		resp := open(t, "soon")
+		if len(resp.Diagnostics) == 0 || resp.Diagnostics[0].Summary != "Invalid Lifetime" {
+			t.Errorf("diagnostics = %v, want Invalid Lifetime", resp.Diagnostics)
+		}
+		fc.AssertCallCount(t, http.MethodPost, "/runner/token", 0)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/runner"
 )
 
@@ -22,6 +23,7 @@ import (
 var (
 	_ resource.Resource                = &runnerTokenResource{}
 	_ resource.ResourceWithConfigure   = &runnerTokenResource{}
+	_ resource.ResourceWithModifyPlan  = &runnerTokenResource{}
 	_ resource.ResourceWithImportState = &runnerTokenResource{}
 )
 
@@ -42,7 +44,8 @@ func NewRunnerTokenResource() resource.Resource {
 
 // runnerTokenResource is the resource implementation.
 type runnerTokenResource struct {
-	client *runner.Service
+	client   *runner.Service
+	defaults providerDefaults
 }
 
 // Metadata returns the resource type name.
@@ -63,10 +66,11 @@ func (r *runnerTokenResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the organization that owns the resource class. Changing this value forces a new resource to be created.",
-				Required:            true,
-				Computed:            false,
+				MarkdownDescription: "The ID of the organization that owns the resource class. Defaults to the provider's `default_organization_id`. Changing this value forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -105,6 +109,9 @@ func (r *runnerTokenResource) Schema(_ context.Context, _ resource.SchemaRequest
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *runnerTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_runner_token", "Create")
+	defer end(&resp.Diagnostics)
+
 	var plan runnerTokenResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -139,6 +146,9 @@ func (r *runnerTokenResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *runnerTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_runner_token", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state runnerTokenResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -146,7 +156,14 @@ func (r *runnerTokenResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	// Use the token for the runner token's org, if the provider has one.
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
 	tokens, err := r.client.ListTokens(ctx, state.ResourceClass.ValueString())
+	if client.IsNotFound(err) {
+		// The resource class, and so every token on it, was deleted.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading CircleCI runner tokens",
@@ -184,6 +201,9 @@ func (r *runnerTokenResource) Update(_ context.Context, _ resource.UpdateRequest
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *runnerTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_runner_token", "Delete")
+	defer end(&resp.Diagnostics)
+
 	var state runnerTokenResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -191,8 +211,9 @@ func (r *runnerTokenResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx = client.WithOrganization(ctx, state.OrganizationId.ValueString())
 	err := r.client.DeleteToken(ctx, state.Id.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting CircleCI runner token",
 			"Could not delete runner token "+state.Id.ValueString()+": "+err.Error(),
@@ -200,6 +221,15 @@ func (r *runnerTokenResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 }
 
+// ModifyPlan fills in organization_id from the provider's default_organization_id.
+func (r *runnerTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider has not been configured yet, e.g. during validation.
+	if r.client == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, path.Root("organization_id"), "default_organization_id", r.defaults.OrganizationID, true)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *runnerTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -216,6 +246,7 @@ func (r *runnerTokenResource) Configure(_ context.Context, req resource.Configur
 	}
 
 	r.client = client.RunnerService
+	r.defaults = client.Defaults
 }
 
 // ImportState imports an existing runner token into Terraform state.
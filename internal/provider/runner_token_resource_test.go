@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
@@ -11,9 +12,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/runner"
 )
 
 func TestAccRunnerTokenResource(t *testing.T) {
@@ -93,3 +97,29 @@ resource "circleci_runner_token" "test" {
 }
 `, organizationId, resourceClass, nickname)
 }
+
+func TestAccRunnerTokenResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	config := fc.ProviderConfig() + testAccRunnerTokenConfig(testAccRunnerOrgID, "disappears/rc", "disappears")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_runner_token.test", func(ctx context.Context, attrs map[string]string) error {
+					return runner.NewServiceWithBaseURL(fc.Client, fc.URL).DeleteToken(ctx, attrs["id"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_runner_token.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,12 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "time"
+
+// formatTimestamp formats a timestamp returned by the API the way the
+// provider exports timestamps: in UTC, with milliseconds.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
@@ -0,0 +1,34 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   time.Time
+		want string
+	}{
+		{
+			name: "utc",
+			in:   time.Date(2025, 3, 4, 5, 6, 7, 890000000, time.UTC),
+			want: "2025-03-04T05:06:07.890Z",
+		},
+		{
+			name: "other_zone",
+			in:   time.Date(2025, 3, 4, 7, 6, 7, 890000000, time.FixedZone("UTC+2", 2*60*60)),
+			want: "2025-03-04T05:06:07.890Z",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := formatTimestamp(tc.in); got != tc.want {
+				t.Errorf("formatTimestamp(%v) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
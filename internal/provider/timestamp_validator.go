@@ -0,0 +1,43 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = timestampValidator{}
+
+type timestampValidator struct{}
+
+func (v timestampValidator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 timestamp (e.g. \"2026-01-02T15:04:05Z\")"
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Must be an RFC 3339 timestamp, such as \"2026-01-02T15:04:05Z\", got %q.", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// TimestampValidator returns a validator that checks for an RFC 3339
+// timestamp, such as those timestamp() returns.
+func TimestampValidator() validator.String {
+	return timestampValidator{}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimestampValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expectError bool
+	}{
+		{name: "utc", value: "2026-01-02T15:04:05Z", expectError: false},
+		{name: "offset", value: "2026-01-02T15:04:05+02:00", expectError: false},
+		{name: "fractional seconds", value: "2026-01-02T15:04:05.123Z", expectError: false},
+
+		{name: "date only", value: "2026-01-02", expectError: true},
+		{name: "no zone", value: "2026-01-02T15:04:05", expectError: true},
+		{name: "space separator", value: "2026-01-02 15:04:05Z", expectError: true},
+		{name: "empty string", value: "", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: types.StringValue(tc.value),
+			}
+			resp := &validator.StringResponse{}
+			TimestampValidator().ValidateString(context.Background(), req, resp)
+
+			if tc.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected validation error for %q but got none", tc.value)
+			}
+			if !tc.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("unexpected validation error for %q: %s", tc.value, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -120,6 +120,9 @@ func (d *TriggerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (d *TriggerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "data.circleci_trigger", "Read")
+	defer end(&resp.Diagnostics)
+
 	var triggerState triggerDataSourceModel
 	diags := req.Config.Get(ctx, &triggerState)
 	if diags != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/common"
 	"terraform-provider-circleci/internal/circleci/trigger"
 )
@@ -174,6 +175,9 @@ func (r *triggerResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_trigger", "Create")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var circleCiTerrformTriggerResource triggerResourceModel
 	diags := req.Plan.Get(ctx, &circleCiTerrformTriggerResource)
@@ -408,6 +412,9 @@ func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_trigger", "Read")
+	defer end(&resp.Diagnostics)
+
 	var triggerState triggerResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &triggerState)...)
@@ -427,14 +434,12 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	readTrigger, err := r.client.Get(ctx, triggerState.ProjectId.ValueString(), triggerState.Id.ValueString())
+	if client.IsNotFound(err) {
+		// The trigger was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		if isApiNotFoundError(err) {
-			// This is the line that must be hit when the resource is gone.
-			resp.State.RemoveResource(ctx)
-			return // Successfully removed resource from state
-		}
-
-		// Standard error return path
 		resp.Diagnostics.AddError("Error Reading Trigger", fmt.Sprintf("API error during read: %s", err.Error()))
 		return
 	}
@@ -530,6 +535,9 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_trigger", "Update")
+	defer end(&resp.Diagnostics)
+
 	var state triggerResourceModel
 
 	// Read Terraform plan data into the model
@@ -664,6 +672,9 @@ func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_trigger", "Delete")
+	defer end(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state triggerResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -674,7 +685,7 @@ func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	// Delete existing order
 	err := r.client.Delete(ctx, state.ProjectId.ValueString(), state.Id.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCi trigger",
 			"Could not delete trigger, unexpected error: "+err.Error(),
@@ -741,15 +752,6 @@ func isValidEventPreset(eventPreset string) bool {
 	}
 }
 
-func isApiNotFoundError(err error) bool {
-	// This is pseudo-code; replace with your actual API client's error inspection
-	if apiErr, ok := err.(interface{ HTTPStatusCode() int }); ok {
-		return apiErr.HTTPStatusCode() == 404
-	}
-	// Alternatively, check the error message string if the status is not exposed
-	return strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found")
-}
-
 // triggerParametersToMap converts the dynamic `parameters` attribute into the
 // map[string]any the SDK sends to the API, preserving each value's JSON type
 // (string, boolean, or number) so typed pipeline parameters work correctly.
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -15,9 +16,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+	"terraform-provider-circleci/internal/circleci/trigger"
 )
 
 func TestAccTriggerResourceGithub(t *testing.T) {
@@ -511,3 +516,137 @@ resource "circleci_trigger" "test_missing_repo_id" {
 		},
 	})
 }
+
+func TestAccTriggerResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := fakecircle.Repo{ExternalID: "2259"}
+	pd, err := fc.AddPipelineDefinition(fakecircle.NewPipelineDefinition{
+		ProjectID: prj.ID,
+		Name:      "disappears",
+		ConfigSource: fakecircle.ConfigSource{
+			Provider: fakecircle.ProviderGitHubServer,
+			Repo:     repo,
+			FilePath: ".circleci/config.yml",
+		},
+		CheckoutSource: fakecircle.CheckoutSource{
+			Provider: fakecircle.ProviderGitHubServer,
+			Repo:     repo,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + testAccTriggerResourceGithubServerConfig(prj.ID.String(), pd.ID.String(), "2259")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_trigger.test_trigger_github_server", func(ctx context.Context, attrs map[string]string) error {
+					return trigger.NewTriggerService(fc.Client).Delete(ctx, attrs["project_id"], attrs["id"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_trigger.test_trigger_github_server", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// TestAccTriggerResource_updateInPlace checks that changing an updatable
+// attribute patches the trigger rather than replacing it.
+func TestAccTriggerResource_updateInPlace(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "in-place",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "in-place",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := fakecircle.Repo{ExternalID: "2259"}
+	pd, err := fc.AddPipelineDefinition(fakecircle.NewPipelineDefinition{
+		ProjectID: prj.ID,
+		Name:      "in-place",
+		ConfigSource: fakecircle.ConfigSource{
+			Provider: fakecircle.ProviderGitHubServer,
+			Repo:     repo,
+			FilePath: ".circleci/config.yml",
+		},
+		CheckoutSource: fakecircle.CheckoutSource{
+			Provider: fakecircle.ProviderGitHubServer,
+			Repo:     repo,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(eventPreset string, disabled bool) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_trigger" "test_trigger" {
+  project_id                    = %[1]q
+  pipeline_id                   = %[2]q
+  event_source_provider         = "github_server"
+  event_source_repo_external_id = "2259"
+  event_preset                  = %[3]q
+  disabled                      = %[4]t
+}
+`, prj.ID, pd.ID, eventPreset, disabled)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("all-pushes", false),
+				Check:  fc.CheckCallCount("POST", "/projects/*/pipeline-definitions/*/triggers", 1),
+			},
+			{
+				PreConfig: fc.ResetCalls,
+				Config:    config("only-tags", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_trigger.test_trigger", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					fc.CheckCallCount("PATCH", "/projects/*/triggers/*", 1),
+					fc.CheckCallCount("POST", "/projects/*/pipeline-definitions/*/triggers", 0),
+					fc.CheckCallCount("DELETE", "/projects/*/triggers/*", 0),
+					resource.TestCheckResourceAttr("circleci_trigger.test_trigger", "event_preset", "only-tags"),
+					resource.TestCheckResourceAttr("circleci_trigger.test_trigger", "disabled", "true"),
+				),
+			},
+		},
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -20,29 +22,33 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"terraform-provider-circleci/internal/circleci/client"
 	"terraform-provider-circleci/internal/circleci/common"
 	"terraform-provider-circleci/internal/circleci/webhook"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &webhookResource{}
-	_ resource.ResourceWithConfigure   = &webhookResource{}
-	_ resource.ResourceWithImportState = &webhookResource{}
+	_ resource.Resource                     = &webhookResource{}
+	_ resource.ResourceWithConfigure        = &webhookResource{}
+	_ resource.ResourceWithConfigValidators = &webhookResource{}
+	_ resource.ResourceWithImportState      = &webhookResource{}
 )
 
 // webhookResourceModel maps the resource schema.
 type webhookResourceModel struct {
-	Id            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Url           types.String `tfsdk:"url"`
-	VerifyTls     types.Bool   `tfsdk:"verify_tls"`
-	SigningSecret types.String `tfsdk:"signing_secret"`
-	ScopeId       types.String `tfsdk:"scope_id"`
-	ScopeType     types.String `tfsdk:"scope_type"`
-	Events        types.List   `tfsdk:"events"`
-	CreatedAt     types.String `tfsdk:"created_at"`
-	UpdatedAt     types.String `tfsdk:"updated_at"`
+	Id                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Url                    types.String `tfsdk:"url"`
+	VerifyTls              types.Bool   `tfsdk:"verify_tls"`
+	SigningSecret          types.String `tfsdk:"signing_secret"`
+	SigningSecretWo        types.String `tfsdk:"signing_secret_wo"`
+	SigningSecretWoVersion types.Int64  `tfsdk:"signing_secret_wo_version"`
+	ScopeId                types.String `tfsdk:"scope_id"`
+	ScopeType              types.String `tfsdk:"scope_type"`
+	Events                 types.List   `tfsdk:"events"`
+	CreatedAt              types.String `tfsdk:"created_at"`
+	UpdatedAt              types.String `tfsdk:"updated_at"`
 }
 
 // NewWebhookResource is a helper function to simplify the provider implementation.
@@ -94,9 +100,28 @@ func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Default:             booldefault.StaticBool(true),
 			},
 			"signing_secret": schema.StringAttribute{
-				MarkdownDescription: "The secret used to sign webhook payloads.",
-				Required:            true,
+				MarkdownDescription: "The secret used to sign webhook payloads. It is stored in state; use `signing_secret_wo` to keep it out. Exactly one of `signing_secret` or `signing_secret_wo` must be set.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("signing_secret_wo")),
+				},
+			},
+			"signing_secret_wo": schema.StringAttribute{
+				MarkdownDescription: "The secret used to sign webhook payloads, which is never stored in state or plan files. Requires Terraform 1.11 or later. Terraform cannot tell when it changes, so change `signing_secret_wo_version` to send a new secret.",
+				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("signing_secret")),
+				},
+			},
+			"signing_secret_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version for `signing_secret_wo`. Changing it sends the current `signing_secret_wo` to CircleCI.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("signing_secret_wo")),
+				},
 			},
 			"scope_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the scope (project) for which the webhook is configured. Changing this value forces a new resource to be created.",
@@ -132,8 +157,18 @@ func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	}
 }
 
+// ConfigValidators returns the validators that check the resource's configuration as a whole.
+func (r *webhookResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(path.MatchRoot("signing_secret"), path.MatchRoot("signing_secret_wo")),
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_webhook", "Create")
+	defer end(&resp.Diagnostics)
+
 	var plan webhookResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -149,13 +184,19 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	signingSecret, diags := writeOnlyValue(ctx, req.Config, plan.SigningSecret, path.Root("signing_secret_wo"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build the webhook request
 	verifyTls := plan.VerifyTls.ValueBool()
 	newWebhook := webhook.Webhook{
 		Name:          plan.Name.ValueString(),
 		Url:           plan.Url.ValueString(),
 		VerifyTls:     &verifyTls,
-		SigningSecret: plan.SigningSecret.ValueString(),
+		SigningSecret: signingSecret,
 		Scope: common.Scope{
 			Id:   plan.ScopeId.ValueString(),
 			Type: plan.ScopeType.ValueString(),
@@ -189,6 +230,9 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "circleci_webhook", "Read")
+	defer end(&resp.Diagnostics)
+
 	var state webhookResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -205,6 +249,11 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	webhookData, err := r.client.Get(ctx, state.Id.ValueString())
+	if client.IsNotFound(err) {
+		// The webhook was deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read CircleCI webhook with id "+state.Id.ValueString(),
@@ -213,16 +262,6 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// Handle case where webhook was deleted outside of Terraform
-	if webhookData == nil {
-		resp.Diagnostics.AddWarning(
-			"Webhook not found during Read",
-			fmt.Sprintf("Webhook ID %s could not be retrieved from CircleCI. Removing from state.", state.Id.ValueString()),
-		)
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
 	// Convert events to types.List
 	eventsAttributeValues := make([]attr.Value, len(webhookData.Events))
 	for i, event := range webhookData.Events {
@@ -262,6 +301,9 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "circleci_webhook", "Update")
+	defer end(&resp.Diagnostics)
+
 	var plan webhookResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -284,6 +326,12 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	signingSecret, diags := writeOnlyValue(ctx, req.Config, plan.SigningSecret, path.Root("signing_secret_wo"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build the webhook update request
 	// Note: Scope cannot be updated
 	verifyTls := plan.VerifyTls.ValueBool()
@@ -291,7 +339,7 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 		Name:          plan.Name.ValueString(),
 		Url:           plan.Url.ValueString(),
 		VerifyTls:     &verifyTls,
-		SigningSecret: plan.SigningSecret.ValueString(),
+		SigningSecret: signingSecret,
 		Events:        events,
 	}
 
@@ -321,6 +369,9 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "circleci_webhook", "Delete")
+	defer end(&resp.Diagnostics)
+
 	var state webhookResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -329,7 +380,7 @@ func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	err := r.client.Delete(ctx, state.Id.ValueString())
-	if err != nil {
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting CircleCI Webhook",
 			"Could not delete webhook, unexpected error: "+err.Error(),
@@ -4,17 +4,24 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-circleci/internal/circleci/testing/fakecircle"
+	"terraform-provider-circleci/internal/circleci/webhook"
 )
 
 func TestAccWebhookResource(t *testing.T) {
@@ -175,3 +182,118 @@ resource "circleci_webhook" "test_webhook" {
 }
 `, name, scopeId)
 }
+
+func TestAccWebhookResource_disappears(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "disappears",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := fc.ProviderConfig() + testAccWebhookResourceConfig("disappears", prj.ID.String())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: testAccCheckResourceDisappears("circleci_webhook.test_webhook", func(ctx context.Context, attrs map[string]string) error {
+					return webhook.NewWebhookService(fc.Client).Delete(ctx, attrs["id"])
+				}),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("circleci_webhook.test_webhook", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccWebhookResource_writeOnly(t *testing.T) {
+	fc := testAccFakeCircle(t)
+	org, err := fc.AddOrg(fakecircle.NewOrg{
+		Type: fakecircle.TypeCircleCI,
+		Name: "write-only",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prj, err := fc.AddProject(fakecircle.NewProject{
+		OrgID: org.ID,
+		Name:  "write-only",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(name, secret string, version int) string {
+		return fc.ProviderConfig() + fmt.Sprintf(`
+resource "circleci_webhook" "test_webhook" {
+  name                      = %[1]q
+  url                       = "https://example.com/webhook"
+  signing_secret_wo         = %[2]q
+  signing_secret_wo_version = %[3]d
+  scope_id                  = %[4]q
+  scope_type                = "project"
+  events                    = ["workflow-completed"]
+}
+`, name, secret, version, prj.ID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("write-only", "first", 1),
+				Check:  fc.CheckLastBody(http.MethodPost, "/webhook", "signing-secret", "first"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"circleci_webhook.test_webhook",
+						tfjsonpath.New("signing_secret"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"circleci_webhook.test_webhook",
+						tfjsonpath.New("signing_secret_wo"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			// Other updates still send the configured secret.
+			{
+				Config: config("renamed", "first", 1),
+				Check:  fc.CheckLastBody(http.MethodPut, "/webhook/*", "signing-secret", "first"),
+			},
+			{
+				Config: config("renamed", "second", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: config("renamed", "second", 2),
+				Check:  fc.CheckLastBody(http.MethodPut, "/webhook/*", "signing-secret", "second"),
+			},
+		},
+	})
+}
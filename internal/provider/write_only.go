@@ -0,0 +1,26 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// writeOnlyValue returns the secret to send to CircleCI: value when it is
+// set, otherwise the write-only attribute at woPath. Write-only values are
+// null in the plan, so woPath is read from the configuration instead.
+func writeOnlyValue(ctx context.Context, config tfsdk.Config, value types.String, woPath path.Path) (string, diag.Diagnostics) {
+	if !value.IsNull() {
+		return value.ValueString(), nil
+	}
+
+	var wo types.String
+	diags := config.GetAttribute(ctx, woPath, &wo)
+	return wo.ValueString(), diags
+}
@@ -0,0 +1,111 @@
+// Copyright (c) CircleCI
+// SPDX-License-Identifier: MPL-2.0
+
+// Package telemetry exports the provider's OpenTelemetry traces and metrics
+// over OTLP, when the standard OTEL_* environment variables ask for it.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// serviceName is the service.name traces and metrics are reported under,
+// unless OTEL_SERVICE_NAME or OTEL_RESOURCE_ATTRIBUTES set another.
+const serviceName = "terraform-provider-circleci"
+
+// Setup installs global tracer and meter providers that export over
+// OTLP/HTTP. Traces are exported when OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set, and metrics when
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_METRICS_ENDPOINT is. The
+// exporters read the rest of their settings, such as headers, from the
+// environment too. OTEL_SDK_DISABLED=true, OTEL_TRACES_EXPORTER=none and
+// OTEL_METRICS_EXPORTER=none turn telemetry off.
+//
+// Call shutdown before the process exits to flush what is left.
+func Setup(ctx context.Context, version string) (shutdown func(context.Context) error, err error) {
+	var shutdowns []func(context.Context) error
+	shutdown = func(ctx context.Context) error {
+		var errs []error
+		for _, f := range shutdowns {
+			errs = append(errs, f(ctx))
+		}
+		return errors.Join(errs...)
+	}
+
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return shutdown, nil
+	}
+	traces := enabled("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_TRACES_EXPORTER")
+	metrics := enabled("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "OTEL_METRICS_EXPORTER")
+	if !traces && !metrics {
+		return shutdown, nil
+	}
+
+	if p := protocol(); p != "" && p != "http/protobuf" {
+		return shutdown, fmt.Errorf("unsupported OTLP protocol %q: only http/protobuf is supported", p)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return shutdown, fmt.Errorf("creating telemetry resource: %w", err)
+	}
+
+	if traces {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return shutdown, fmt.Errorf("creating OTLP trace exporter: %w", err)
+		}
+		tp := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+		shutdowns = append(shutdowns, tp.Shutdown)
+		otel.SetTracerProvider(tp)
+	}
+
+	if metrics {
+		exporter, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			return shutdown, fmt.Errorf("creating OTLP metric exporter: %w", err)
+		}
+		mp := sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+			sdkmetric.WithResource(res),
+		)
+		shutdowns = append(shutdowns, mp.Shutdown)
+		otel.SetMeterProvider(mp)
+	}
+
+	return shutdown, nil
+}
+
+// enabled reports whether a signal should be exported: its endpoint, or the
+// shared one, is set and its exporter is not "none".
+func enabled(endpointVar, exporterVar string) bool {
+	if strings.EqualFold(os.Getenv(exporterVar), "none") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv(endpointVar) != ""
+}
+
+func protocol() string {
+	return os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+}
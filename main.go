@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	"terraform-provider-circleci/internal/provider"
+	"terraform-provider-circleci/internal/telemetry"
 )
 
 var (
@@ -29,7 +30,19 @@ func main() {
 		Address: "registry.terraform.io/circleci/circleci",
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	shutdownTelemetry, err := telemetry.Setup(ctx, version)
+	if err != nil {
+		// Telemetry is optional, so carry on without it.
+		log.Printf("[WARN] OpenTelemetry is not set up: %s", err)
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	if err := shutdownTelemetry(ctx); err != nil {
+		log.Printf("[WARN] Flushing OpenTelemetry data: %s", err)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())